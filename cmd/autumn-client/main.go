@@ -16,6 +16,7 @@ import (
 
 	"github.com/journeymidnight/autumn/manager/pmclient"
 	"github.com/journeymidnight/autumn/manager/smclient"
+	"github.com/journeymidnight/autumn/proto/pspb"
 	"github.com/journeymidnight/autumn/utils"
 	"github.com/journeymidnight/autumn/xlog"
 	_ "github.com/journeymidnight/autumn/xlog"
@@ -196,6 +197,8 @@ func benchmark(pmAddrs []string, op BenchType, threadNum int, duration int, size
 func bootstrap(c *cli.Context) error {
 	smAddrs := utils.SplitAndTrim(c.String("smAddr"), ",")
 	pmAddrs := utils.SplitAndTrim(c.String("pmAddr"), ",")
+	replicates := uint32(c.Int("replicates"))
+	quorum := c.Bool("quorum")
 
 	smc := smclient.NewSMClient(smAddrs)
 	if err := smc.Connect(); err != nil {
@@ -214,16 +217,20 @@ func bootstrap(c *cli.Context) error {
 	}
 	//choose the first one
 
-	log, _, err := smc.CreateStream(context.Background())
+	log, _, err := smc.CreateStream(context.Background(), replicates, quorum)
 	if err != nil {
 		return err
 	}
-	row, _, err := smc.CreateStream(context.Background())
+	row, _, err := smc.CreateStream(context.Background(), replicates, quorum)
+	if err != nil {
+		return err
+	}
+	blob, _, err := smc.CreateStream(context.Background(), replicates, quorum)
 	if err != nil {
 		return err
 	}
 
-	partID, err := pmc.Bootstrap(log.StreamID, row.StreamID, pss[0].PSID)
+	partID, err := pmc.Bootstrap(log.StreamID, row.StreamID, blob.StreamID, pss[0].PSID)
 	if err != nil {
 		return err
 	}
@@ -231,6 +238,33 @@ func bootstrap(c *cli.Context) error {
 	return nil
 }
 
+//options overrides options of ps for a partition, they are used when the partition is opened
+func options(c *cli.Context) error {
+	pmAddrs := utils.SplitAndTrim(c.String("pmAddr"), ",")
+	partID := c.Uint64("part")
+	if partID == 0 {
+		return errors.New("--part is required")
+	}
+	pmc := pmclient.NewAutumnPMClient(pmAddrs)
+	if err := pmc.Connect(); err != nil {
+		return err
+	}
+	opts := &pspb.PartitionOptions{
+		MemtableSize:    c.Uint64("memtable-size") << 20,
+		WriteChCapacity: uint32(c.Uint("write-ch-capacity")),
+		MaxReplaySize:   c.Uint64("max-replay-size") << 20,
+		FlushInterval:   int64(c.Duration("flush-interval") / time.Second),
+		TableBlockSize:  uint32(c.Uint("table-block-size")) << 10,
+		ValueThreshold:  uint32(c.Uint("value-threshold")),
+		MixedBlockSize:  uint32(c.Uint("mixed-block-size")) << 10,
+	}
+	if err := pmc.SetPartitionOptions(partID, opts); err != nil {
+		return err
+	}
+	fmt.Printf("options of partition %d: %+v\n", partID, *opts)
+	return nil
+}
+
 func del(c *cli.Context) error {
 	pmAddr := utils.SplitAndTrim(c.String("pmAddr"), ",")
 	client := NewAutumnLib(pmAddr)
@@ -356,7 +390,7 @@ func alloc(c *cli.Context) error {
 	if err := client.Connect(); err != nil {
 		return err
 	}
-	s, e, err := client.CreateStream(context.Background())
+	s, e, err := client.CreateStream(context.Background(), 3, false)
 	if err != nil {
 		return err
 	}
@@ -383,14 +417,33 @@ func main() {
 
 		{
 			Name:  "bootstrap",
-			Usage: "bootstrap --pmAddr <addrs> --smAddr <addrs>",
+			Usage: "bootstrap --pmAddr <addrs> --smAddr <addrs> [--replicates 3] [--quorum]",
 			Flags: []cli.Flag{
 				&cli.StringFlag{Name: "smAddr", Value: "127.0.0.1:3401"},
 				&cli.StringFlag{Name: "pmAddr", Value: "127.0.0.1:3401"},
+				&cli.IntFlag{Name: "replicates", Value: 3},
+				&cli.BoolFlag{Name: "quorum", Value: false},
 			},
 			Action: bootstrap,
 		},
 
+		{
+			Name:  "options",
+			Usage: "options --pmAddr <addrs> --part <id> [--memtable-size <MB>] [--value-threshold <bytes>] ..., 0 means the option of ps",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "pmAddr", Value: "127.0.0.1:3401"},
+				&cli.Uint64Flag{Name: "part"},
+				&cli.Uint64Flag{Name: "memtable-size", Usage: "MB"},
+				&cli.UintFlag{Name: "write-ch-capacity"},
+				&cli.Uint64Flag{Name: "max-replay-size", Usage: "MB"},
+				&cli.DurationFlag{Name: "flush-interval"},
+				&cli.UintFlag{Name: "table-block-size", Usage: "KB"},
+				&cli.UintFlag{Name: "value-threshold", Usage: "bytes"},
+				&cli.UintFlag{Name: "mixed-block-size", Usage: "KB"},
+			},
+			Action: options,
+		},
+
 		{
 			Name:  "put",
 			Usage: "put --pmAddr <addrs> <KEY> <FILE>",
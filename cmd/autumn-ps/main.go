@@ -1,16 +1,22 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/journeymidnight/autumn/partitionserver"
+	"github.com/journeymidnight/autumn/rangepartition/y"
 	"github.com/journeymidnight/autumn/utils"
 	"github.com/journeymidnight/autumn/xlog"
+	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
+	"sigs.k8s.io/yaml"
 )
 
 func main() {
@@ -19,10 +25,24 @@ func main() {
 	var dir string
 	var smAddr string
 	var pmAddr string
+	var compression string
+	var masterKeyFile string
+	var logExtentSize, rowExtentSize uint
+	var blockCacheSize, valueCacheSize, indexCacheSize uint
+	var gcDiscardRatio float64
+	var maxReplaySize uint
+	var flushInterval time.Duration
+	var memtableSize, writeChCapacity uint
+	var tableBlockSize, valueThreshold, mixedBlockSize uint
 
 	app := &cli.App{
 		HelpName: "",
+		Before:   loadConfig,
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "config",
+				Usage: "yaml file of flags below, e.g. \"memtable-size: 64\", flags in command line take precedence",
+			},
 			&cli.StringFlag{
 				Name:        "listen",
 				Usage:       "ps grpc listen url",
@@ -45,6 +65,95 @@ func main() {
 				Destination: &pmAddr,
 				Required:    true,
 			},
+			&cli.StringFlag{
+				Name:        "compression",
+				Usage:       "compression of table and log blocks: none or snappy",
+				Value:       "snappy",
+				Destination: &compression,
+			},
+			&cli.StringFlag{
+				Name:        "master-key-file",
+				Usage:       "file of master keys, each line is \"{ID} {hex of 32 bytes}\", empty means no encryption",
+				Destination: &masterKeyFile,
+			},
+			&cli.UintFlag{
+				Name:        "log-extent-size",
+				Usage:       "max extent size of log streams in MB",
+				Value:       1024,
+				Destination: &logExtentSize,
+			},
+			&cli.UintFlag{
+				Name:        "row-extent-size",
+				Usage:       "max extent size of row streams in MB",
+				Value:       256,
+				Destination: &rowExtentSize,
+			},
+			&cli.UintFlag{
+				Name:        "block-cache-size",
+				Usage:       "size of block cache shared by all partitions in MB, 0 means no cache",
+				Value:       256,
+				Destination: &blockCacheSize,
+			},
+			&cli.UintFlag{
+				Name:        "value-cache-size",
+				Usage:       "size of cache for big values in MB, 0 means no cache",
+				Value:       0,
+				Destination: &valueCacheSize,
+			},
+			&cli.UintFlag{
+				Name:        "index-cache-size",
+				Usage:       "memory limit of table index and bloom filter in MB, 0 means no limit",
+				Value:       64,
+				Destination: &indexCacheSize,
+			},
+			&cli.Float64Flag{
+				Name:        "gc-discard-ratio",
+				Usage:       "extents of value log are reclaimed when discarded bytes are more than this ratio, 0 disables gc",
+				Value:       0.5,
+				Destination: &gcDiscardRatio,
+			},
+			&cli.UintFlag{
+				Name:        "max-replay-size",
+				Usage:       "memtable is flushed when log written after last flush is more than this size in MB, 0 means no limit",
+				Value:       256,
+				Destination: &maxReplaySize,
+			},
+			&cli.DurationFlag{
+				Name:        "flush-interval",
+				Usage:       "memtable is flushed when it is older than this, 0 means no limit",
+				Value:       10 * time.Minute,
+				Destination: &flushInterval,
+			},
+			&cli.UintFlag{
+				Name:        "memtable-size",
+				Usage:       "size of memtable in MB",
+				Value:       64,
+				Destination: &memtableSize,
+			},
+			&cli.UintFlag{
+				Name:        "write-ch-capacity",
+				Usage:       "capacity of write channel of each partition",
+				Value:       64,
+				Destination: &writeChCapacity,
+			},
+			&cli.UintFlag{
+				Name:        "table-block-size",
+				Usage:       "size of data blocks of tables in KB",
+				Value:       64,
+				Destination: &tableBlockSize,
+			},
+			&cli.UintFlag{
+				Name:        "value-threshold",
+				Usage:       "values bigger than this size in bytes are written to blob stream",
+				Value:       1024,
+				Destination: &valueThreshold,
+			},
+			&cli.UintFlag{
+				Name:        "mixed-block-size",
+				Usage:       "size of blocks of small values in log stream in KB",
+				Value:       4,
+				Destination: &mixedBlockSize,
+			},
 		},
 	}
 
@@ -60,6 +169,31 @@ func main() {
 	//
 	ps := partitionserver.NewPartitionServer(smAddrs, pmAddrs, dir, "127.0.0.1:9951")
 
+	ct, err := y.ParseCompression(compression)
+	utils.Check(err)
+	//uint32 of bytes overflows
+	if logExtentSize > 2048 || rowExtentSize > 2048 {
+		panic("extent size must be in (0, 2048] MB")
+	}
+	opt := partitionserver.DefaultOptions()
+	opt.LogExtentSize = uint32(logExtentSize << 20)
+	opt.RowExtentSize = uint32(rowExtentSize << 20)
+	opt.BlockCacheSize = int64(blockCacheSize) << 20
+	opt.ValueCacheSize = int64(valueCacheSize) << 20
+	opt.IndexCacheSize = int64(indexCacheSize) << 20
+	opt.GCDiscardRatio = gcDiscardRatio
+	opt.MasterKeyFile = masterKeyFile
+	//options of partitions, could be overridden by PM for each partition
+	opt.Partition.MaxSkipList = int64(memtableSize) << 20
+	opt.Partition.WriteChCapacity = int(writeChCapacity)
+	opt.Partition.MaxReplaySize = uint64(maxReplaySize) << 20
+	opt.Partition.FlushInterval = flushInterval
+	opt.Partition.Table.BlockSize = uint32(tableBlockSize) << 10
+	opt.Partition.Table.Compression = ct
+	opt.Partition.Stream.ValueThreshold = uint32(valueThreshold)
+	opt.Partition.Stream.MaxMixedBlockSize = uint32(mixedBlockSize) << 10
+	utils.Check(ps.SetOptions(opt))
+
 	ps.Init()
 
 	utils.Check(ps.ServeGRPC())
@@ -81,3 +215,33 @@ func main() {
 	}
 
 }
+
+//loadConfig sets flags which are not in command line from the yaml file of --config
+func loadConfig(c *cli.Context) error {
+	file := c.String("config")
+	if file == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var flags map[string]interface{}
+	//keep integers as they are
+	useNumber := func(d *json.Decoder) *json.Decoder {
+		d.UseNumber()
+		return d
+	}
+	if err = yaml.Unmarshal(data, &flags, useNumber); err != nil {
+		return errors.Wrapf(err, "parse %s", file)
+	}
+	for name, value := range flags {
+		if name == "config" || c.IsSet(name) {
+			continue
+		}
+		if err = c.Set(name, fmt.Sprint(value)); err != nil {
+			return errors.Wrapf(err, "%s in %s", name, file)
+		}
+	}
+	return nil
+}
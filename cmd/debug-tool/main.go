@@ -44,6 +44,7 @@ extents/7
 nodes/1
 nodes/2
 nodes/3
+gcExtents/8
 streams/4
 streams/6
 */
@@ -84,7 +85,7 @@ func receiveData(client *clientv3.Client) []KV {
 				Value: fmt.Sprintf("%+v", x),
 			}
 			data = append(data, d)
-		} else if strings.HasPrefix(string(kv.Key), "extents") {
+		} else if strings.HasPrefix(string(kv.Key), "extents") || strings.HasPrefix(string(kv.Key), "gcExtents") {
 			var x pb.ExtentInfo
 			if err := x.Unmarshal(kv.Value); err != nil {
 				panic(err)
@@ -247,8 +248,19 @@ func parseParts(kv *mvccpb.KeyValue) KV {
 		ret.Key = string(kv.Key)
 		ret.Value = fmt.Sprintf("%+v", tables.Locs)
 	case "discard":
+		var stats pspb.DiscardStats
+		if err = stats.Unmarshal(kv.Value); err != nil {
+			panic(err)
+		}
+		ret.Key = string(kv.Key)
+		ret.Value = fmt.Sprintf("%+v", stats.Discard)
+	case "options":
+		var opts pspb.PartitionOptions
+		if err = opts.Unmarshal(kv.Value); err != nil {
+			panic(err)
+		}
 		ret.Key = string(kv.Key)
-		ret.Value = fmt.Sprintf("%+v", kv.Value)
+		ret.Value = fmt.Sprintf("%+v", opts)
 	case "parent":
 		ret.Key = string(kv.Key)
 		ret.Value = fmt.Sprintf("%+v", binary.BigEndian.Uint64(kv.Value))
@@ -17,6 +17,9 @@ func main() {
 
 	var listen string
 	var dir string
+	var journal string
+	var checksum string
+	var scrubRate uint64
 	var ID uint64
 	app := &cli.App{
 		HelpName: "",
@@ -33,6 +36,23 @@ func main() {
 				Destination: &dir,
 				Required:    true,
 			},
+			&cli.StringFlag{
+				Name:        "journal",
+				Usage:       "journal dir on SSD, optional",
+				Destination: &journal,
+			},
+			&cli.StringFlag{
+				Name:        "checksum",
+				Usage:       "checksum of new extents: adler32, crc32c or xxhash64",
+				Value:       "crc32c",
+				Destination: &checksum,
+			},
+			&cli.Uint64Flag{
+				Name:        "scrub-rate",
+				Usage:       "max read speed of background scrubber in MB/s, 0 disables scrubber",
+				Value:       32,
+				Destination: &scrubRate,
+			},
 			&cli.Uint64Flag{
 				Name:        "ID",
 				Destination: &ID,
@@ -48,10 +68,14 @@ func main() {
 	xlog.InitLog([]string{fmt.Sprintf("node_%d.log", ID)}, zap.DebugLevel)
 
 	//FIXME: sm address
-	node := node.NewExtentNode(dir, listen, []string{"127.0.0.1:3401"})
+	node := node.NewExtentNode(dir, journal, listen, []string{"127.0.0.1:3401"})
+
+	err := node.SetChecksum(checksum)
+	utils.Check(err)
+	node.SetScrubRate(scrubRate << 20)
 
-	//open all extent files
-	err := node.LoadExtents()
+	//open all extent files, replay journal
+	err = node.LoadExtents()
 	utils.Check(err)
 
 	//open 'node_id' file, if 'node_id' doesn't exist. register to sm
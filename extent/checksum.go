@@ -0,0 +1,168 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless  by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extent
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+
+	"github.com/cespare/xxhash"
+	"github.com/journeymidnight/autumn/utils"
+	"github.com/pkg/errors"
+)
+
+type ChecksumType byte
+
+const (
+	//ChecksumLegacy is adler32 in version 0 block header, extents created before
+	//versioned block header use it
+	ChecksumLegacy ChecksumType = iota
+	ChecksumCRC32C
+	ChecksumXXHash64
+
+	DefaultChecksum = ChecksumCRC32C
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+func ParseChecksumType(s string) (ChecksumType, error) {
+	switch s {
+	case "adler32":
+		return ChecksumLegacy, nil
+	case "crc32c":
+		return ChecksumCRC32C, nil
+	case "xxhash64":
+		return ChecksumXXHash64, nil
+	default:
+		return 0, errors.Errorf("unknown checksum type %s", s)
+	}
+}
+
+func (ct ChecksumType) String() string {
+	switch ct {
+	case ChecksumLegacy:
+		return "adler32"
+	case ChecksumCRC32C:
+		return "crc32c"
+	case ChecksumXXHash64:
+		return "xxhash64"
+	default:
+		return "unknown"
+	}
+}
+
+func (ct ChecksumType) size() int {
+	if ct == ChecksumXXHash64 {
+		return 8
+	}
+	return 4
+}
+
+func (ct ChecksumType) sum(data []byte) uint64 {
+	switch ct {
+	case ChecksumCRC32C:
+		return uint64(crc32.Checksum(data, crc32cTable))
+	case ChecksumXXHash64:
+		return xxhash.Sum64(data)
+	default:
+		return uint64(utils.AdlerCheckSum(data))
+	}
+}
+
+/*
+version 0 block header(ChecksumLegacy):
++-------------------+----------------------+--------------------+----------+
+| adler32 (4 bytes) | uvarint(blockLength) | uvarint(len(user)) | userData |
++-------------------+----------------------+--------------------+----------+
+
+version 1 block header:
++----------------------+-----------------------+----------------------+--------------------+----------+
+| checksumType(1 byte) | checkSum(4 or 8 bytes)| uvarint(blockLength) | uvarint(len(user)) | userData |
++----------------------+-----------------------+----------------------+--------------------+----------+
+*/
+type blockHeader struct {
+	checksumType ChecksumType
+	checkSum     uint64
+	blockLength  uint32
+	userData     []byte
+}
+
+func (h *blockHeader) marshal(buf []byte) error {
+	sz := 0
+	if h.checksumType == ChecksumLegacy {
+		binary.BigEndian.PutUint32(buf, uint32(h.checkSum))
+		sz += 4
+	} else {
+		buf[0] = byte(h.checksumType)
+		sz++
+		if h.checksumType.size() == 8 {
+			binary.BigEndian.PutUint64(buf[sz:], h.checkSum)
+		} else {
+			binary.BigEndian.PutUint32(buf[sz:], uint32(h.checkSum))
+		}
+		sz += h.checksumType.size()
+	}
+	sz += binary.PutUvarint(buf[sz:], uint64(h.blockLength))
+	if len(h.userData) > 0 {
+		sz += binary.PutUvarint(buf[sz:], uint64(len(h.userData)))
+		if sz+len(h.userData) > len(buf) {
+			return errors.Errorf("user data is too big %d", len(h.userData))
+		}
+		copy(buf[sz:], h.userData)
+	}
+	return nil
+}
+
+//unmarshal parses version 1 block header, if legacy is true, parses version 0 block header
+func (h *blockHeader) unmarshal(buf []byte, legacy bool) error {
+	index := 0
+	if legacy {
+		h.checksumType = ChecksumLegacy
+		h.checkSum = uint64(binary.BigEndian.Uint32(buf))
+		index += 4
+	} else {
+		h.checksumType = ChecksumType(buf[0])
+		index++
+		switch h.checksumType {
+		case ChecksumCRC32C:
+			h.checkSum = uint64(binary.BigEndian.Uint32(buf[index:]))
+		case ChecksumXXHash64:
+			h.checkSum = binary.BigEndian.Uint64(buf[index:])
+		default:
+			return errors.Errorf("unknown checksum type %d", h.checksumType)
+		}
+		index += h.checksumType.size()
+	}
+	blockLength, n := binary.Uvarint(buf[index:])
+	index += n
+	ulen, n := binary.Uvarint(buf[index:])
+	index += n
+	if int(ulen)+index > len(buf) {
+		return errors.Errorf("user data is too big %d", int(ulen)+index)
+	}
+	h.blockLength = uint32(blockLength)
+	h.userData = nil
+	if ulen > 0 {
+		h.userData = buf[index : index+int(ulen)]
+	}
+	return nil
+}
+
+func (h *blockHeader) verify(data []byte) error {
+	if sum := h.checksumType.sum(data); sum != h.checkSum {
+		return errors.Errorf("%s checksum not match, %d vs %d", h.checksumType, sum, h.checkSum)
+	}
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"math"
 	"os"
 	"sync/atomic"
@@ -73,6 +74,8 @@ type Extent struct {
 	ID           uint64
 	fileName     string
 	file         *os.File
+	checksumType ChecksumType //checksum of new blocks, ChecksumLegacy means version 0 block header
+	mmap         atomic.Value //*mapping, read-only mapping of sealed extent
 	//FIXME: add SSD Chanel
 
 }
@@ -81,9 +84,16 @@ const (
 	extentMagicNumber = "EXTENTXX"
 )
 
+/*
+extent header:
+magicNumber(8 bytes) | ID(8 bytes) | version(1 byte) | checksumType(1 byte)
+extents created before versioned block header have version 0
+*/
 type extentHeader struct {
-	magicNumber []byte
-	ID          uint64
+	magicNumber  []byte
+	ID           uint64
+	version      byte
+	checksumType ChecksumType
 }
 
 func newExtentHeader(ID uint64) *extentHeader {
@@ -91,9 +101,18 @@ func newExtentHeader(ID uint64) *extentHeader {
 		ID: ID,
 	}
 	eh.magicNumber = []byte(extentMagicNumber)
+	eh.setChecksumType(DefaultChecksum)
 	return &eh
 }
 
+func (eh *extentHeader) setChecksumType(ct ChecksumType) {
+	eh.checksumType = ct
+	eh.version = 1
+	if ct == ChecksumLegacy {
+		eh.version = 0
+	}
+}
+
 func (eh *extentHeader) Size() uint32 {
 	return 16
 }
@@ -102,6 +121,8 @@ func (eh *extentHeader) Marshal(w io.Writer) error {
 	var buf [512]byte
 	copy(buf[:], eh.magicNumber[:])
 	binary.BigEndian.PutUint64(buf[8:], eh.ID)
+	buf[16] = eh.version
+	buf[17] = byte(eh.checksumType)
 
 	n, err := w.Write(buf[:])
 	if n != 512 || err != nil {
@@ -122,15 +143,87 @@ func (eh *extentHeader) Unmarshal(r io.Reader) error {
 	eh.magicNumber = []byte(extentMagicNumber)
 	copy(eh.magicNumber, buf[:8])
 	eh.ID = binary.BigEndian.Uint64(buf[8:16])
+	eh.version = buf[16]
+	eh.checksumType = ChecksumLegacy
+	if eh.version > 0 {
+		eh.checksumType = ChecksumType(buf[17])
+	}
 	return nil
 }
 
+/*
+seal footer(512 bytes), written after the last block when extent is sealed:
+magicNumber(8 bytes) | sealSize(8 bytes) | crc32c of the first 16 bytes(4 bytes)
+footer is not included in commitLength
+*/
+const (
+	sealMagicNumber = "SEALEDXX"
+)
+
+func writeSealFooter(fileName string, sealSize uint32) error {
+	var buf [512]byte
+	copy(buf[:], sealMagicNumber)
+	binary.BigEndian.PutUint64(buf[8:], uint64(sealSize))
+	binary.BigEndian.PutUint32(buf[16:], crc32.Checksum(buf[:16], crc32cTable))
+
+	//extent file is opened with O_APPEND or read-only, so open it again
+	f, err := os.OpenFile(fileName, os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err = f.WriteAt(buf[:], int64(sealSize)); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+//readSealFooter returns sealSize if the last 512 bytes of file is a valid footer
+func readSealFooter(f *os.File, size int64) (uint32, bool) {
+	//extent header + footer
+	if size < 1024 {
+		return 0, false
+	}
+	var buf [512]byte
+	if _, err := f.ReadAt(buf[:], size-512); err != nil {
+		return 0, false
+	}
+	if bytes.Compare(buf[:8], []byte(sealMagicNumber)) != 0 {
+		return 0, false
+	}
+	if crc32.Checksum(buf[:16], crc32cTable) != binary.BigEndian.Uint32(buf[16:]) {
+		return 0, false
+	}
+	sealSize := binary.BigEndian.Uint64(buf[8:])
+	if sealSize != uint64(size-512) {
+		return 0, false
+	}
+	//the last block may carry the same bytes, the footer written by Seal is right after the last block
+	eh := newExtentHeader(0)
+	if err := eh.Unmarshal(io.NewSectionReader(f, 0, 512)); err != nil {
+		return 0, false
+	}
+	if scanBlockHeaders(f, uint32(sealSize), eh.checksumType) != uint32(sealSize) {
+		return 0, false
+	}
+	return uint32(sealSize), true
+}
+
 func CreateExtent(fileName string, ID uint64) (*Extent, error) {
+	return CreateExtentWithChecksum(fileName, ID, DefaultChecksum)
+}
+
+//CreateExtentWithChecksum creates an extent whose blocks are protected by checksumType
+func CreateExtentWithChecksum(fileName string, ID uint64, checksumType ChecksumType) (*Extent, error) {
+	if checksumType.String() == "unknown" {
+		return nil, errors.Errorf("unknown checksum type %d", checksumType)
+	}
 	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0644)
 	if err != nil {
 		return nil, err
 	}
 	extentHeader := newExtentHeader(ID)
+	extentHeader.setChecksumType(checksumType)
 	if err = extentHeader.Marshal(f); err != nil {
 		return nil, err
 	}
@@ -142,38 +235,54 @@ func CreateExtent(fileName string, ID uint64) (*Extent, error) {
 		commitLength: 512,
 		fileName:     fileName,
 		file:         f,
+		checksumType: checksumType,
 	}, nil
 
 }
 
 func OpenExtent(fileName string) (*Extent, error) {
 
-	d, err := xattr.LGet(fileName, "seal")
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	info, _ := file.Stat()
+	if info.Size() > math.MaxUint32 {
+		file.Close()
+		return nil, errors.Errorf("check extent file, the extent file is too big")
+	}
+	//sealed extent has a footer, no need to scan blocks
+	sealSize, sealed := readSealFooter(file, info.Size())
+	if !sealed {
+		//extents sealed by old version only have xattr
+		d, err := xattr.LGet(fileName, "seal")
+		if err == nil && bytes.Compare(d, []byte("true")) == 0 {
+			sealed = true
+			sealSize = uint32(info.Size())
+		}
+	}
 
 	//if extent is a sealed extent
-	if err == nil && bytes.Compare(d, []byte("true")) == 0 {
-		file, err := os.Open(fileName)
-		if err != nil {
-			return nil, err
-		}
-		info, _ := file.Stat()
-		if info.Size() > math.MaxUint32 {
-			return nil, errors.Errorf("check extent file, the extent file is too big")
-		}
+	if sealed {
 		//check extent header
 
 		eh := newExtentHeader(0)
 		if err = eh.Unmarshal(file); err != nil {
+			file.Close()
 			return nil, err
 		}
 
-		return &Extent{
+		ex := &Extent{
 			isSeal:       1,
-			commitLength: uint32(info.Size()),
+			commitLength: sealSize,
 			fileName:     fileName,
 			file:         file,
 			ID:           eh.ID,
-		}, nil
+			checksumType: eh.checksumType,
+		}
+		//if mmap fails, fallback to ReadAt
+		ex.mapFile()
+		return ex, nil
 	}
 
 	/*
@@ -182,39 +291,41 @@ func OpenExtent(fileName string) (*Extent, error) {
 		是检查Offset的Append, 如果这3个任何一个失败, client就找sm把extent变成:truncate/Sealed.
 		2. 由于写入是多个sector,也会有一致性问题:
 		   2a. 如果存在SSD journal, 需要从SSD journal恢复成功的extent(因为SSD写入成功后,就已经返回OK了, 需要确保已经返回的数据的原子性)
+		   node在OpenExtent之后replay journal, 见node/journal.go
 		   2b. 如果没有SSD的存在,比如要写入4个sector, 但是只写入的2个, 只有metaBlock和一部分block, 需要truncate到之前的版本, 保证
 		   原子性
 	*/
 
-	//replay the extent file, 这里的replay重新读了所有文件内容, 也许不需要,
-	//前面可以只读block的meta, 直到最后一个block再读文件数据, 检查checksum
+	file.Close()
+
+	//replay the extent file, 只读block头, 直到最后一个block再读文件数据, 检查checksum.
+	//append在sync之后才返回, 下一次append之前的数据已经落盘, 所以只有最后一个block可能写了一半.
+	//前面block的静默损坏由scrubber检查, 见node/scrubber.go
 	f, err := os.OpenFile(fileName, os.O_APPEND|os.O_RDWR, 0644)
 	if err != nil {
 		return nil, err
 	}
-	info, _ := f.Stat()
+	info, _ = f.Stat()
 	currentSize := uint32(info.Size())
 
 	eh := newExtentHeader(0)
 	err = eh.Unmarshal(f)
 	if err != nil {
+		f.Close()
 		return nil, err
 	}
-	offset := uint32(512)
 
-	for offset < currentSize {
-		b, err := readBlock(f)
-		if err != nil {
-			//this block is corrupt, so, truncate extent to current offset
-			if err = f.Truncate(int64(offset)); err != nil {
-				return nil, err
-			}
-			if err = f.Sync(); err != nil {
-				return nil, err
-			}
-			break
+	offset := scanBlockHeaders(f, currentSize, eh.checksumType)
+	if offset < currentSize {
+		//the tail is corrupt, so, truncate extent to the end of last valid block
+		if err = f.Truncate(int64(offset)); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if err = f.Sync(); err != nil {
+			f.Close()
+			return nil, err
 		}
-		offset += b.BlockLength + 512
 	}
 
 	return &Extent{
@@ -223,9 +334,41 @@ func OpenExtent(fileName string) (*Extent, error) {
 		fileName:     fileName,
 		file:         f,
 		ID:           eh.ID,
+		checksumType: eh.checksumType,
 	}, nil
 }
 
+//scanBlockHeaders walks block headers from 512, and verifies checksum of the last block,
+//returns the end of last valid block
+func scanBlockHeaders(f *os.File, size uint32, checksumType ChecksumType) uint32 {
+	var buf [512]byte
+	offset := uint32(512)
+	last := uint32(0) //offset of the last block
+	for uint64(offset)+512 <= uint64(size) {
+		if _, err := f.ReadAt(buf[:], int64(offset)); err != nil {
+			break
+		}
+		var h blockHeader
+		if err := h.unmarshal(buf[:], checksumType == ChecksumLegacy); err != nil {
+			break
+		}
+		end := uint64(offset) + 512 + uint64(h.blockLength)
+		if !align(uint64(h.blockLength)) || end > uint64(size) {
+			break
+		}
+		last = offset
+		offset = uint32(end)
+	}
+	if last == 0 {
+		return offset
+	}
+	r := io.NewSectionReader(f, int64(last), int64(offset-last))
+	if _, _, err := readBlockData(r, checksumType); err != nil {
+		return last
+	}
+	return offset
+}
+
 //support multple threads
 //limit max read size
 type extentBlockReader struct {
@@ -259,21 +402,111 @@ func (r *extentBlockReader) Read(p []byte) (n int, err error) {
 func (ex *Extent) Seal(commit uint32) error {
 	ex.Lock()
 	defer ex.Unlock()
-	atomic.StoreInt32(&ex.isSeal, 1)
 
 	currentLength := ex.commitLength
 	if currentLength < commit {
-		return errors.Errorf("commit is less than current commit length")
-	} else if currentLength > commit {
-		ex.file.Truncate(int64(commit))
+		//keep it unsealed, so missing blocks could be recovered from other replicates
+		return errors.Errorf("extent %d is shorter than seal size, %d vs %d", ex.ID, currentLength, commit)
+	}
+	if ex.IsSeal() && currentLength == commit {
+		return nil
+	}
+
+	//sealed extent is opened read-only and mapped, so unmap it and truncate by name.
+	//readers must release the mapping before truncate, or they get SIGBUS.
+	//truncate also removes the old footer
+	ex.unmapFile(true)
+	if err := os.Truncate(ex.fileName, int64(commit)); err != nil {
+		return err
+	}
+	atomic.StoreUint32(&ex.commitLength, commit)
+	if err := writeSealFooter(ex.fileName, commit); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&ex.isSeal, 1)
+
+	//sealed extent is immutable, read it from mmap
+	ex.mapFile()
+	return nil
+
+}
+
+//mapping is a refcounted mmap of sealed extent, the extent holds one reference and every
+//reader holds one until it does not use the data. it is unmapped when refs drops to 0
+type mapping struct {
+	data     []byte
+	refs     int32 //atomic
+	unmapped chan struct{}
+}
+
+func newMapping(data []byte) *mapping {
+	return &mapping{
+		data:     data,
+		refs:     1,
+		unmapped: make(chan struct{}),
+	}
+}
+
+//acquire returns false if the mapping is being unmapped
+func (m *mapping) acquire() bool {
+	for {
+		refs := atomic.LoadInt32(&m.refs)
+		if refs <= 0 {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&m.refs, refs, refs+1) {
+			return true
+		}
+	}
+}
+
+//release is a no-op if m is nil, so readers of unsealed extents could call it too
+func (m *mapping) release() {
+	if m == nil {
+		return
+	}
+	if atomic.AddInt32(&m.refs, -1) == 0 {
+		munmap(m.data)
+		close(m.unmapped)
 	}
+}
 
-	if err := xattr.FSet(ex.file, "seal", []byte("true")); err != nil {
+//mapFile maps the sealed extent into memory, caller must make sure the extent is sealed
+func (ex *Extent) mapFile() error {
+	if ex.mapped() != nil {
+		return nil
+	}
+	data, err := mmap(ex.file, int(ex.CommitLength()))
+	if err != nil {
 		return err
 	}
+	ex.mmap.Store(newMapping(data))
+	return nil
+}
+
+func (ex *Extent) mapped() *mapping {
+	m, _ := ex.mmap.Load().(*mapping)
+	return m
+}
 
+//acquireMapping returns the mapping with a reference held, or nil if extent is not mapped
+func (ex *Extent) acquireMapping() *mapping {
+	if m := ex.mapped(); m != nil && m.acquire() {
+		return m
+	}
 	return nil
+}
 
+//unmapFile drops the extent's reference of mapping, if wait is true, it returns after
+//readers release the mapping and it is unmapped
+func (ex *Extent) unmapFile(wait bool) {
+	if m := ex.mapped(); m != nil {
+		ex.mmap.Store((*mapping)(nil))
+		m.release()
+		if wait {
+			<-m.unmapped
+		}
+	}
 }
 
 func (ex *Extent) IsSeal() bool {
@@ -287,11 +520,21 @@ func (ex *Extent) getReader(offset uint32) io.Reader {
 
 }
 
-//Close function is not thread-safe
+//Close function is not thread-safe, reads in progress keep the mmap of sealed extent until they are done
 func (ex *Extent) Close() {
 	ex.Lock()
 	defer ex.Unlock()
+	ex.unmapFile(false)
+	ex.file.Close()
+}
+
+//Remove closes the extent and deletes its file, the extent can not be used any more
+func (ex *Extent) Remove() error {
+	ex.Lock()
+	defer ex.Unlock()
+	ex.unmapFile(false)
 	ex.file.Close()
+	return os.Remove(ex.fileName)
 }
 
 var (
@@ -317,8 +560,7 @@ func (ex *Extent) ReadEntries(offset uint32, maxTotalSize uint32, replay bool) (
 	//for i := uint32(0); i < maxNumOfBlocks; i++ {
 	size := 0
 	for {
-		r := ex.getReader(offset) //seek
-		entries, blockLength, err := readBlockEntries(r, ex.ID, offset, replay)
+		entries, blockLength, err := ex.readBlockEntries(offset, replay)
 
 		if err == io.EOF {
 			if ex.IsSeal() {
@@ -341,34 +583,56 @@ func (ex *Extent) ReadEntries(offset uint32, maxTotalSize uint32, replay bool) (
 	return ret, offset, nil
 }
 
+//ReadBlocks returns blocks at offset, blocks of sealed extent are copied out of mmap,
+//so they could be used after the extent is closed
 func (ex *Extent) ReadBlocks(offset uint32, maxNumOfBlocks uint32, maxTotalSize uint32) ([]*pb.Block, error) {
+	blocks, release, err := ex.ReadBlocksNoCopy(offset, maxNumOfBlocks, maxTotalSize)
+	defer release()
+	for _, block := range blocks {
+		block.Data = append([]byte(nil), block.Data...)
+	}
+	return blocks, err
+}
+
+//ReadBlocksNoCopy is ReadBlocks without copy, data of blocks refers to mmap of sealed extent,
+//caller must call release after blocks are not used, release is never nil
+func (ex *Extent) ReadBlocksNoCopy(offset uint32, maxNumOfBlocks uint32, maxTotalSize uint32) ([]*pb.Block, func(), error) {
 
 	var ret []*pb.Block
+	//extent could be sealed during read, so the first blocks are read by ReadAt and the rest from mmap
+	var mappings []*mapping
+	release := func() {
+		for _, m := range mappings {
+			m.release()
+		}
+	}
 	//TODO: fix block number
 	current := atomic.LoadUint32(&ex.commitLength)
 	if current <= offset {
 		if ex.IsSeal() {
-			return nil, EndOfExtent
+			return nil, release, EndOfExtent
 		} else {
-			return nil, EndOfStream
+			return nil, release, EndOfStream
 		}
 	}
 	size := uint32(0)
 	for i := uint32(0); i < maxNumOfBlocks; i++ {
-		r := ex.getReader(offset)
-
-		block, err := readBlock(r)
+		block, m, err := ex.readBlock(offset)
+		if m != nil {
+			mappings = append(mappings, m)
+		}
 
 		if err == io.EOF {
 			if ex.IsSeal() {
-				return ret, EndOfExtent
+				return ret, release, EndOfExtent
 			} else {
-				return ret, EndOfStream
+				return ret, release, EndOfStream
 			}
 		}
 
 		if err != nil {
-			return nil, err
+			release()
+			return nil, func() {}, err
 		}
 
 		ret = append(ret, &block)
@@ -380,7 +644,18 @@ func (ex *Extent) ReadBlocks(offset uint32, maxNumOfBlocks uint32, maxTotalSize
 			break
 		}
 	}
-	return ret, nil
+	return ret, release, nil
+}
+
+//VerifyBlock reads the block at offset and verifies its checksum, returns the size of block(header included),
+//used by scrubber
+func (ex *Extent) VerifyBlock(offset uint32) (uint32, error) {
+	h, _, m, err := ex.readBlockData(offset)
+	m.release()
+	if err != nil {
+		return 0, errors.Wrapf(err, "extent %d, block at %d", ex.ID, offset)
+	}
+	return h.blockLength + 512, nil
 }
 
 func (ex *Extent) CommitLength() uint32 {
@@ -388,84 +663,176 @@ func (ex *Extent) CommitLength() uint32 {
 }
 
 func (ex *Extent) AppendBlocks(blocks []*pb.Block, lastCommit *uint32) (ret []uint32, err error) {
+	ret, end, err := ex.writeBlocks(blocks, lastCommit)
+	if err != nil {
+		return nil, err
+	}
+	//如果有SSD journal, node调用WriteBlocksAt, 只sync journal, 见node/journal.go
+	//如果没有SSD journal,就需要调用sync
+	ex.file.Sync()
+
+	atomic.StoreUint32(&ex.commitLength, end)
+	return
+}
+
+//writeBlocks writes blocks to the end of file, commitLength is not changed
+func (ex *Extent) writeBlocks(blocks []*pb.Block, lastCommit *uint32) (ret []uint32, end uint32, err error) {
 	ex.AssertLock()
 
 	if atomic.LoadInt32(&ex.isSeal) == 1 {
-		return nil, errors.Errorf("immuatble")
+		return nil, 0, errors.Errorf("immuatble")
 	}
 
 	//for secondary extents, it must check lastCommit.
 	if lastCommit != nil && *lastCommit != ex.CommitLength() {
-		return nil, errors.Errorf("offset not match...")
+		return nil, 0, errors.Errorf("offset not match...")
 	}
 
 	/*
 		wrap <offset + blocks>
 		offset := ex.commitLength
 	*/
-	currentLength := atomic.LoadUint32(&ex.commitLength)
-
-	truncate := func() {
-		ex.file.Truncate(int64(currentLength))
-		ex.commitLength = currentLength
-	}
+	start := atomic.LoadUint32(&ex.commitLength)
+	currentLength := start
 
 	for _, block := range blocks {
-		if err = writeBlock(ex.file, block); err != nil {
-			defer truncate()
-			return nil, err
+		if err = writeBlock(ex.file, block, ex.checksumType); err != nil {
+			ex.file.Truncate(int64(start))
+			return nil, 0, err
 		}
-		//if we have ssd journal, do not have to sync every time.
-		//TODO: wait ssd channel,  这里分情况, 如果有SSD journal, 就不需要调用sync
-		//如果没有SSD journal,就需要调用sync
 		ret = append(ret, currentLength)
 		currentLength += block.BlockLength + 512
 	}
-	ex.file.Sync()
-
-	atomic.StoreUint32(&ex.commitLength, currentLength)
-	return
+	return ret, currentLength, nil
 }
 
-func writeBlock(w io.Writer, block *pb.Block) (err error) {
+//AppendBlocksAt appends blocks at offset. If offset is less than commitLength, the request
+//could be a retry, it returns the offsets of the blocks if they have been appended at offset
+func (ex *Extent) AppendBlocksAt(blocks []*pb.Block, offset uint32) ([]uint32, error) {
+	ret, end, err := ex.WriteBlocksAt(blocks, offset)
+	if err != nil {
+		return nil, err
+	}
+	if err = ex.Sync(); err != nil {
+		ex.Rollback()
+		return nil, err
+	}
+	ex.Commit(end)
+	return ret, nil
+}
 
-	if !align(uint64(block.BlockLength)) {
-		return errors.Errorf("block is not aligned %d", block.BlockLength)
+//WriteBlocksAt is AppendBlocksAt without sync, the blocks are visible after Commit(end).
+//caller syncs the file(or the whole disk) and calls Commit or Rollback before unlock
+func (ex *Extent) WriteBlocksAt(blocks []*pb.Block, offset uint32) ([]uint32, uint32, error) {
+	ex.AssertLock()
+	if offset < ex.CommitLength() {
+		ret, err := ex.checkAppended(blocks, offset)
+		return ret, ex.CommitLength(), err
 	}
-	//checkSum
+	return ex.writeBlocks(blocks, &offset)
+}
 
-	if block.CheckSum != utils.AdlerCheckSum(block.Data) {
-		return errors.Errorf("alder32 checksum not match  %d vs %d", block.CheckSum, utils.AdlerCheckSum(block.Data))
+func (ex *Extent) Sync() error {
+	return ex.file.Sync()
+}
+
+//Commit makes blocks before end visible
+func (ex *Extent) Commit(end uint32) {
+	ex.AssertLock()
+	if end > ex.CommitLength() {
+		atomic.StoreUint32(&ex.commitLength, end)
 	}
+}
 
-	var buf [512]byte
+//Rollback truncates blocks which are written but not committed
+func (ex *Extent) Rollback() {
+	ex.AssertLock()
+	ex.file.Truncate(int64(ex.CommitLength()))
+}
 
-	sz := 0
-	binary.BigEndian.PutUint32(buf[:], block.CheckSum)
-	sz += 4
+//Truncate drops blocks after offset, caller must hold lock. used by journal replay
+func (ex *Extent) Truncate(offset uint32) error {
+	ex.AssertLock()
+	if ex.IsSeal() {
+		return errors.Errorf("immuatble")
+	}
+	if offset < 512 || offset > ex.CommitLength() {
+		return errors.Errorf("can not truncate extent %d to %d", ex.ID, offset)
+	}
+	if err := ex.file.Truncate(int64(offset)); err != nil {
+		return err
+	}
+	atomic.StoreUint32(&ex.commitLength, offset)
+	return nil
+}
 
-	sz += binary.PutUvarint(buf[sz:], uint64(block.BlockLength))
-	if len(block.UserData) > 0 {
-		sz += binary.PutUvarint(buf[sz:], uint64(len(block.UserData)))
-		if sz+len(block.UserData) > 512 {
-			return errors.Errorf("user data is too big %d", block.UserData)
+//VerifyBlocksAt returns nil if blocks are stored at offset and stored data is not corrupt,
+//unlike checkAppended, block data is read and compared
+func (ex *Extent) VerifyBlocksAt(blocks []*pb.Block, offset uint32) error {
+	for _, block := range blocks {
+		b, err := readBlock(ex.getReader(offset), ex.checksumType)
+		if err != nil {
+			return err
+		}
+		if b.BlockLength != block.BlockLength || !bytes.Equal(b.Data, block.Data) {
+			return errors.Errorf("block at %d is different", offset)
 		}
-		copy(buf[sz:], block.UserData)
+		offset += block.BlockLength + 512
 	}
+	return nil
+}
 
-	/*
-		if 512 < (4 + 4 + 4 + 2 + len(block.UserData)) {
-			return errors.Errorf("user data is too big %d", block.UserData)
+//checkAppended compares checksum and length of blocks with the block headers on disk
+func (ex *Extent) checkAppended(blocks []*pb.Block, offset uint32) ([]uint32, error) {
+	current := ex.CommitLength()
+	var ret []uint32
+	var buf [512]byte
+	for _, block := range blocks {
+		if offset+block.BlockLength+512 > current {
+			return nil, errors.Errorf("offset not match, block at %d is not appended", offset)
 		}
-		binary.BigEndian.PutUint32(buf[:], block.CheckSum)
-		binary.BigEndian.PutUint32(buf[4:], block.BlockLength)
-		binary.BigEndian.PutUint16(buf[8:], uint16(block.Lazy))
-		if len(block.UserData) != 0 {
-			binary.BigEndian.PutUint32(buf[10:], uint32(len(block.UserData)))
-			//w.Write(block.UserData)
-			copy(buf[14:], block.UserData)
+		if _, err := io.ReadFull(ex.getReader(offset), buf[:]); err != nil {
+			return nil, err
 		}
-	*/
+		var h blockHeader
+		if err := h.unmarshal(buf[:], ex.checksumType == ChecksumLegacy); err != nil {
+			return nil, err
+		}
+		if h.blockLength != block.BlockLength || h.verify(block.Data) != nil {
+			return nil, errors.Errorf("offset not match, block at %d is different", offset)
+		}
+		ret = append(ret, offset)
+		offset += block.BlockLength + 512
+	}
+	return ret, nil
+}
+
+//writeBlock writes block with version 1 block header, if checksumType is ChecksumLegacy,
+//writes version 0 block header
+func writeBlock(w io.Writer, block *pb.Block, checksumType ChecksumType) (err error) {
+
+	if !align(uint64(block.BlockLength)) {
+		return errors.Errorf("block is not aligned %d", block.BlockLength)
+	}
+	//block.CheckSum is always adler32, it protects data between client and node
+	if block.CheckSum != utils.AdlerCheckSum(block.Data) {
+		return errors.Errorf("alder32 checksum not match  %d vs %d", block.CheckSum, utils.AdlerCheckSum(block.Data))
+	}
+
+	h := blockHeader{
+		checksumType: checksumType,
+		checkSum:     uint64(block.CheckSum),
+		blockLength:  block.BlockLength,
+		userData:     block.UserData,
+	}
+	if checksumType != ChecksumLegacy {
+		h.checkSum = checksumType.sum(block.Data)
+	}
+
+	var buf [512]byte
+	if err = h.marshal(buf[:]); err != nil {
+		return err
+	}
 
 	w.Write(buf[:])
 
@@ -474,51 +841,100 @@ func writeBlock(w io.Writer, block *pb.Block) (err error) {
 	return err
 }
 
-func readBlockEntries(reader io.Reader, extentID uint64, offset uint32, replay bool) ([]*pb.EntryInfo, uint64, error) {
-
+//readBlockData reads block header and data, and verifies checksum
+func readBlockData(reader io.Reader, checksumType ChecksumType) (blockHeader, []byte, error) {
 	var buf [512]byte
+	var h blockHeader
 
 	_, err := io.ReadFull(reader, buf[:])
-
 	if err != nil {
-		return nil, 0, err
+		return h, nil, err
 	}
-
-	checkSum := binary.BigEndian.Uint32(buf[:4])
-	index := 4
-	blockLength, n := binary.Uvarint(buf[index:])
-	index += n
-	ulen, n := binary.Uvarint(buf[index:])
-	index += n
-
-	if int(ulen)+index > 512 {
-		return nil, 0, errors.Errorf("user data is too big %d", int(ulen)+index)
-	}
-	var UserData []byte
-	if ulen > 0 {
-		UserData = buf[index : index+int(ulen)]
+	if err = h.unmarshal(buf[:], checksumType == ChecksumLegacy); err != nil {
+		return h, nil, err
 	}
 
-	data := make([]byte, blockLength, blockLength)
+	data := make([]byte, h.blockLength, h.blockLength)
 	_, err = io.ReadFull(reader, data)
 
 	if err != nil {
 		if err == io.EOF {
 			err = io.ErrUnexpectedEOF
 		}
-		return nil, 0, err
+		return h, nil, err
 	}
 
 	//checkSum
-	if utils.AdlerCheckSum(data) != checkSum {
-		return nil, 0, errors.Errorf("alder32 checksum not match, %d vs %d", utils.AdlerCheckSum(data), checkSum)
+	if err = h.verify(data); err != nil {
+		return h, nil, err
+	}
+	if !align(uint64(h.blockLength)) {
+		return h, nil, errors.Errorf("block is not aligned %d", h.blockLength)
+	}
+	return h, data, nil
+}
+
+//readBlockData returns header and data of the block at offset, data of sealed extent is sliced from mmap
+//without copy, and the mapping is returned with a reference held, caller must release it after data
+//is not used. unsealed extent is read by ReadAt, the returned mapping is nil
+func (ex *Extent) readBlockData(offset uint32) (blockHeader, []byte, *mapping, error) {
+	if m := ex.acquireMapping(); m != nil {
+		h, data, err := sliceBlockData(m.data, offset, ex.checksumType)
+		return h, data, m, err
+	}
+	//sealed extent has a footer after commitLength
+	if offset >= ex.CommitLength() {
+		return blockHeader{}, nil, nil, io.EOF
+	}
+	h, data, err := readBlockData(ex.getReader(offset), ex.checksumType)
+	return h, data, nil, err
+}
+
+//sliceBlockData is the mmap version of readBlockData, the returned data refers to buf
+func sliceBlockData(buf []byte, offset uint32, checksumType ChecksumType) (blockHeader, []byte, error) {
+	var h blockHeader
+	if uint64(offset) >= uint64(len(buf)) {
+		return h, nil, io.EOF
+	}
+	if uint64(offset)+512 > uint64(len(buf)) {
+		return h, nil, io.ErrUnexpectedEOF
+	}
+	if err := h.unmarshal(buf[offset:offset+512], checksumType == ChecksumLegacy); err != nil {
+		return h, nil, err
+	}
+	start := uint64(offset) + 512
+	end := start + uint64(h.blockLength)
+	if end > uint64(len(buf)) {
+		return h, nil, io.ErrUnexpectedEOF
 	}
-	if !align(uint64(blockLength)) {
-		return nil, 0, errors.Errorf("block is not aligned %d", blockLength)
+	data := buf[start:end:end]
+
+	if err := h.verify(data); err != nil {
+		return h, nil, err
+	}
+	if !align(uint64(h.blockLength)) {
+		return h, nil, errors.Errorf("block is not aligned %d", h.blockLength)
+	}
+	return h, data, nil
+}
+
+func (ex *Extent) readBlockEntries(offset uint32, replay bool) ([]*pb.EntryInfo, uint64, error) {
+
+	//entries are unmarshaled with copy, so mmap is released after return
+	h, data, m, err := ex.readBlockData(offset)
+	defer m.release()
+	if err != nil {
+		return nil, 0, err
 	}
+	extentID := ex.ID
+	blockLength := uint64(h.blockLength)
+	UserData := h.userData
 
 	var mix pspb.MixedLog
 	utils.MustUnMarshal(UserData, &mix)
+	if data, err = y.DecompressMixedLog(&mix, data); err != nil {
+		return nil, 0, err
+	}
 
 	var ret []*pb.EntryInfo
 	//FIXME: offsets换成len, 表示end offset
@@ -528,7 +944,11 @@ func readBlockEntries(reader io.Reader, extentID uint64, offset uint32, replay b
 		err := entry.Unmarshal(data[mix.Offsets[i] : mix.Offsets[i]+length])
 		utils.Check(err)
 
-		if y.ShouldWriteValueToLSM(entry) {
+		//big values are marked by stream client, unmarked big values written before the
+		//threshold was configurable are the only entry in their blocks
+		isBig := entry.Meta&uint32(y.BitValuePointer) > 0 ||
+			(len(mix.Offsets) == 2 && len(entry.Value) > y.ValueThrottle)
+		if !isBig {
 
 			if replay { //replay read
 				ret = append(ret, &pb.EntryInfo{
@@ -565,55 +985,37 @@ func readBlockEntries(reader io.Reader, extentID uint64, offset uint32, replay b
 	return ret, blockLength, nil
 }
 
-func readBlock(reader io.Reader) (pb.Block, error) {
-
-	var buf [512]byte
-
-	_, err := io.ReadFull(reader, buf[:])
-
+//readBlock returns the block at offset and the mapping its data refers to, caller must
+//release the mapping after the block is not used
+func (ex *Extent) readBlock(offset uint32) (pb.Block, *mapping, error) {
+	h, data, m, err := ex.readBlockData(offset)
 	if err != nil {
-		return pb.Block{}, err
-	}
-
-	checkSum := binary.BigEndian.Uint32(buf[:4])
-	index := 4
-	blockLength, n := binary.Uvarint(buf[index:])
-	index += n
-	len, n := binary.Uvarint(buf[index:])
-	index += n
-
-	if int(len)+index > 512 {
-		return pb.Block{}, errors.Errorf("user data is too big %d", int(len)+index)
-	}
-	var UserData []byte
-	if len > 0 {
-		UserData = buf[index : index+int(len)]
+		m.release()
+		return pb.Block{}, nil, err
 	}
+	return newBlock(h, data), m, nil
+}
 
-	data := make([]byte, blockLength, blockLength)
-	_, err = io.ReadFull(reader, data)
+func readBlock(reader io.Reader, checksumType ChecksumType) (pb.Block, error) {
 
+	h, data, err := readBlockData(reader, checksumType)
 	if err != nil {
-		if err == io.EOF {
-			err = io.ErrUnexpectedEOF
-		}
 		return pb.Block{}, err
 	}
+	return newBlock(h, data), nil
+}
 
-	//checkSum
-	if utils.AdlerCheckSum(data) != checkSum {
-		return pb.Block{}, errors.Errorf("alder32 checksum not match, %d vs %d", utils.AdlerCheckSum(data), checkSum)
-	}
-	if !align(uint64(blockLength)) {
-		return pb.Block{}, errors.Errorf("block is not aligned %d", blockLength)
+func newBlock(h blockHeader, data []byte) pb.Block {
+	checkSum := uint32(h.checkSum)
+	if h.checksumType != ChecksumLegacy {
+		checkSum = utils.AdlerCheckSum(data)
 	}
-
 	return pb.Block{
 		CheckSum:    checkSum,
-		BlockLength: uint32(blockLength),
+		BlockLength: h.blockLength,
 		Data:        data,
-		UserData:    UserData,
-	}, nil
+		UserData:    h.userData,
+	}
 }
 
 /*
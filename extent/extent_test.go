@@ -1,7 +1,9 @@
 package extent
 
 import (
+	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"os"
 	"sync/atomic"
@@ -79,14 +81,17 @@ func TestReadWriteBlockUserData(t *testing.T) {
 		UserData:    []byte("hello"),
 	}
 
-	f := newMemory(3000)
-	err := writeBlock(f, &block)
-	assert.Nil(t, err)
+	for _, ct := range []ChecksumType{ChecksumLegacy, ChecksumCRC32C, ChecksumXXHash64} {
+		f := newMemory(3000)
+		err := writeBlock(f, &block, ct)
+		assert.Nil(t, err)
 
-	f.resetPos()
-	block1, err := readBlock(f)
+		f.resetPos()
+		block1, err := readBlock(f, ct)
+		assert.Nil(t, err)
 
-	assert.Equal(t, block, block1)
+		assert.Equal(t, block, block1)
+	}
 }
 
 func TestReadWriteBlock(t *testing.T) {
@@ -97,14 +102,36 @@ func TestReadWriteBlock(t *testing.T) {
 		Data:        data,
 	}
 
-	f := newMemory(3000)
-	err := writeBlock(f, &block)
-	assert.Nil(t, err)
+	for _, ct := range []ChecksumType{ChecksumLegacy, ChecksumCRC32C, ChecksumXXHash64} {
+		f := newMemory(3000)
+		err := writeBlock(f, &block, ct)
+		assert.Nil(t, err)
 
-	f.resetPos()
-	block1, err := readBlock(f)
+		f.resetPos()
+		block1, err := readBlock(f, ct)
+		assert.Nil(t, err)
 
-	assert.Equal(t, block, block1)
+		assert.Equal(t, block, block1)
+	}
+}
+
+func TestBlockChecksumCorrupt(t *testing.T) {
+	data := make([]byte, 1024)
+	utils.SetRandStringBytes(data)
+	block := pb.Block{
+		CheckSum:    utils.AdlerCheckSum(data),
+		BlockLength: 1024,
+		Data:        data,
+	}
+	for _, ct := range []ChecksumType{ChecksumLegacy, ChecksumCRC32C, ChecksumXXHash64} {
+		f := newMemory(3000)
+		require.Nil(t, writeBlock(f, &block, ct))
+		f.vec[512+100] ^= 0xff
+
+		f.resetPos()
+		_, err := readBlock(f, ct)
+		assert.NotNil(t, err, ct.String())
+	}
 }
 
 func generateBlock(name string, size uint32) *pb.Block {
@@ -171,6 +198,36 @@ func TestAppendReadFile(t *testing.T) {
 	}
 }
 
+func TestAppendBlocksAt(t *testing.T) {
+	cases := []*pb.Block{
+		generateBlock("object1", 4096),
+		generateBlock("object2", 8192),
+	}
+	extent, err := CreateExtent("localtest_at.ext", 100)
+	defer os.Remove("localtest_at.ext")
+	require.Nil(t, err)
+	extent.Lock()
+	defer extent.Unlock()
+
+	ret, err := extent.AppendBlocksAt(cases, 512)
+	require.Nil(t, err)
+	end := extent.CommitLength()
+
+	//retry at the same offset, nothing is written
+	dup, err := extent.AppendBlocksAt(cases, 512)
+	require.Nil(t, err)
+	assert.Equal(t, ret, dup)
+	assert.Equal(t, end, extent.CommitLength())
+
+	//different blocks at the same offset
+	_, err = extent.AppendBlocksAt([]*pb.Block{generateBlock("object3", 4096)}, 512)
+	assert.NotNil(t, err)
+
+	//offset beyond commitLength
+	_, err = extent.AppendBlocksAt(cases, end+512)
+	assert.NotNil(t, err)
+}
+
 func TestReplayExtent(t *testing.T) {
 
 	extentName := "localtest.ext"
@@ -274,3 +331,214 @@ func BenchmarkExtent(b *testing.B) {
 		commit += 512 + n
 	}
 }
+
+func TestVerifyBlock(t *testing.T) {
+	cases := []*pb.Block{
+		generateBlock("object1", 4096),
+		generateBlock("object2", 8192),
+	}
+	extent, err := CreateExtent("localtest_verify.ext", 100)
+	defer os.Remove("localtest_verify.ext")
+	require.Nil(t, err)
+	extent.Lock()
+	ret, err := extent.AppendBlocks(cases, nil)
+	extent.Unlock()
+	require.Nil(t, err)
+
+	n, err := extent.VerifyBlock(ret[0])
+	require.Nil(t, err)
+	assert.Equal(t, ret[1], ret[0]+n)
+
+	//flip one byte of the second block's data
+	f, err := os.OpenFile("localtest_verify.ext", os.O_RDWR, 0644)
+	require.Nil(t, err)
+	var b [1]byte
+	pos := int64(ret[1] + 512 + 100)
+	_, err = f.ReadAt(b[:], pos)
+	require.Nil(t, err)
+	b[0] ^= 0xff
+	_, err = f.WriteAt(b[:], pos)
+	require.Nil(t, err)
+	f.Close()
+
+	_, err = extent.VerifyBlock(ret[1])
+	assert.NotNil(t, err)
+}
+
+func TestReadBlocksMmap(t *testing.T) {
+	cases := []*pb.Block{
+		generateBlock("object1", 4096),
+		generateBlock("object2", 8192),
+	}
+	extent, err := CreateExtent("localtest_mmap.ext", 100)
+	defer os.Remove("localtest_mmap.ext")
+	require.Nil(t, err)
+	extent.Lock()
+	ret, err := extent.AppendBlocks(cases, nil)
+	extent.Unlock()
+	require.Nil(t, err)
+
+	expected, err := extent.ReadBlocks(ret[0], 10, 1<<20)
+	require.Equal(t, EndOfStream, err)
+
+	//sealed extent is read from mmap
+	require.Nil(t, extent.mapFile())
+	defer extent.Close()
+	require.NotNil(t, extent.mapped())
+	blocks, err := extent.ReadBlocks(ret[0], 10, 1<<20)
+	require.Equal(t, EndOfStream, err)
+	assert.Equal(t, expected, blocks)
+
+	blocks, err = extent.ReadBlocks(ret[1], 1, 1<<20)
+	require.Nil(t, err)
+	assert.Equal(t, expected[1:], blocks)
+
+	//a reader keeps the mapping after the extent unmaps it
+	_, data, m, err := extent.readBlockData(ret[1])
+	require.Nil(t, err)
+	require.NotNil(t, m)
+	extent.unmapFile(false)
+	assert.Nil(t, extent.mapped())
+	assert.Equal(t, expected[1].Data, data)
+	select {
+	case <-m.unmapped:
+		t.Fatal("mapping is unmapped before reader releases it")
+	default:
+	}
+	m.release()
+	<-m.unmapped
+
+	//blocks are read by ReadAt after unmap
+	blocks, err = extent.ReadBlocks(ret[1], 1, 1<<20)
+	require.Nil(t, err)
+	assert.Equal(t, expected[1:], blocks)
+
+	//blocks without copy keep the mapping until release
+	require.Nil(t, extent.mapFile())
+	m = extent.mapped()
+	blocks, release, err := extent.ReadBlocksNoCopy(ret[0], 10, 1<<20)
+	require.Equal(t, EndOfStream, err)
+	extent.unmapFile(false)
+	assert.Equal(t, expected, blocks)
+	select {
+	case <-m.unmapped:
+		t.Fatal("mapping is unmapped before blocks are released")
+	default:
+	}
+	release()
+	<-m.unmapped
+}
+
+func TestSealFooter(t *testing.T) {
+	extentName := "localtest_footer.ext"
+	cases := []*pb.Block{
+		generateBlock("object1", 4096),
+		generateBlock("object2", 8192),
+	}
+	ex, err := CreateExtent(extentName, 100)
+	defer os.Remove(extentName)
+	require.Nil(t, err)
+	ex.Lock()
+	_, err = ex.AppendBlocks(cases, nil)
+	ex.Unlock()
+	require.Nil(t, err)
+	commit := ex.CommitLength()
+	require.Nil(t, ex.Seal(commit))
+	ex.Close()
+
+	//footer is after the last block
+	info, err := os.Stat(extentName)
+	require.Nil(t, err)
+	assert.Equal(t, int64(commit+512), info.Size())
+
+	ex, err = OpenExtent(extentName)
+	require.Nil(t, err)
+	assert.True(t, ex.IsSeal())
+	assert.Equal(t, commit, ex.CommitLength())
+	blocks, err := ex.ReadBlocks(512, 10, 20<<20)
+	assert.Equal(t, EndOfExtent, err)
+	assert.Equal(t, cases, blocks)
+	ex.Close()
+
+	//broken footer, extent is replayed and the footer is truncated
+	f, err := os.OpenFile(extentName, os.O_RDWR, 0644)
+	require.Nil(t, err)
+	_, err = f.WriteAt([]byte("X"), int64(commit))
+	require.Nil(t, err)
+	f.Close()
+
+	ex, err = OpenExtent(extentName)
+	require.Nil(t, err)
+	assert.False(t, ex.IsSeal())
+	assert.Equal(t, commit, ex.CommitLength())
+	ex.Close()
+}
+
+func TestForgedSealFooter(t *testing.T) {
+	extentName := "localtest_forged.ext"
+	ex, err := CreateExtent(extentName, 100)
+	defer os.Remove(extentName)
+	require.Nil(t, err)
+
+	//the last 512 bytes of block data look like a footer
+	data := make([]byte, 4096)
+	copy(data[4096-512:], sealMagicNumber)
+	sealSize := uint64(512 + 512 + 4096 - 512)
+	binary.BigEndian.PutUint64(data[4096-512+8:], sealSize)
+	binary.BigEndian.PutUint32(data[4096-512+16:], crc32.Checksum(data[4096-512:4096-512+16], crc32cTable))
+	ex.Lock()
+	_, err = ex.AppendBlocks([]*pb.Block{{CheckSum: utils.AdlerCheckSum(data), BlockLength: 4096, Data: data}}, nil)
+	ex.Unlock()
+	require.Nil(t, err)
+	commit := ex.CommitLength()
+	ex.Close()
+
+	ex, err = OpenExtent(extentName)
+	require.Nil(t, err)
+	assert.False(t, ex.IsSeal())
+	assert.Equal(t, commit, ex.CommitLength())
+	ex.Close()
+}
+
+func TestOpenExtentTornTail(t *testing.T) {
+	extentName := "localtest_torn.ext"
+	cases := []*pb.Block{
+		generateBlock("object1", 4096),
+		generateBlock("object2", 8192),
+	}
+	ex, err := CreateExtent(extentName, 100)
+	defer os.Remove(extentName)
+	require.Nil(t, err)
+	ex.Lock()
+	ret, err := ex.AppendBlocks(cases, nil)
+	ex.Unlock()
+	require.Nil(t, err)
+	commit := ex.CommitLength()
+	ex.Close()
+
+	//half written header
+	f, err := os.OpenFile(extentName, os.O_RDWR, 0644)
+	require.Nil(t, err)
+	_, err = f.WriteAt(make([]byte, 100), int64(commit))
+	require.Nil(t, err)
+
+	ex, err = OpenExtent(extentName)
+	require.Nil(t, err)
+	assert.Equal(t, commit, ex.CommitLength())
+	ex.Close()
+
+	//data of the last block is corrupt
+	var b [1]byte
+	pos := int64(ret[1] + 512 + 100)
+	_, err = f.ReadAt(b[:], pos)
+	require.Nil(t, err)
+	b[0] ^= 0xff
+	_, err = f.WriteAt(b[:], pos)
+	require.Nil(t, err)
+	f.Close()
+
+	ex, err = OpenExtent(extentName)
+	require.Nil(t, err)
+	assert.Equal(t, ret[1], ex.CommitLength())
+	ex.Close()
+}
@@ -0,0 +1,17 @@
+// +build !linux,!darwin
+
+package extent
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+func mmap(f *os.File, size int) ([]byte, error) {
+	return nil, errors.New("mmap is not supported")
+}
+
+func munmap(data []byte) error {
+	return nil
+}
@@ -135,8 +135,22 @@ func parseParts(kvs []*mvccpb.KeyValue) map[uint64]*pspb.PartitionMeta {
 				continue
 			}
 			ret[partID].Locs = &tables
+		case "keys":
+			var keys pspb.DataKeys
+			if err = keys.Unmarshal(kv.Value); err != nil {
+				xlog.Logger.Errorf(err.Error())
+				continue
+			}
+			ret[partID].Keys = &keys
 		case "discard":
 			ret[partID].Discard = kv.Value
+		case "options":
+			var opts pspb.PartitionOptions
+			if err = opts.Unmarshal(kv.Value); err != nil {
+				xlog.Logger.Errorf(err.Error())
+				continue
+			}
+			ret[partID].Opts = &opts
 		case "parent":
 			ret[partID].Parent = binary.BigEndian.Uint64(kv.Value)
 		case "range":
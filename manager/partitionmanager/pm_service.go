@@ -78,6 +78,190 @@ func (pm *PartitionManager) SetRowStreamTables(ctx context.Context, req *pspb.Se
 
 }
 
+func (pm *PartitionManager) SetDataKeys(ctx context.Context, req *pspb.SetDataKeysRequest) (*pspb.SetDataKeysResponse, error) {
+	errDone := func(err error) (*pspb.SetDataKeysResponse, error) {
+		xlog.Logger.Warnf("set data keys: %v", err)
+		return &pspb.SetDataKeysResponse{
+			Code: pb.Code_ERROR,
+		}, nil
+	}
+
+	if !pm.AmLeader() {
+		return &pspb.SetDataKeysResponse{
+			Code: pb.Code_NOT_LEADER,
+		}, nil
+	}
+
+	if req.Keys == nil || len(req.Keys.Keys) == 0 || req.PartitionID == 0 {
+		return errDone(errors.Errorf("invalid request"))
+	}
+
+	pm.partLock.Lock()
+	defer pm.partLock.Unlock()
+
+	meta, ok := pm.partMeta[req.PartitionID]
+	if !ok {
+		return errDone(errors.Errorf("no such partition %d", req.PartitionID))
+	}
+	//data keys can only be appended, a lost key makes data unreadable
+	if meta.Keys != nil && len(req.Keys.Keys) < len(meta.Keys.Keys) {
+		return errDone(errors.Errorf("can not remove data keys of partition %d", req.PartitionID))
+	}
+
+	data, err := req.Keys.Marshal()
+	utils.Check(err)
+	ops := []clientv3.Op{
+		clientv3.OpPut(fmt.Sprintf("PART/%d/keys", req.PartitionID), string(data)),
+	}
+	err = manager.EtctSetKVS(pm.client, []clientv3.Cmp{
+		clientv3.Compare(clientv3.Value(pm.leaderKey), "=", pm.memberValue),
+	}, ops)
+	if err != nil {
+		return errDone(err)
+	}
+
+	meta.Keys = proto.Clone(req.Keys).(*pspb.DataKeys)
+	return &pspb.SetDataKeysResponse{
+		Code: pb.Code_OK,
+	}, nil
+}
+
+//SetDiscardStats saves discard stats of value log in PART/{id}/discard
+func (pm *PartitionManager) SetDiscardStats(ctx context.Context, req *pspb.SetDiscardStatsRequest) (*pspb.SetDiscardStatsResponse, error) {
+	errDone := func(err error) (*pspb.SetDiscardStatsResponse, error) {
+		xlog.Logger.Warnf("set discard stats: %v", err)
+		return &pspb.SetDiscardStatsResponse{
+			Code: pb.Code_ERROR,
+		}, nil
+	}
+
+	if !pm.AmLeader() {
+		return &pspb.SetDiscardStatsResponse{
+			Code: pb.Code_NOT_LEADER,
+		}, nil
+	}
+
+	if req.Stats == nil || req.PartitionID == 0 {
+		return errDone(errors.Errorf("invalid request"))
+	}
+
+	pm.partLock.Lock()
+	defer pm.partLock.Unlock()
+
+	meta, ok := pm.partMeta[req.PartitionID]
+	if !ok {
+		return errDone(errors.Errorf("no such partition %d", req.PartitionID))
+	}
+
+	data, err := req.Stats.Marshal()
+	utils.Check(err)
+	ops := []clientv3.Op{
+		clientv3.OpPut(fmt.Sprintf("PART/%d/discard", req.PartitionID), string(data)),
+	}
+	err = manager.EtctSetKVS(pm.client, []clientv3.Cmp{
+		clientv3.Compare(clientv3.Value(pm.leaderKey), "=", pm.memberValue),
+	}, ops)
+	if err != nil {
+		return errDone(err)
+	}
+
+	meta.Discard = data
+	return &pspb.SetDiscardStatsResponse{
+		Code: pb.Code_OK,
+	}, nil
+}
+
+//SetBlobStreams saves blob streams of a partition in PART/{id}/blobStreams
+func (pm *PartitionManager) SetBlobStreams(ctx context.Context, req *pspb.SetBlobStreamsRequest) (*pspb.SetBlobStreamsResponse, error) {
+	errDone := func(err error) (*pspb.SetBlobStreamsResponse, error) {
+		xlog.Logger.Warnf("set blob streams: %v", err)
+		return &pspb.SetBlobStreamsResponse{
+			Code: pb.Code_ERROR,
+		}, nil
+	}
+
+	if !pm.AmLeader() {
+		return &pspb.SetBlobStreamsResponse{
+			Code: pb.Code_NOT_LEADER,
+		}, nil
+	}
+
+	if req.Blobs == nil || req.PartitionID == 0 {
+		return errDone(errors.Errorf("invalid request"))
+	}
+
+	pm.partLock.Lock()
+	defer pm.partLock.Unlock()
+
+	meta, ok := pm.partMeta[req.PartitionID]
+	if !ok {
+		return errDone(errors.Errorf("no such partition %d", req.PartitionID))
+	}
+
+	data, err := req.Blobs.Marshal()
+	utils.Check(err)
+	ops := []clientv3.Op{
+		clientv3.OpPut(fmt.Sprintf("PART/%d/blobStreams", req.PartitionID), string(data)),
+	}
+	err = manager.EtctSetKVS(pm.client, []clientv3.Cmp{
+		clientv3.Compare(clientv3.Value(pm.leaderKey), "=", pm.memberValue),
+	}, ops)
+	if err != nil {
+		return errDone(err)
+	}
+
+	meta.Blobs = proto.Clone(req.Blobs).(*pspb.BlobStreams)
+	return &pspb.SetBlobStreamsResponse{
+		Code: pb.Code_OK,
+	}, nil
+}
+
+//SetPartitionOptions saves options of a partition in PART/{id}/options, they are used when
+//the partition is opened next time
+func (pm *PartitionManager) SetPartitionOptions(ctx context.Context, req *pspb.SetPartitionOptionsRequest) (*pspb.SetPartitionOptionsResponse, error) {
+	errDone := func(err error) (*pspb.SetPartitionOptionsResponse, error) {
+		xlog.Logger.Warnf("set partition options: %v", err)
+		return &pspb.SetPartitionOptionsResponse{
+			Code: pb.Code_ERROR,
+		}, nil
+	}
+
+	if !pm.AmLeader() {
+		return &pspb.SetPartitionOptionsResponse{
+			Code: pb.Code_NOT_LEADER,
+		}, nil
+	}
+
+	if req.Opts == nil || req.PartitionID == 0 {
+		return errDone(errors.Errorf("invalid request"))
+	}
+
+	pm.partLock.Lock()
+	defer pm.partLock.Unlock()
+
+	meta, ok := pm.partMeta[req.PartitionID]
+	if !ok {
+		return errDone(errors.Errorf("no such partition %d", req.PartitionID))
+	}
+
+	data, err := req.Opts.Marshal()
+	utils.Check(err)
+	ops := []clientv3.Op{
+		clientv3.OpPut(fmt.Sprintf("PART/%d/options", req.PartitionID), string(data)),
+	}
+	err = manager.EtctSetKVS(pm.client, []clientv3.Cmp{
+		clientv3.Compare(clientv3.Value(pm.leaderKey), "=", pm.memberValue),
+	}, ops)
+	if err != nil {
+		return errDone(err)
+	}
+
+	meta.Opts = proto.Clone(req.Opts).(*pspb.PartitionOptions)
+	return &pspb.SetPartitionOptionsResponse{
+		Code: pb.Code_OK,
+	}, nil
+}
+
 func (pm *PartitionManager) allocUniqID(count uint64) (uint64, uint64, error) {
 
 	pm.allocIdLock.Lock()
@@ -197,6 +381,15 @@ func (pm *PartitionManager) Bootstrap(ctx context.Context, req *pspb.BootstrapRe
 		clientv3.OpPut(fmt.Sprintf("PART/%d/parent", partID), uint64ToBig(req.Parent)),
 		clientv3.OpPut(fmt.Sprintf("PART/%d/range", partID), string(rangeValue)),
 	}
+	var blobs *pspb.BlobStreams
+	if req.BlobID != 0 {
+		blobs = &pspb.BlobStreams{Blob: []uint64{req.BlobID}}
+		data, err := blobs.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, clientv3.OpPut(fmt.Sprintf("PART/%d/blobStreams", partID), string(data)))
+	}
 
 	//FIXME: update PSVERSION
 	err = manager.EtctSetKVS(pm.client, []clientv3.Cmp{
@@ -204,6 +397,7 @@ func (pm *PartitionManager) Bootstrap(ctx context.Context, req *pspb.BootstrapRe
 	}, ops)
 
 	pm.partMeta[partID] = &pspb.PartitionMeta{
+		Blobs:     blobs,
 		LogStream: req.LogID,
 		RowStream: req.RowID,
 		Parent:    req.Parent,
@@ -5,10 +5,28 @@ import (
 )
 
 type MockPMClient struct {
-	Tables []*pspb.Location
+	Tables  []*pspb.Location
+	Keys    *pspb.DataKeys
+	Discard *pspb.DiscardStats
+	Blobs   []uint64
 }
 
 func (c *MockPMClient) SetRowStreamTables(id uint64, tables []*pspb.Location) error {
 	c.Tables = tables
 	return nil
 }
+
+func (c *MockPMClient) SetDataKeys(id uint64, keys *pspb.DataKeys) error {
+	c.Keys = keys
+	return nil
+}
+
+func (c *MockPMClient) SetDiscardStats(id uint64, stats *pspb.DiscardStats) error {
+	c.Discard = stats
+	return nil
+}
+
+func (c *MockPMClient) SetBlobStreams(id uint64, blobs []uint64) error {
+	c.Blobs = blobs
+	return nil
+}
@@ -16,6 +16,9 @@ import (
 //FIXME: delete PMCLient, add function to range_partition
 type PMClient interface {
 	SetRowStreamTables(uint64, []*pspb.Location) error
+	SetDataKeys(uint64, *pspb.DataKeys) error
+	SetDiscardStats(uint64, *pspb.DiscardStats) error
+	SetBlobStreams(uint64, []uint64) error
 }
 
 type AutumnPMClient struct {
@@ -103,6 +106,30 @@ func (client *AutumnPMClient) SetRowStreamTables(id uint64, tables []*pspb.Locat
 	return err
 }
 
+func (client *AutumnPMClient) SetDataKeys(id uint64, keys *pspb.DataKeys) error {
+	err := errors.New("unknow err")
+	client.try(func(conn *grpc.ClientConn) bool {
+		c := pspb.NewPartitionManagerServiceClient(conn)
+		res, e := c.SetDataKeys(context.Background(), &pspb.SetDataKeysRequest{
+			PartitionID: id,
+			Keys:        keys,
+		})
+		if e != nil {
+			xlog.Logger.Warnf(e.Error())
+			return true
+		}
+		if res.Code != pb.Code_OK {
+			xlog.Logger.Warnf("set data keys of %d: %s", id, res.Code.String())
+			return true
+		}
+		err = nil
+		return false
+
+	}, 10*time.Millisecond)
+
+	return err
+}
+
 func (client *AutumnPMClient) GetPartitionMeta(psid uint64) (ret []*pspb.PartitionMeta) {
 
 	client.try(func(conn *grpc.ClientConn) bool {
@@ -126,13 +153,14 @@ func (client *AutumnPMClient) GetPartitionMeta(psid uint64) (ret []*pspb.Partiti
 	return ret
 }
 
-func (client *AutumnPMClient) Bootstrap(logID uint64, rowID uint64, psID uint64) (uint64, error) {
+func (client *AutumnPMClient) Bootstrap(logID uint64, rowID uint64, blobID uint64, psID uint64) (uint64, error) {
 	acerr := errors.New("unknow err")
 	var partID uint64
 
 	req := &pspb.BootstrapRequest{
 		LogID:  logID,
 		RowID:  rowID,
+		BlobID: blobID,
 		Parent: psID,
 	}
 	client.try(func(conn *grpc.ClientConn) bool {
@@ -209,3 +237,76 @@ func (client *AutumnPMClient) RegisterSelf(address string) (uint64, error) {
 	return id, err
 
 }
+
+func (client *AutumnPMClient) SetDiscardStats(id uint64, stats *pspb.DiscardStats) error {
+	err := errors.New("unknow err")
+	client.try(func(conn *grpc.ClientConn) bool {
+		c := pspb.NewPartitionManagerServiceClient(conn)
+		res, e := c.SetDiscardStats(context.Background(), &pspb.SetDiscardStatsRequest{
+			PartitionID: id,
+			Stats:       stats,
+		})
+		if e != nil {
+			xlog.Logger.Warnf(e.Error())
+			return true
+		}
+		if res.Code != pb.Code_OK {
+			xlog.Logger.Warnf("set discard stats of %d: %s", id, res.Code.String())
+			return true
+		}
+		err = nil
+		return false
+
+	}, 10*time.Millisecond)
+
+	return err
+}
+
+func (client *AutumnPMClient) SetBlobStreams(id uint64, blobs []uint64) error {
+	err := errors.New("unknow err")
+	client.try(func(conn *grpc.ClientConn) bool {
+		c := pspb.NewPartitionManagerServiceClient(conn)
+		res, e := c.SetBlobStreams(context.Background(), &pspb.SetBlobStreamsRequest{
+			PartitionID: id,
+			Blobs:       &pspb.BlobStreams{Blob: blobs},
+		})
+		if e != nil {
+			xlog.Logger.Warnf(e.Error())
+			return true
+		}
+		if res.Code != pb.Code_OK {
+			xlog.Logger.Warnf("set blob streams of %d: %s", id, res.Code.String())
+			return true
+		}
+		err = nil
+		return false
+
+	}, 10*time.Millisecond)
+
+	return err
+}
+
+//SetPartitionOptions overrides options of ps for partition id, 0 means the option of ps
+func (client *AutumnPMClient) SetPartitionOptions(id uint64, opts *pspb.PartitionOptions) error {
+	err := errors.New("unknow err")
+	client.try(func(conn *grpc.ClientConn) bool {
+		c := pspb.NewPartitionManagerServiceClient(conn)
+		res, e := c.SetPartitionOptions(context.Background(), &pspb.SetPartitionOptionsRequest{
+			PartitionID: id,
+			Opts:        opts,
+		})
+		if e != nil {
+			xlog.Logger.Warnf(e.Error())
+			return true
+		}
+		if res.Code != pb.Code_OK {
+			xlog.Logger.Warnf("set options of %d: %s", id, res.Code.String())
+			return true
+		}
+		err = nil
+		return false
+
+	}, 10*time.Millisecond)
+
+	return err
+}
@@ -146,14 +146,19 @@ func (client *SMClient) RegisterNode(ctx context.Context, addr string) (uint64,
 	return 0, errors.Errorf("timeout : cannot register Node")
 }
 
-func (client *SMClient) CreateStream(ctx context.Context) (*pb.StreamInfo, *pb.ExtentInfo, error) {
+//CreateStream creates a stream whose extents have replicates replicas, if replicates is 0, use default value 3.
+//if quorum is true, append succeeds once a majority of replicas acknowledge
+func (client *SMClient) CreateStream(ctx context.Context, replicates uint32, quorum bool) (*pb.StreamInfo, *pb.ExtentInfo, error) {
 	client.RLock()
 	defer client.RUnlock()
 	current := atomic.LoadInt32(&client.lastLeader)
 	for loop := 0; loop < len(client.conns)*2; loop++ {
 		if client.conns != nil && client.conns[current] != nil {
 			c := pb.NewStreamManagerServiceClient(client.conns[current])
-			res, err := c.CreateStream(ctx, &pb.CreateStreamRequest{})
+			res, err := c.CreateStream(ctx, &pb.CreateStreamRequest{
+				Replicates: replicates,
+				Quorum:     quorum,
+			})
 			if err == context.Canceled || err == context.DeadlineExceeded {
 				return nil, nil, err
 			}
@@ -329,3 +334,68 @@ func (client *SMClient) TruncateStream(ctx context.Context, streamID uint64, ext
 	return errors.Errorf("timeout: StreamInfo failed")
 
 }
+
+func (client *SMClient) StreamAttachExtents(ctx context.Context, streamID uint64, extentIDs []uint64) (*pb.StreamInfo, error) {
+	client.RLock()
+	defer client.RUnlock()
+	last := atomic.LoadInt32(&client.lastLeader)
+	current := last
+	for loop := 0; loop < len(client.conns)*2; loop++ {
+		if client.conns != nil && client.conns[current] != nil {
+			c := pb.NewStreamManagerServiceClient(client.conns[current])
+			res, err := c.StreamAttachExtents(ctx, &pb.StreamAttachExtentsRequest{
+				StreamID:  streamID,
+				ExtentIDs: extentIDs,
+			})
+			if err == context.Canceled || err == context.DeadlineExceeded {
+				return nil, err
+			}
+			if err != nil {
+				xlog.Logger.Warnf(err.Error())
+				current = (current + 1) % int32(len(client.conns))
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+			if current != last {
+				atomic.StoreInt32(&client.lastLeader, current)
+			}
+			return res.Stream, nil
+		}
+	}
+	return nil, errors.Errorf("timeout: StreamAttachExtents failed")
+}
+
+//ReportCorruptExtent tells sm that the replicate of extentID on nodeID is corrupt
+func (client *SMClient) ReportCorruptExtent(ctx context.Context, extentID uint64, nodeID uint64, reason string) error {
+	client.RLock()
+	defer client.RUnlock()
+	last := atomic.LoadInt32(&client.lastLeader)
+	current := last
+	for loop := 0; loop < len(client.conns)*2; loop++ {
+		if client.conns != nil && client.conns[current] != nil {
+			c := pb.NewStreamManagerServiceClient(client.conns[current])
+			res, err := c.ReportCorruptExtent(ctx, &pb.ReportCorruptExtentRequest{
+				ExtentID: extentID,
+				NodeID:   nodeID,
+				Reason:   reason,
+			})
+			if err == context.Canceled || err == context.DeadlineExceeded {
+				return err
+			}
+			if err != nil {
+				xlog.Logger.Warnf(err.Error())
+				current = (current + 1) % int32(len(client.conns))
+				time.Sleep(500 * time.Millisecond)
+				continue
+			}
+			if res.Code != pb.Code_OK {
+				return errors.New(res.Code.String())
+			}
+			if current != last {
+				atomic.StoreInt32(&client.lastLeader, current)
+			}
+			return nil
+		}
+	}
+	return errors.Errorf("timeout: ReportCorruptExtent failed")
+}
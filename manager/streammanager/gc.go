@@ -0,0 +1,210 @@
+package streammanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/gogo/protobuf/proto"
+	"github.com/journeymidnight/autumn/conn"
+	"github.com/journeymidnight/autumn/manager"
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/utils"
+	"github.com/journeymidnight/autumn/xlog"
+)
+
+/*
+GC流程:
+1. 找到sm.extents里面refs为0的extent, 即不再被任何stream引用的extent.
+refs在CreateStream/StreamAllocExtent时为1, StreamAttachExtents时加1, Truncate时减1
+2. extent第一次被发现没有引用时, 只记录时间, 超过gcGracePeriod之后才真正删除
+3. 在一个etcd transaction里面删除extents/{id}, 写入gcExtents/{id}, 之后这个extent
+只存在于sm.gcExtents里面
+4. 对gcExtents里面每个extent的每个副本发送DeleteExtent, 成功的node从Replicates里面
+去掉, 如果node不在线, 下一次gc时重试
+5. 所有副本都删除成功后, 删除gcExtents/{id}
+*/
+
+const (
+	gcInterval    = 5 * time.Minute
+	gcGracePeriod = 30 * time.Minute
+)
+
+func (sm *StreamManager) startGC() {
+	sm.gcStopper = utils.NewStopper()
+	sm.gcStopper.RunWorker(sm.runGC)
+}
+
+func (sm *StreamManager) stopGC() {
+	if sm.gcStopper != nil {
+		sm.gcStopper.Stop()
+		sm.gcStopper = nil
+	}
+}
+
+func (sm *StreamManager) runGC() {
+	//leader could be changed, candidates are only valid in one term
+	candidates := make(map[uint64]time.Time)
+	randTicker := utils.NewRandomTicker(gcInterval, 2*gcInterval)
+	defer randTicker.Stop()
+	for {
+		select {
+		case <-randTicker.C:
+			if !sm.AmLeader() {
+				continue
+			}
+			sm.markUnreferencedExtents(candidates)
+			sm.deleteGCExtents()
+		case <-sm.gcStopper.ShouldStop():
+			return
+		}
+	}
+}
+
+//unreferencedExtents returns all extents in sm.extents whose refs is 0
+func (sm *StreamManager) unreferencedExtents() []uint64 {
+	sm.extentsLock.RLock()
+	defer sm.extentsLock.RUnlock()
+
+	var ret []uint64
+	for extentID, extentInfo := range sm.extents {
+		if extentInfo.Refs == 0 {
+			ret = append(ret, extentID)
+		}
+	}
+	return ret
+}
+
+func (sm *StreamManager) markUnreferencedExtents(candidates map[uint64]time.Time) {
+	now := time.Now()
+	unreferenced := make(map[uint64]bool)
+	for _, extentID := range sm.unreferencedExtents() {
+		unreferenced[extentID] = true
+		firstSeen, ok := candidates[extentID]
+		if !ok {
+			candidates[extentID] = now
+			continue
+		}
+		if now.Sub(firstSeen) < gcGracePeriod {
+			continue
+		}
+		if err := sm.markExtentDeleted(extentID); err != nil {
+			xlog.Logger.Warnf("failed to mark extent %d as deleted: %v", extentID, err)
+			continue
+		}
+		delete(candidates, extentID)
+	}
+	//extent is referenced again or deleted
+	for extentID := range candidates {
+		if !unreferenced[extentID] {
+			delete(candidates, extentID)
+		}
+	}
+}
+
+//markExtentDeleted moves extents/{id} to gcExtents/{id}
+func (sm *StreamManager) markExtentDeleted(extentID uint64) error {
+	sm.extentsLock.Lock()
+	defer sm.extentsLock.Unlock()
+
+	//double check, extent could be attached again after unreferencedExtents
+	extentInfo, ok := sm.extents[extentID]
+	if !ok || extentInfo.Refs > 0 {
+		return nil
+	}
+	edata, err := extentInfo.Marshal()
+	utils.Check(err)
+
+	ops := []clientv3.Op{
+		clientv3.OpDelete(formatExtentReplicate(extentID)),
+		clientv3.OpPut(formatGCExtentKey(extentID), string(edata)),
+	}
+	err = manager.EtctSetKVS(sm.client, []clientv3.Cmp{
+		clientv3.Compare(clientv3.Value(sm.leaderKey), "=", sm.memberValue),
+	}, ops)
+	if err != nil {
+		return err
+	}
+
+	delete(sm.extents, extentID)
+	sm.gcLock.Lock()
+	sm.gcExtents[extentID] = extentInfo
+	sm.gcLock.Unlock()
+	xlog.Logger.Infof("extent %d is not referenced by any stream, mark it deleted", extentID)
+	return nil
+}
+
+func (sm *StreamManager) cloneGCExtents() []*pb.ExtentInfo {
+	sm.gcLock.RLock()
+	defer sm.gcLock.RUnlock()
+	var ret []*pb.ExtentInfo
+	for _, extentInfo := range sm.gcExtents {
+		ret = append(ret, proto.Clone(extentInfo).(*pb.ExtentInfo))
+	}
+	return ret
+}
+
+//deleteGCExtents sends DeleteExtent to every replica, if some nodes are offline
+//the rest replicas are saved and retried in next round
+func (sm *StreamManager) deleteGCExtents() {
+	for _, extentInfo := range sm.cloneGCExtents() {
+		var remains []uint64
+		for _, nodeID := range extentInfo.Replicates {
+			if err := sm.sendDeleteToNode(nodeID, extentInfo.ExtentID); err != nil {
+				xlog.Logger.Warnf("failed to delete extent %d on node %d: %v", extentInfo.ExtentID, nodeID, err)
+				remains = append(remains, nodeID)
+			}
+		}
+		if len(remains) == len(extentInfo.Replicates) {
+			continue
+		}
+
+		extentKey := formatGCExtentKey(extentInfo.ExtentID)
+		var op clientv3.Op
+		if len(remains) == 0 {
+			op = clientv3.OpDelete(extentKey)
+		} else {
+			extentInfo.Replicates = remains
+			edata, err := extentInfo.Marshal()
+			utils.Check(err)
+			op = clientv3.OpPut(extentKey, string(edata))
+		}
+		err := manager.EtctSetKVS(sm.client, []clientv3.Cmp{
+			clientv3.Compare(clientv3.Value(sm.leaderKey), "=", sm.memberValue),
+		}, []clientv3.Op{op})
+		if err != nil {
+			xlog.Logger.Warnf(err.Error())
+			continue
+		}
+
+		sm.gcLock.Lock()
+		if len(remains) == 0 {
+			delete(sm.gcExtents, extentInfo.ExtentID)
+			xlog.Logger.Infof("extent %d is deleted on all nodes", extentInfo.ExtentID)
+		} else {
+			sm.gcExtents[extentInfo.ExtentID] = extentInfo
+		}
+		sm.gcLock.Unlock()
+	}
+}
+
+func (sm *StreamManager) sendDeleteToNode(nodeID uint64, extentID uint64) error {
+	sm.nodeLock.RLock()
+	node, ok := sm.nodes[nodeID]
+	sm.nodeLock.RUnlock()
+	if !ok {
+		//node has been removed from cluster, nothing to delete
+		return nil
+	}
+	pctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := conn.GetPools().Connect(node.Address)
+	if pool == nil {
+		return conn.ErrNoConnection
+	}
+	c := pb.NewExtentServiceClient(pool.Get())
+	_, err := c.DeleteExtent(pctx, &pb.DeleteExtentRequest{
+		ExtentID: extentID,
+	})
+	return err
+}
@@ -13,6 +13,9 @@ type AllocExtentPolicy interface {
 type SimplePolicy struct{}
 
 func (sp *SimplePolicy) AllocExtent(ns []NodeStatus, count int, keepNodes []uint64) ([]NodeStatus, error) {
+	if count > len(ns) {
+		return nil, errors.Errorf("cannot find enough nodes")
+	}
 	sort.Slice(ns, func(a, b int) bool {
 		if ns[a].lastEcho.After(ns[b].lastEcho) {
 			return true
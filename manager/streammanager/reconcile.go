@@ -0,0 +1,208 @@
+package streammanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/journeymidnight/autumn/conn"
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/utils"
+	"github.com/journeymidnight/autumn/xlog"
+	"github.com/pkg/errors"
+)
+
+/*
+seal一致性检查流程:
+1. StreamAllocExtent时, receiveCommitlength选出的长度作为ExtentInfo.SealSize, 和新的extent在
+同一个etcd transaction里面写入. SealSize是sealed extent唯一可信的长度
+2. sealExtents不检查返回值, 有的副本可能没有seal(超时, node不在线), 所以后台定期检查所有
+SealSize不为0的extent的每个副本:
+	2a. 副本长度大于SealSize, 或者长度等于SealSize但是没有seal: 发送Seal(SealSize), node会truncate
+	2b. 副本长度小于SealSize: 发送RecoverExtent, node从一个完整的副本读取缺少的block, 写入后seal
+	2c. 副本在ExtentInfo.Corrupts里(scrubber报告损坏): 发送RecoverExtent(drop=true), node删除损坏的副本,
+	从健康的副本复制所有block后seal, 成功后从Corrupts里去掉
+3. 所有副本都是SealSize并且已经seal的extent, 在这个term内不再检查, 除非又有副本被报告损坏
+*/
+
+const (
+	reconcileInterval = time.Minute
+	recoverTimeout    = 60 * time.Second
+)
+
+func (sm *StreamManager) startReconcile() {
+	sm.reconcileStopper = utils.NewStopper()
+	sm.reconcileStopper.RunWorker(sm.runReconcile)
+}
+
+func (sm *StreamManager) stopReconcile() {
+	if sm.reconcileStopper != nil {
+		sm.reconcileStopper.Stop()
+		sm.reconcileStopper = nil
+	}
+}
+
+func (sm *StreamManager) runReconcile() {
+	//extents whose replicates are all sealed at sealSize, only valid in one term
+	done := make(map[uint64]bool)
+	randTicker := utils.NewRandomTicker(reconcileInterval, 2*reconcileInterval)
+	defer randTicker.Stop()
+	for {
+		select {
+		case <-randTicker.C:
+			if !sm.AmLeader() {
+				continue
+			}
+			sealed := sm.sealedExtents()
+			current := make(map[uint64]bool)
+			for _, extentInfo := range sealed {
+				current[extentInfo.ExtentID] = true
+				//a done extent is checked again if scrubber reports a corrupt replicate
+				if done[extentInfo.ExtentID] && len(extentInfo.Corrupts) == 0 {
+					continue
+				}
+				if sm.reconcileExtent(extentInfo) {
+					done[extentInfo.ExtentID] = true
+				}
+			}
+			//extent is deleted
+			for extentID := range done {
+				if !current[extentID] {
+					delete(done, extentID)
+				}
+			}
+		case <-sm.reconcileStopper.ShouldStop():
+			return
+		}
+	}
+}
+
+//sealedExtents returns copies of extents whose sealSize is recorded
+func (sm *StreamManager) sealedExtents() []*pb.ExtentInfo {
+	sm.extentsLock.RLock()
+	defer sm.extentsLock.RUnlock()
+	var ret []*pb.ExtentInfo
+	for _, extentInfo := range sm.extents {
+		if extentInfo.SealSize > 0 {
+			ret = append(ret, proto.Clone(extentInfo).(*pb.ExtentInfo))
+		}
+	}
+	return ret
+}
+
+func (sm *StreamManager) extentServiceClient(nodeID uint64) (pb.ExtentServiceClient, string, error) {
+	sm.nodeLock.RLock()
+	node, ok := sm.nodes[nodeID]
+	sm.nodeLock.RUnlock()
+	if !ok {
+		return nil, "", errors.Errorf("no such node %d", nodeID)
+	}
+	pool := conn.GetPools().Connect(node.Address)
+	if pool == nil {
+		return nil, "", conn.ErrNoConnection
+	}
+	return pb.NewExtentServiceClient(pool.Get()), node.Address, nil
+}
+
+//reconcileExtent makes every replicate sealed at sealSize, returns true if all replicates are consistent
+func (sm *StreamManager) reconcileExtent(extentInfo *pb.ExtentInfo) bool {
+	sealSize := uint32(extentInfo.SealSize)
+	corrupts := make(map[uint64]bool)
+	for _, nodeID := range extentInfo.Corrupts {
+		corrupts[nodeID] = true
+	}
+
+	consistent := true
+	var source string //address of a replicate which has all blocks before sealSize
+	var shorter []uint64
+	var corrupt []uint64
+	for _, nodeID := range extentInfo.Replicates {
+		//corrupt replicate is dropped and copied from source
+		if corrupts[nodeID] {
+			corrupt = append(corrupt, nodeID)
+			continue
+		}
+		c, addr, err := sm.extentServiceClient(nodeID)
+		if err != nil {
+			xlog.Logger.Warnf("reconcile extent %d: %v", extentInfo.ExtentID, err)
+			consistent = false
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		res, err := c.CommitLength(ctx, &pb.CommitLengthRequest{
+			ExtentID: extentInfo.ExtentID,
+		})
+		if err == nil && (res.Length > sealSize || (res.Length == sealSize && !res.Sealed)) {
+			xlog.Logger.Infof("seal extent %d on node %d, length %d, seal size %d", extentInfo.ExtentID, nodeID, res.Length, sealSize)
+			_, err = c.Seal(ctx, &pb.SealRequest{
+				ExtentID:     extentInfo.ExtentID,
+				CommitLength: sealSize,
+			})
+		}
+		cancel()
+		if err != nil {
+			xlog.Logger.Warnf("reconcile extent %d on node %d: %v", extentInfo.ExtentID, nodeID, err)
+			consistent = false
+			continue
+		}
+		if res.Length < sealSize {
+			shorter = append(shorter, nodeID)
+			continue
+		}
+		if source == "" {
+			source = addr
+		}
+	}
+
+	for _, nodeID := range shorter {
+		consistent = false
+		if source == "" {
+			xlog.Logger.Errorf("extent %d has no complete replicate of seal size %d", extentInfo.ExtentID, sealSize)
+			break
+		}
+		c, _, err := sm.extentServiceClient(nodeID)
+		if err != nil {
+			continue
+		}
+		xlog.Logger.Infof("recover extent %d on node %d from %s, seal size %d", extentInfo.ExtentID, nodeID, source, sealSize)
+		ctx, cancel := context.WithTimeout(context.Background(), recoverTimeout)
+		_, err = c.RecoverExtent(ctx, &pb.RecoverExtentRequest{
+			ExtentID: extentInfo.ExtentID,
+			SealSize: sealSize,
+			Source:   source,
+		})
+		cancel()
+		if err != nil {
+			xlog.Logger.Warnf("failed to recover extent %d on node %d: %v", extentInfo.ExtentID, nodeID, err)
+		}
+	}
+
+	for _, nodeID := range corrupt {
+		consistent = false
+		if source == "" {
+			xlog.Logger.Errorf("extent %d has no healthy replicate to repair node %d", extentInfo.ExtentID, nodeID)
+			break
+		}
+		c, _, err := sm.extentServiceClient(nodeID)
+		if err != nil {
+			continue
+		}
+		xlog.Logger.Infof("repair corrupt extent %d on node %d from %s, seal size %d", extentInfo.ExtentID, nodeID, source, sealSize)
+		ctx, cancel := context.WithTimeout(context.Background(), recoverTimeout)
+		_, err = c.RecoverExtent(ctx, &pb.RecoverExtentRequest{
+			ExtentID: extentInfo.ExtentID,
+			SealSize: sealSize,
+			Source:   source,
+			Drop:     true,
+		})
+		cancel()
+		if err != nil {
+			xlog.Logger.Warnf("failed to repair extent %d on node %d: %v", extentInfo.ExtentID, nodeID, err)
+			continue
+		}
+		if err = sm.removeCorrupt(extentInfo.ExtentID, nodeID); err != nil {
+			xlog.Logger.Warnf("failed to remove node %d from corrupts of extent %d: %v", nodeID, extentInfo.ExtentID, err)
+		}
+	}
+	return consistent
+}
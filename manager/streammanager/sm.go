@@ -19,6 +19,8 @@ import (
 const (
 	idKey             = "AutumnSMIDKey"
 	electionKeyPrefix = "AutumnSMLeader"
+	defaultReplicates = 3
+	maxOpsInTxn       = 128 //default --max-txn-ops of etcd
 )
 
 type NodeStatus struct {
@@ -38,6 +40,14 @@ type StreamManager struct {
 	nodeLock utils.SafeMutex
 	nodes    map[uint64]*NodeStatus
 
+	//extents which are waiting to be deleted on nodes
+	gcLock    utils.SafeMutex
+	gcExtents map[uint64]*pb.ExtentInfo
+	gcStopper *utils.Stopper
+
+	//check replicates of sealed extents, see reconcile.go
+	reconcileStopper *utils.Stopper
+
 	etcd       *embed.Etcd
 	client     *clientv3.Client
 	config     *manager.Config
@@ -95,6 +105,8 @@ func (sm *StreamManager) runAsLeader() {
 	defer sm.extentsLock.Unlock()
 	sm.nodeLock.Lock()
 	defer sm.nodeLock.Unlock()
+	sm.gcLock.Lock()
+	defer sm.gcLock.Unlock()
 
 	//load streams
 	kvs, err := manager.EtcdRange(sm.client, "streams")
@@ -140,6 +152,10 @@ func (sm *StreamManager) runAsLeader() {
 		}
 		sm.extents[extentID] = &extentInfo
 	}
+	if err = sm.recountRefs(); err != nil {
+		xlog.Logger.Warnf(err.Error())
+		return
+	}
 
 	sm.nodes = make(map[uint64]*NodeStatus)
 
@@ -164,6 +180,27 @@ func (sm *StreamManager) runAsLeader() {
 		}
 	}
 
+	//load extents to be deleted
+	kvs, err = manager.EtcdRange(sm.client, "gcExtents")
+	if err != nil {
+		xlog.Logger.Warnf(err.Error())
+		return
+	}
+	sm.gcExtents = make(map[uint64]*pb.ExtentInfo)
+	for _, kv := range kvs {
+		extentID, err := parseKey(string(kv.Key), "gcExtents")
+		if err != nil {
+			xlog.Logger.Warnf(err.Error())
+			return
+		}
+		var extentInfo pb.ExtentInfo
+		if err = extentInfo.Unmarshal(kv.Value); err != nil {
+			xlog.Logger.Warnf(err.Error())
+			return
+		}
+		sm.gcExtents[extentID] = &extentInfo
+	}
+
 	atomic.StoreInt32(&sm.isLeader, 1)
 }
 
@@ -189,11 +226,15 @@ func (sm *StreamManager) LeaderLoop() {
 		sm.leaderKey = e.Key()
 		xlog.Logger.Infof("elected %d as leader", sm.ID)
 		sm.runAsLeader()
+		sm.startGC()
+		sm.startReconcile()
 
 		select {
 		case <-s.Done():
 			s.Close()
 			atomic.StoreInt32(&sm.isLeader, 0)
+			sm.stopGC()
+			sm.stopReconcile()
 			xlog.Logger.Info("%d's leadershipt expire", sm.ID)
 		}
 	}
@@ -245,5 +286,6 @@ func (sm *StreamManager) RegisterGRPC(grpcServer *grpc.Server) {
 }
 
 func (sm *StreamManager) Close() {
-
+	sm.stopGC()
+	sm.stopReconcile()
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -34,9 +35,14 @@ func (sm *StreamManager) CreateStream(ctx context.Context, req *pb.CreateStreamR
 	streamID := start
 	extentID := start + 1
 
+	replicates := req.Replicates
+	if replicates == 0 {
+		replicates = defaultReplicates
+	}
+
 	nodes := sm.cloneNodeStatus()
 
-	nodes, err = sm.policy.AllocExtent(nodes, 3, nil)
+	nodes, err = sm.policy.AllocExtent(nodes, int(replicates), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -50,8 +56,10 @@ func (sm *StreamManager) CreateStream(ctx context.Context, req *pb.CreateStreamR
 	//new  stream
 	streamKey := formatStreamKey(streamID)
 	streamInfo := pb.StreamInfo{
-		StreamID:  streamID,
-		ExtentIDs: []uint64{extentID},
+		StreamID:   streamID,
+		ExtentIDs:  []uint64{extentID},
+		Replicates: replicates,
+		Quorum:     req.Quorum,
 	}
 
 	sdata, err := streamInfo.Marshal()
@@ -62,6 +70,7 @@ func (sm *StreamManager) CreateStream(ctx context.Context, req *pb.CreateStreamR
 	extentInfo := pb.ExtentInfo{
 		ExtentID:   extentID,
 		Replicates: extractNodeId(nodes),
+		Refs:       1,
 	}
 
 	edata, err := extentInfo.Marshal()
@@ -82,7 +91,7 @@ func (sm *StreamManager) CreateStream(ctx context.Context, req *pb.CreateStreamR
 
 	//update memory, create stream and extent.
 
-	sm.addExtent(streamID, &extentInfo)
+	sm.addStream(proto.Clone(&streamInfo).(*pb.StreamInfo), &extentInfo)
 
 	return &pb.CreateStreamResponse{
 		Code:   pb.Code_OK,
@@ -103,21 +112,13 @@ func (sm *StreamManager) addNode(id uint64, addr string) {
 	}
 }
 
-func (sm *StreamManager) addExtent(streamID uint64, extent *pb.ExtentInfo) {
+func (sm *StreamManager) addStream(stream *pb.StreamInfo, extent *pb.ExtentInfo) {
 	sm.streamLock.Lock()
 	defer sm.streamLock.Unlock()
 	sm.extentsLock.Lock()
 	defer sm.extentsLock.Unlock()
 
-	s, ok := sm.streams[streamID]
-	if ok {
-		s.ExtentIDs = append(s.ExtentIDs, extent.ExtentID)
-	} else {
-		sm.streams[streamID] = &pb.StreamInfo{
-			StreamID:  streamID,
-			ExtentIDs: []uint64{extent.ExtentID},
-		}
-	}
+	sm.streams[stream.StreamID] = stream
 	sm.extents[extent.ExtentID] = extent
 }
 
@@ -155,8 +156,20 @@ func (sm *StreamManager) StreamAllocExtent(ctx context.Context, req *pb.StreamAl
 		return nil, errors.Errorf("extentID no match %d vs %d", id, req.ExtentToSeal)
 	}
 
-	//recevied commit length
-	size := sm.receiveCommitlength(ctx, nodes, req.ExtentToSeal)
+	stream := sm.cloneStream(req.StreamID)
+	if stream == nil {
+		return nil, errors.Errorf("no such stream %d", req.StreamID)
+	}
+	replicates := stream.Replicates
+	if replicates == 0 {
+		replicates = defaultReplicates
+	}
+
+	//recevied commit length, seal size must come from replicas, or committed data could be lost
+	size, err := sm.receiveCommitlength(ctx, nodes, req.ExtentToSeal, ackCount(len(nodes), stream.Quorum))
+	if err != nil {
+		return nil, err
+	}
 
 	sm.sealExtents(ctx, nodes, req.ExtentToSeal, size)
 
@@ -168,7 +181,7 @@ func (sm *StreamManager) StreamAllocExtent(ctx context.Context, req *pb.StreamAl
 
 	nodes = sm.cloneNodeStatus()
 
-	nodes, err = sm.policy.AllocExtent(nodes, 3, nil)
+	nodes, err = sm.policy.AllocExtent(nodes, int(replicates), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -177,8 +190,22 @@ func (sm *StreamManager) StreamAllocExtent(ctx context.Context, req *pb.StreamAl
 		return nil, err
 	}
 
+	//the stream could be changed by Truncate or StreamAttachExtents while sealing, re-read it
+	//under lock, so the changes are not overwritten
+	sm.streamLock.Lock()
+	defer sm.streamLock.Unlock()
+	sm.extentsLock.Lock()
+	defer sm.extentsLock.Unlock()
+	current, ok := sm.streams[req.StreamID]
+	if !ok {
+		return nil, errors.Errorf("no such stream %d", req.StreamID)
+	}
+	if n := len(current.ExtentIDs); n == 0 || current.ExtentIDs[n-1] != req.ExtentToSeal {
+		return nil, errors.Errorf("last extent of stream %d is not %d any more", req.StreamID, req.ExtentToSeal)
+	}
+	stream = proto.Clone(current).(*pb.StreamInfo)
+
 	//update etcd
-	stream := sm.cloneStream(req.StreamID)
 	stream.ExtentIDs = append(stream.ExtentIDs, extentID)
 	//add extentID to stream
 	streamKey := formatStreamKey(req.StreamID)
@@ -190,10 +217,9 @@ func (sm *StreamManager) StreamAllocExtent(ctx context.Context, req *pb.StreamAl
 	extentInfo := pb.ExtentInfo{
 		ExtentID:   extentID,
 		Replicates: extractNodeId(nodes),
+		Refs:       1,
 	}
 
-	//set old
-
 	edata, err := extentInfo.Marshal()
 	utils.Check(err)
 
@@ -202,6 +228,17 @@ func (sm *StreamManager) StreamAllocExtent(ctx context.Context, req *pb.StreamAl
 		clientv3.OpPut(extentKey, string(edata)),
 	}
 
+	//set old, sealSize is the only source of truth of committed length, replicates which failed to seal
+	//are reconciled later, see reconcile.go
+	var sealedExtent *pb.ExtentInfo
+	if old, ok := sm.extents[req.ExtentToSeal]; ok {
+		sealedExtent = proto.Clone(old).(*pb.ExtentInfo)
+		sealedExtent.SealSize = uint64(size)
+		odata, err := sealedExtent.Marshal()
+		utils.Check(err)
+		ops = append(ops, clientv3.OpPut(formatExtentReplicate(req.ExtentToSeal), string(odata)))
+	}
+
 	err = manager.EtctSetKVS(sm.client, []clientv3.Cmp{
 		clientv3.Compare(clientv3.Value(sm.leaderKey), "=", sm.memberValue),
 	}, ops)
@@ -211,7 +248,11 @@ func (sm *StreamManager) StreamAllocExtent(ctx context.Context, req *pb.StreamAl
 	}
 
 	//update memory
-	sm.addExtent(req.StreamID, &extentInfo)
+	sm.streams[req.StreamID] = stream
+	sm.extents[extentID] = &extentInfo
+	if sealedExtent != nil {
+		sm.extents[req.ExtentToSeal] = sealedExtent
+	}
 
 	return &pb.StreamAllocExtentResponse{
 		StreamID: req.StreamID,
@@ -241,14 +282,19 @@ func (sm *StreamManager) sealExtents(ctx context.Context, nodes []NodeStatus, ex
 			}
 		})
 	}
+	stopper.Wait()
 }
 
-func (sm *StreamManager) receiveCommitlength(ctx context.Context, nodes []NodeStatus, extentID uint64) uint32 {
+//receiveCommitlength returns the largest length which at least acks replicas have.
+//an acknowledged append is on at least acks replicas, if r of n replicas answer, at least
+//acks-(n-r) of them have it, so the (acks-(n-r))th largest length is committed. at least
+//n-acks+1 replicas must answer, or a lagging minority could decide the length
+func (sm *StreamManager) receiveCommitlength(ctx context.Context, nodes []NodeStatus, extentID uint64, acks int) (uint32, error) {
 	pctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
 	stopper := utils.NewStopper()
-	reCh := make(chan uint32)
+	reCh := make(chan uint32, len(nodes))
 	for _, node := range nodes {
 		addr := node.Address
 		stopper.RunWorker(func() {
@@ -268,18 +314,36 @@ func (sm *StreamManager) receiveCommitlength(ctx context.Context, nodes []NodeSt
 	}
 	stopper.Wait()
 	close(reCh)
-	//choose min of all size
-	ret := uint32(512)
+	var sizes []uint32
 	for size := range reCh {
 		if size == math.MaxUint32 {
 			continue
 		}
-		if size < ret {
-			ret = size
-		}
+		sizes = append(sizes, size)
 	}
+	return committedLength(sizes, len(nodes), acks, extentID)
+}
 
-	return ret
+//committedLength chooses the committed length from sizes reported by replicas
+func committedLength(sizes []uint32, replicates int, acks int, extentID uint64) (uint32, error) {
+	k := acks - (replicates - len(sizes))
+	if len(sizes) == 0 || k < 1 {
+		return 0, errors.Errorf("can not get commit length of extent %d, %d of %d replicas answer, %d acks",
+			extentID, len(sizes), replicates, acks)
+	}
+	//sort desc, choose the kth size. if all replicas answer, it is the acks-th size
+	sort.Slice(sizes, func(i, j int) bool {
+		return sizes[i] > sizes[j]
+	})
+	return sizes[k-1], nil
+}
+
+//ackCount returns how many replicas must acknowledge an append
+func ackCount(replicates int, quorum bool) int {
+	if quorum {
+		return replicates/2 + 1
+	}
+	return replicates
 }
 
 //FIXME: sendCmdToNodes()
@@ -307,7 +371,7 @@ func (sm *StreamManager) sendAllocToNodes(ctx context.Context, nodes []NodeStatu
 		})
 	}
 	stopper.Wait()
-	if complets != 3 || !sm.AmLeader() {
+	if int(complets) != len(nodes) || !sm.AmLeader() {
 		return errors.Errorf("not to create stream")
 	}
 	return nil
@@ -426,18 +490,26 @@ func (sm *StreamManager) Truncate(ctx context.Context, req *pb.TruncateRequest)
 	}
 	sm.streamLock.Lock()
 	defer sm.streamLock.Unlock()
+	sm.extentsLock.Lock()
+	defer sm.extentsLock.Unlock()
 	streamInfo, ok := sm.streams[req.StreamID]
 	if !ok {
 		return &pb.TruncateResponse{
 			Code: pb.Code_TruncateNotMatch,
 		}, nil
 	}
-	var i int
-	for i = range streamInfo.ExtentIDs {
-		if streamInfo.ExtentIDs[i] == req.ExtentID {
+	i := -1
+	for j := range streamInfo.ExtentIDs {
+		if streamInfo.ExtentIDs[j] == req.ExtentID {
+			i = j
 			break
 		}
 	}
+	if i == -1 {
+		return &pb.TruncateResponse{
+			Code: pb.Code_TruncateNotMatch,
+		}, nil
+	}
 
 	if i == 0 {
 		return &pb.TruncateResponse{
@@ -445,12 +517,9 @@ func (sm *StreamManager) Truncate(ctx context.Context, req *pb.TruncateRequest)
 	}
 
 	//update ETCD
-	newExtentIDs := streamInfo.ExtentIDs[i:]
 	streamKey := formatStreamKey(req.StreamID)
-	newStreamInfo := pb.StreamInfo{
-		StreamID:  req.StreamID,
-		ExtentIDs: newExtentIDs,
-	}
+	newStreamInfo := proto.Clone(streamInfo).(*pb.StreamInfo)
+	newStreamInfo.ExtentIDs = streamInfo.ExtentIDs[i:]
 
 	sdata, err := newStreamInfo.Marshal()
 	utils.Check(err)
@@ -458,6 +527,15 @@ func (sm *StreamManager) Truncate(ctx context.Context, req *pb.TruncateRequest)
 	ops := []clientv3.Op{
 		clientv3.OpPut(streamKey, string(sdata)),
 	}
+
+	//decrease refs of truncated extents, extents whose refs is 0 will be deleted by GC
+	newExtents := sm.changeRefs(streamInfo.ExtentIDs[:i], -1)
+	for _, extentInfo := range newExtents {
+		edata, err := extentInfo.Marshal()
+		utils.Check(err)
+		ops = append(ops, clientv3.OpPut(formatExtentReplicate(extentInfo.ExtentID), string(edata)))
+	}
+
 	err = manager.EtctSetKVS(sm.client, []clientv3.Cmp{
 		clientv3.Compare(clientv3.Value(sm.leaderKey), "=", sm.memberValue),
 	}, ops)
@@ -466,19 +544,259 @@ func (sm *StreamManager) Truncate(ctx context.Context, req *pb.TruncateRequest)
 		return nil, err
 	}
 
-	sm.streams[req.StreamID] = &newStreamInfo
+	sm.streams[req.StreamID] = newStreamInfo
+	for _, extentInfo := range newExtents {
+		sm.extents[extentInfo.ExtentID] = extentInfo
+	}
 	return &pb.TruncateResponse{
 		Code: pb.Code_OK}, nil
 }
 
+//StreamAttachExtents shares sealed extents with stream req.StreamID. The extents are
+//inserted before the last extent of the stream, because only the last extent is appendable.
+func (sm *StreamManager) StreamAttachExtents(ctx context.Context, req *pb.StreamAttachExtentsRequest) (*pb.StreamAttachExtentsResponse, error) {
+	if !sm.AmLeader() {
+		return nil, errors.Errorf("not a leader")
+	}
+	sm.streamLock.Lock()
+	defer sm.streamLock.Unlock()
+	sm.extentsLock.Lock()
+	defer sm.extentsLock.Unlock()
+
+	streamInfo, ok := sm.streams[req.StreamID]
+	if !ok {
+		return nil, errors.Errorf("no such stream %d", req.StreamID)
+	}
+
+	existed := make(map[uint64]bool)
+	for _, extentID := range streamInfo.ExtentIDs {
+		existed[extentID] = true
+	}
+	for _, extentID := range req.ExtentIDs {
+		if existed[extentID] {
+			return nil, errors.Errorf("extent %d is already in stream %d", extentID, req.StreamID)
+		}
+		existed[extentID] = true
+		if _, ok := sm.extents[extentID]; !ok {
+			return nil, errors.Errorf("no such extent %d", extentID)
+		}
+		if !sm.isSealed(extentID) {
+			return nil, errors.Errorf("extent %d is not sealed", extentID)
+		}
+	}
+
+	if len(req.ExtentIDs) == 0 {
+		return &pb.StreamAttachExtentsResponse{
+			Code:   pb.Code_OK,
+			Stream: proto.Clone(streamInfo).(*pb.StreamInfo),
+		}, nil
+	}
+
+	//update ETCD
+	n := len(streamInfo.ExtentIDs)
+	newExtentIDs := make([]uint64, 0, n+len(req.ExtentIDs))
+	newExtentIDs = append(newExtentIDs, streamInfo.ExtentIDs[:n-1]...)
+	newExtentIDs = append(newExtentIDs, req.ExtentIDs...)
+	newExtentIDs = append(newExtentIDs, streamInfo.ExtentIDs[n-1])
+	newStreamInfo := proto.Clone(streamInfo).(*pb.StreamInfo)
+	newStreamInfo.ExtentIDs = newExtentIDs
+	sdata, err := newStreamInfo.Marshal()
+	utils.Check(err)
+
+	ops := []clientv3.Op{
+		clientv3.OpPut(formatStreamKey(req.StreamID), string(sdata)),
+	}
+	newExtents := sm.changeRefs(req.ExtentIDs, 1)
+	for _, extentInfo := range newExtents {
+		edata, err := extentInfo.Marshal()
+		utils.Check(err)
+		ops = append(ops, clientv3.OpPut(formatExtentReplicate(extentInfo.ExtentID), string(edata)))
+	}
+
+	err = manager.EtctSetKVS(sm.client, []clientv3.Cmp{
+		clientv3.Compare(clientv3.Value(sm.leaderKey), "=", sm.memberValue),
+	}, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	//update memory
+	sm.streams[req.StreamID] = newStreamInfo
+	for _, extentInfo := range newExtents {
+		sm.extents[extentInfo.ExtentID] = extentInfo
+	}
+	xlog.Logger.Infof("attach extents %v to stream %d", req.ExtentIDs, req.StreamID)
+
+	return &pb.StreamAttachExtentsResponse{
+		Code:   pb.Code_OK,
+		Stream: proto.Clone(newStreamInfo).(*pb.StreamInfo),
+	}, nil
+}
+
+//ReportCorruptExtent records a corrupt replicate found by node's scrubber in ExtentInfo.Corrupts,
+//the replicate should be repaired from other replicates
+func (sm *StreamManager) ReportCorruptExtent(ctx context.Context, req *pb.ReportCorruptExtentRequest) (*pb.ReportCorruptExtentResponse, error) {
+	if !sm.AmLeader() {
+		return nil, errors.Errorf("not a leader")
+	}
+	sm.extentsLock.Lock()
+	defer sm.extentsLock.Unlock()
+
+	extentInfo, ok := sm.extents[req.ExtentID]
+	if !ok {
+		return nil, errors.Errorf("no such extent %d", req.ExtentID)
+	}
+	found := false
+	for _, nodeID := range extentInfo.Replicates {
+		if nodeID == req.NodeID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.Errorf("node %d is not a replicate of extent %d", req.NodeID, req.ExtentID)
+	}
+	xlog.Logger.Warnf("extent %d on node %d is corrupt: %s", req.ExtentID, req.NodeID, req.Reason)
+	for _, nodeID := range extentInfo.Corrupts {
+		if nodeID == req.NodeID {
+			return &pb.ReportCorruptExtentResponse{Code: pb.Code_OK}, nil
+		}
+	}
+
+	newExtentInfo := proto.Clone(extentInfo).(*pb.ExtentInfo)
+	newExtentInfo.Corrupts = append(newExtentInfo.Corrupts, req.NodeID)
+	edata, err := newExtentInfo.Marshal()
+	utils.Check(err)
+	err = manager.EtctSetKVS(sm.client, []clientv3.Cmp{
+		clientv3.Compare(clientv3.Value(sm.leaderKey), "=", sm.memberValue),
+	}, []clientv3.Op{
+		clientv3.OpPut(formatExtentReplicate(req.ExtentID), string(edata)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sm.extents[req.ExtentID] = newExtentInfo
+	return &pb.ReportCorruptExtentResponse{Code: pb.Code_OK}, nil
+}
+
+//removeCorrupt removes nodeID from ExtentInfo.Corrupts after the replicate is repaired
+func (sm *StreamManager) removeCorrupt(extentID uint64, nodeID uint64) error {
+	sm.extentsLock.Lock()
+	defer sm.extentsLock.Unlock()
+	extentInfo, ok := sm.extents[extentID]
+	if !ok {
+		return errors.Errorf("no such extent %d", extentID)
+	}
+	newExtentInfo := proto.Clone(extentInfo).(*pb.ExtentInfo)
+	newExtentInfo.Corrupts = nil
+	for _, id := range extentInfo.Corrupts {
+		if id != nodeID {
+			newExtentInfo.Corrupts = append(newExtentInfo.Corrupts, id)
+		}
+	}
+	edata, err := newExtentInfo.Marshal()
+	utils.Check(err)
+	err = manager.EtctSetKVS(sm.client, []clientv3.Cmp{
+		clientv3.Compare(clientv3.Value(sm.leaderKey), "=", sm.memberValue),
+	}, []clientv3.Op{
+		clientv3.OpPut(formatExtentReplicate(extentID), string(edata)),
+	})
+	if err != nil {
+		return err
+	}
+	sm.extents[extentID] = newExtentInfo
+	return nil
+}
+
+//changeRefs returns copies of extents with refs changed by delta, sm.extents is not modified.
+//caller must hold extentsLock
+func (sm *StreamManager) changeRefs(extentIDs []uint64, delta int) []*pb.ExtentInfo {
+	var ret []*pb.ExtentInfo
+	for _, extentID := range extentIDs {
+		extentInfo, ok := sm.extents[extentID]
+		if !ok {
+			continue
+		}
+		newExtentInfo := proto.Clone(extentInfo).(*pb.ExtentInfo)
+		if delta < 0 && newExtentInfo.Refs < uint64(-delta) {
+			xlog.Logger.Warnf("refs of extent %d is %d, can not decrease by %d", extentID, newExtentInfo.Refs, -delta)
+			newExtentInfo.Refs = 0
+		} else {
+			newExtentInfo.Refs = uint64(int64(newExtentInfo.Refs) + int64(delta))
+		}
+		ret = append(ret, newExtentInfo)
+	}
+	return ret
+}
+
+//isSealed returns true if extentID is not the last extent of any stream,
+//StreamAllocExtent always seals the last extent before appending a new one.
+//caller must hold streamLock
+func (sm *StreamManager) isSealed(extentID uint64) bool {
+	for _, s := range sm.streams {
+		if len(s.ExtentIDs) > 0 && s.ExtentIDs[len(s.ExtentIDs)-1] == extentID {
+			return false
+		}
+	}
+	return true
+}
+
+//recountRefs rebuilds refs of all extents from streams, refs of extents which were
+//created before refcount was introduced are 0. changed extents are saved in etcd before
+//sm.extents is updated.
+//caller must hold streamLock and extentsLock
+func (sm *StreamManager) recountRefs() error {
+	refs := make(map[uint64]uint64)
+	for _, s := range sm.streams {
+		for _, extentID := range s.ExtentIDs {
+			refs[extentID]++
+		}
+	}
+	var newExtents []*pb.ExtentInfo
+	for extentID, extentInfo := range sm.extents {
+		if extentInfo.Refs != refs[extentID] {
+			xlog.Logger.Warnf("refs of extent %d is %d, but it is in %d streams", extentID, extentInfo.Refs, refs[extentID])
+			newExtentInfo := proto.Clone(extentInfo).(*pb.ExtentInfo)
+			newExtentInfo.Refs = refs[extentID]
+			newExtents = append(newExtents, newExtentInfo)
+		}
+	}
+
+	//etcd limits the number of operations in a txn
+	for len(newExtents) > 0 {
+		n := len(newExtents)
+		if n > maxOpsInTxn {
+			n = maxOpsInTxn
+		}
+		var ops []clientv3.Op
+		for _, extentInfo := range newExtents[:n] {
+			edata, err := extentInfo.Marshal()
+			utils.Check(err)
+			ops = append(ops, clientv3.OpPut(formatExtentReplicate(extentInfo.ExtentID), string(edata)))
+		}
+		err := manager.EtctSetKVS(sm.client, []clientv3.Cmp{
+			clientv3.Compare(clientv3.Value(sm.leaderKey), "=", sm.memberValue),
+		}, ops)
+		if err != nil {
+			return err
+		}
+		for _, extentInfo := range newExtents[:n] {
+			sm.extents[extentInfo.ExtentID] = extentInfo
+		}
+		newExtents = newExtents[n:]
+	}
+	return nil
+}
+
 func (sm *StreamManager) getAppendExtentsAddr(streamID uint64) ([]NodeStatus, uint64, error) {
 	sm.streamLock.RLock()
 	s, ok := sm.streams[streamID]
-	lastExtentID := s.ExtentIDs[len(s.ExtentIDs)-1]
-	sm.streamLock.RUnlock()
 	if !ok {
+		sm.streamLock.RUnlock()
 		return nil, 0, errors.Errorf("no such stream %d", streamID)
 	}
+	lastExtentID := s.ExtentIDs[len(s.ExtentIDs)-1]
+	sm.streamLock.RUnlock()
 	sm.extentsLock.RLock()
 	extInfo, ok := sm.extents[lastExtentID]
 	sm.extentsLock.RUnlock()
@@ -496,7 +814,7 @@ func (sm *StreamManager) getAppendExtentsAddr(streamID uint64) ([]NodeStatus, ui
 
 func (sm *StreamManager) cloneStream(streamID uint64) *pb.StreamInfo {
 	sm.streamLock.RLock()
-	defer sm.streamLock.Unlock()
+	defer sm.streamLock.RUnlock()
 	stream, ok := sm.streams[streamID]
 	if !ok {
 		return nil
@@ -543,6 +861,10 @@ func formatExtentReplicate(ID uint64) string {
 	return fmt.Sprintf("extents/%d", ID)
 }
 
+func formatGCExtentKey(ID uint64) string {
+	return fmt.Sprintf("gcExtents/%d", ID)
+}
+
 func parseKey(s string, prefix string) (uint64, error) {
 
 	parts := strings.Split(s, "/")
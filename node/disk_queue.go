@@ -0,0 +1,184 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless  by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"os"
+
+	"github.com/journeymidnight/autumn/extent"
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/utils"
+	"github.com/journeymidnight/autumn/xlog"
+	"github.com/pkg/errors"
+)
+
+const (
+	maxAppendBatch = 64
+)
+
+var errQueueStopped = errors.New("disk queue is stopped")
+
+type appendRequest struct {
+	ex      *extent.Extent
+	blocks  []*pb.Block
+	offset  uint32
+	offsets []uint32
+	err     error
+	done    chan struct{}
+}
+
+/*
+diskQueue是一个硬盘上的append队列(group commit):
+1. Append/ReplicateBlocks把写请求放入队列, 等待完成. 调用者持有extent的锁, 所以一个batch里面
+每个extent最多只有一个请求
+2. 后台线程一次取出所有等待的请求(最多maxAppendBatch个), 依次写入各自的extent, 不调用sync
+3. 整个batch只调用一次syncDisk, 然后commit每个extent, 返回每个请求的offsets
+4. 如果sync失败, 所有extent都rollback到之前的commitLength
+5. 如果有SSD journal, 第3步只sync journal, extent所在的硬盘在journal checkpoint时才sync, 见journal.go
+*/
+type diskQueue struct {
+	dir     *os.File
+	journal *journal //could be nil
+	reqCh   chan *appendRequest
+	stopper *utils.Stopper
+}
+
+func newDiskQueue(dirName string, j *journal) (*diskQueue, error) {
+	dir, err := os.Open(dirName)
+	if err != nil {
+		return nil, err
+	}
+	q := &diskQueue{
+		dir:     dir,
+		journal: j,
+		reqCh:   make(chan *appendRequest, maxAppendBatch),
+		stopper: utils.NewStopper(),
+	}
+	q.stopper.RunWorker(q.run)
+	return q, nil
+}
+
+//append blocks until blocks are synced on disk, caller must hold ex's lock
+func (q *diskQueue) append(ex *extent.Extent, blocks []*pb.Block, offset uint32) ([]uint32, error) {
+	req := &appendRequest{
+		ex:     ex,
+		blocks: blocks,
+		offset: offset,
+		done:   make(chan struct{}),
+	}
+	select {
+	case q.reqCh <- req:
+	case <-q.stopper.ShouldStop():
+		return nil, errQueueStopped
+	}
+	select {
+	case <-req.done:
+	case <-q.stopper.ShouldStop():
+		//run could return before req is queued, nobody touches req after run returns
+		q.stopper.Wait()
+		select {
+		case <-req.done:
+		default:
+			return nil, errQueueStopped
+		}
+	}
+	return req.offsets, req.err
+}
+
+func (q *diskQueue) run() {
+	for {
+		select {
+		case req := <-q.reqCh:
+			batch := []*appendRequest{req}
+		slurp:
+			for len(batch) < maxAppendBatch {
+				select {
+				case req = <-q.reqCh:
+					batch = append(batch, req)
+				default:
+					break slurp
+				}
+			}
+			q.commit(batch)
+		case <-q.stopper.ShouldStop():
+			//reply requests which are still in the queue
+			for {
+				select {
+				case req := <-q.reqCh:
+					req.err = errQueueStopped
+					close(req.done)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (q *diskQueue) commit(batch []*appendRequest) {
+	ends := make([]uint32, len(batch))
+	var extents []*extent.Extent
+	for i, req := range batch {
+		req.offsets, ends[i], req.err = req.ex.WriteBlocksAt(req.blocks, req.offset)
+		if req.err == nil {
+			extents = append(extents, req.ex)
+		}
+	}
+
+	var err error
+	if len(extents) > 0 {
+		if q.journal != nil {
+			err = q.journal.write(batch)
+		} else {
+			err = syncDisk(q.dir, extents)
+		}
+	}
+
+	for i, req := range batch {
+		if req.err == nil {
+			if err != nil {
+				req.ex.Rollback()
+				req.offsets, req.err = nil, err
+			} else {
+				req.ex.Commit(ends[i])
+			}
+		}
+		close(req.done)
+	}
+
+	if q.journal != nil && q.journal.needCheckpoint() {
+		q.checkpoint()
+	}
+}
+
+//checkpoint syncs extents on disk, and then clears the journal
+func (q *diskQueue) checkpoint() {
+	if err := syncDisk(q.dir, q.journal.dirtyExtents()); err != nil {
+		xlog.Logger.Warnf("failed to sync extents, journal is kept: %v", err)
+		return
+	}
+	if err := q.journal.reset(); err != nil {
+		xlog.Logger.Warnf("failed to reset journal: %v", err)
+	}
+}
+
+func (q *diskQueue) stop() {
+	q.stopper.Stop()
+	if q.journal != nil {
+		q.checkpoint()
+		q.journal.close()
+	}
+	q.dir.Close()
+}
@@ -0,0 +1,215 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless  by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/journeymidnight/autumn/extent"
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/xlog"
+)
+
+const (
+	journalFileName = "extents.jnl"
+	//checkpoint when journal is bigger than maxJournalSize
+	maxJournalSize = 256 << 20
+)
+
+/*
+SSD journal:
+1. append先写入extent文件(不sync, 只在page cache), 再把相同的数据写入SSD上的journal, sync journal后就返回
+2. journal记录的格式: crc32(4 bytes) + length(4 bytes) + pb.ReplicateBlocksRequest
+3. journal超过maxJournalSize时做checkpoint: sync所有extent所在的硬盘, 然后清空journal
+4. node启动时, 先打开所有extent, 再按顺序replay journal:
+   4a. extent不存在(已经被删除)或者已经seal, 跳过
+   4b. record的offset小于extent的commitLength, 比较record的block和extent里的数据, 相同就跳过,
+   不同(没有sync的block被OpenExtent当成完整的)就truncate到offset, 重新写入
+   4c. record的offset等于extent的commitLength, 写入extent
+   4d. 最后一个record可能不完整(crc不对), 这个record没有返回给client, 丢弃
+   4e. record的offset大于extent的commitLength, extent缺少数据, journal无法修复, 打印警告后跳过,
+   由primary的catchUp或者seal后的reconcile补齐
+*/
+type journal struct {
+	file *os.File
+	size int64
+	//extents written since last checkpoint, removed extents are deleted by forget
+	dirtyLock sync.Mutex
+	dirty     map[uint64]*extent.Extent
+}
+
+func openJournal(dir string) (*journal, error) {
+	f, err := os.OpenFile(path.Join(dir, journalFileName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &journal{
+		file:  f,
+		dirty: make(map[uint64]*extent.Extent),
+	}, nil
+}
+
+//replay writes records in journal to extents, and then reset the journal
+func (j *journal) replay(getExtent func(uint64) *extent.Extent) error {
+	if _, err := j.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	count := 0
+	var head [8]byte
+	for {
+		if _, err := io.ReadFull(j.file, head[:]); err != nil {
+			break
+		}
+		checkSum := binary.BigEndian.Uint32(head[:4])
+		length := binary.BigEndian.Uint32(head[4:])
+		data := make([]byte, length)
+		if _, err := io.ReadFull(j.file, data); err != nil {
+			break
+		}
+		if crc32.ChecksumIEEE(data) != checkSum {
+			xlog.Logger.Warnf("journal record is corrupted, stop replay")
+			break
+		}
+		var record pb.ReplicateBlocksRequest
+		if err := record.Unmarshal(data); err != nil {
+			xlog.Logger.Warnf("journal record is corrupted, stop replay: %v", err)
+			break
+		}
+		if err := replayRecord(getExtent(record.ExtentID), &record); err != nil {
+			return err
+		}
+		count++
+	}
+	xlog.Logger.Infof("replay %d records from journal", count)
+	return j.reset()
+}
+
+func replayRecord(ex *extent.Extent, record *pb.ReplicateBlocksRequest) error {
+	//deleted or sealed
+	if ex == nil || ex.IsSeal() {
+		return nil
+	}
+	ex.Lock()
+	defer ex.Unlock()
+	commit := ex.CommitLength()
+	if record.Commit < commit {
+		//OpenExtent only verifies the last block, blocks written after last checkpoint were not synced,
+		//journal has the acknowledged data
+		if err := ex.VerifyBlocksAt(record.Blocks, record.Commit); err == nil {
+			return nil
+		}
+		xlog.Logger.Warnf("extent %d is different from journal at %d, truncate and replay", ex.ID, record.Commit)
+		if err := ex.Truncate(record.Commit); err != nil {
+			return err
+		}
+	}
+	if record.Commit > commit {
+		//can not be repaired from journal, skip the record and keep starting. the replica is
+		//shorter than others, it is caught up by primary or recovered after the extent is sealed
+		xlog.Logger.Warnf("extent %d misses data between %d and %d, skip journal record", ex.ID, commit, record.Commit)
+		return nil
+	}
+	_, err := ex.AppendBlocks(record.Blocks, &record.Commit)
+	return err
+}
+
+//write appends records of a batch to journal and syncs it
+func (j *journal) write(batch []*appendRequest) error {
+	var buf []byte
+	for _, req := range batch {
+		if req.err != nil {
+			continue
+		}
+		record := pb.ReplicateBlocksRequest{
+			ExtentID: req.ex.ID,
+			Commit:   req.offset,
+			Blocks:   req.blocks,
+		}
+		data, err := record.Marshal()
+		if err != nil {
+			return err
+		}
+		var head [8]byte
+		binary.BigEndian.PutUint32(head[:4], crc32.ChecksumIEEE(data))
+		binary.BigEndian.PutUint32(head[4:], uint32(len(data)))
+		buf = append(buf, head[:]...)
+		buf = append(buf, data...)
+	}
+	if len(buf) == 0 {
+		return nil
+	}
+	j.dirtyLock.Lock()
+	for _, req := range batch {
+		if req.err == nil {
+			j.dirty[req.ex.ID] = req.ex
+		}
+	}
+	j.dirtyLock.Unlock()
+	if _, err := j.file.WriteAt(buf, j.size); err != nil {
+		return err
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	j.size += int64(len(buf))
+	return nil
+}
+
+func (j *journal) needCheckpoint() bool {
+	return j.size > maxJournalSize
+}
+
+func (j *journal) dirtyExtents() []*extent.Extent {
+	j.dirtyLock.Lock()
+	defer j.dirtyLock.Unlock()
+	var ret []*extent.Extent
+	for _, ex := range j.dirty {
+		ret = append(ret, ex)
+	}
+	return ret
+}
+
+//reset is called after all data in journal is synced in extent files
+func (j *journal) reset() error {
+	if err := j.file.Truncate(0); err != nil {
+		return err
+	}
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+	j.size = 0
+	j.dirtyLock.Lock()
+	j.dirty = make(map[uint64]*extent.Extent)
+	j.dirtyLock.Unlock()
+	return nil
+}
+
+//forget is called after ex is removed or replaced, checkpoint does not sync it
+func (j *journal) forget(ex *extent.Extent) {
+	j.dirtyLock.Lock()
+	defer j.dirtyLock.Unlock()
+	if j.dirty[ex.ID] == ex {
+		delete(j.dirty, ex.ID)
+	}
+}
+
+func (j *journal) close() {
+	j.file.Close()
+}
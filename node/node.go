@@ -39,6 +39,10 @@ var (
 	_ = fmt.Printf
 )
 
+const (
+	loadExtentsConcurrency = 16
+)
+
 /*
 view about the extents it owns and where the peer replicas are for a given extent
 extentID:[nodeID, nodeID, nodeID]
@@ -48,25 +52,48 @@ type ExtentNode struct {
 	grcpServer  *grpc.Server
 	listenUrl   string
 	baseFileDir string
+	journalDir  string //optional, SSD journal
 	extentMap   *sync.Map
+	//checksum of new extents
+	checksumType extent.ChecksumType
 	//extentMap map[uint64]*extent.Extent //extent it owns: extentID => file
 	//TODO: cached SM date in EN
 	//replicates *sync.Map
 	//replicates map[uint64][]string //extentID => [addr1, addr2]
 
 	smClient *smclient.SMClient
+	//FIXME: one queue for each disk after node supports multiple disks
+	diskQueue *diskQueue
+
+	scrubRate    uint64 //bytes per second, 0 means no scrubber
+	scrubStopper *utils.Stopper
 }
 
-func NewExtentNode(baseFileDir string, listenUrl string, smAddr []string) *ExtentNode {
+//NewExtentNode creates a node, if journalDir is not empty, appends are acknowledged after
+//they are synced in journal
+func NewExtentNode(baseFileDir string, journalDir string, listenUrl string, smAddr []string) *ExtentNode {
 	utils.AssertTrue(xlog.Logger != nil)
 
 	return &ExtentNode{
 		extentMap: new(sync.Map),
 		//replicates:  new(sync.Map),
-		baseFileDir: baseFileDir,
-		listenUrl:   listenUrl,
-		smClient:    smclient.NewSMClient(smAddr),
+		baseFileDir:  baseFileDir,
+		journalDir:   journalDir,
+		checksumType: extent.DefaultChecksum,
+		scrubRate:    defaultScrubRate,
+		listenUrl:    listenUrl,
+		smClient:     smclient.NewSMClient(smAddr),
+	}
+}
+
+//SetChecksum sets checksum(adler32, crc32c or xxhash64) of new extents
+func (en *ExtentNode) SetChecksum(name string) error {
+	ct, err := extent.ParseChecksumType(name)
+	if err != nil {
+		return err
 	}
+	en.checksumType = ct
+	return nil
 }
 
 func (en *ExtentNode) getExtent(ID uint64) *extent.Extent {
@@ -96,6 +123,14 @@ func (en *ExtentNode) setReplicates(extentID uint64, addrs []string) {
 }
 */
 
+//appendBlocks merges concurrent appends on the same disk into one sync, caller must hold ex's lock
+func (en *ExtentNode) appendBlocks(ex *extent.Extent, blocks []*pb.Block, offset uint32) ([]uint32, error) {
+	if en.diskQueue == nil {
+		return ex.AppendBlocksAt(blocks, offset)
+	}
+	return en.diskQueue.append(ex, blocks, offset)
+}
+
 func (en *ExtentNode) RegisterNode() {
 	xlog.Logger.Infof("RegisterNode")
 
@@ -144,22 +179,55 @@ func (en *ExtentNode) LoadExtents() error {
 	if err != nil {
 		return err
 	}
+
+	//open extents in parallel, unsealed extents need to scan block headers
+	nameCh := make(chan string)
+	stopper := utils.NewStopper()
+	for i := 0; i < loadExtentsConcurrency; i++ {
+		stopper.RunWorker(func() {
+			for name := range nameCh {
+				ext, err := extent.OpenExtent(path.Join(en.baseFileDir, name))
+				if err != nil {
+					xlog.Logger.Warnf("can not open %s %v", name, err)
+					continue
+				}
+				en.setExtent(ext.ID, ext)
+			}
+		})
+	}
+	start := time.Now()
 	for _, info := range fileInfos {
 		name := info.Name()
 		if strings.HasSuffix(name, ".ext") {
-			ext, err := extent.OpenExtent(path.Join(en.baseFileDir, name))
-			if err != nil {
-				xlog.Logger.Warnf("can not open %s %v", name, err)
-				continue
-			}
-			en.setExtent(ext.ID, ext)
+			nameCh <- name
 		}
 	}
+	close(nameCh)
+	stopper.Wait()
+	xlog.Logger.Infof("open extents in %v", time.Since(start))
+
+	//replay journal before serving
+	var j *journal
+	if en.journalDir != "" {
+		if j, err = openJournal(en.journalDir); err != nil {
+			return err
+		}
+		if err = j.replay(en.getExtent); err != nil {
+			return err
+		}
+	}
+	if en.diskQueue, err = newDiskQueue(en.baseFileDir, j); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (en *ExtentNode) Shutdown() {
 	en.grcpServer.Stop()
+	en.stopScrub()
+	if en.diskQueue != nil {
+		en.diskQueue.stop()
+	}
 	//loop over all extent to close
 	//Range(f func(key, value interface{}) bool)
 	en.extentMap.Range(func(k, v interface{}) bool {
@@ -174,6 +242,7 @@ func (en *ExtentNode) ServeGRPC() error {
 		grpc.MaxRecvMsgSize(65<<20),
 		grpc.MaxSendMsgSize(65<<20),
 		grpc.MaxConcurrentStreams(1000),
+		grpc.StatsHandler(releaseHandler{}),
 	)
 
 	pb.RegisterExtentServiceServer(grpcServer, en)
@@ -186,5 +255,7 @@ func (en *ExtentNode) ServeGRPC() error {
 		grpcServer.Serve(listener)
 	}()
 	en.grcpServer = grpcServer
+	//verify extents in background
+	en.startScrub()
 	return nil
 }
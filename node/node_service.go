@@ -60,7 +60,7 @@ func (en *ExtentNode) ReplicateBlocks(ctx context.Context, req *pb.ReplicateBloc
 	}
 	ex.Lock()
 	defer ex.Unlock()
-	ret, err := ex.AppendBlocks(req.Blocks, &req.Commit)
+	ret, err := en.appendBlocks(ex, req.Blocks, req.Commit)
 	if err != nil {
 		return nil, err
 	}
@@ -83,24 +83,110 @@ func (en *ExtentNode) connPoolOfReplicates(peers []string) ([]*conn.Pool, error)
 	return ret, nil
 }
 
-//一般来说,需要用slurp的方式合并IO, 但是考虑到stream上层是由一单线程, io queue在partiion layer实现
+//connPoolOfQuorum returns nil for unhealthy peers, at least acks peers must be healthy
+func (en *ExtentNode) connPoolOfQuorum(peers []string, acks int) ([]*conn.Pool, error) {
+	var ret []*conn.Pool
+	healthy := 0
+	for _, peer := range peers {
+		pool := conn.GetPools().Connect(peer)
+		if pool != nil && pool.IsHealthy() {
+			healthy++
+		} else {
+			pool = nil
+		}
+		ret = append(ret, pool)
+	}
+	if healthy < acks {
+		return nil, errors.Errorf("only %d peers are healthy, need %d", healthy, acks)
+	}
+	return ret, nil
+}
+
+//catchUp sends blocks between the replica's commitLength and offset to a lagging replica
+func (en *ExtentNode) catchUp(ctx context.Context, client pb.ExtentServiceClient, ex *extent.Extent, offset uint32) error {
+	res, err := client.CommitLength(ctx, &pb.CommitLengthRequest{
+		ExtentID: ex.ID,
+	})
+	if err != nil {
+		return err
+	}
+	start := res.Length
+	if start > offset {
+		return errors.Errorf("replica of extent %d is longer than primary, %d vs %d", ex.ID, start, offset)
+	}
+	xlog.Logger.Infof("catch up replica of extent %d from %d to %d", ex.ID, start, offset)
+	for start < offset {
+		blocks, release, err := ex.ReadBlocksNoCopy(start, 16, (8 << 20))
+		if err != nil && err != extent.EndOfStream && err != extent.EndOfExtent {
+			return err
+		}
+		//primary could be appending new blocks, only send blocks before offset
+		var toSend []*pb.Block
+		end := start
+		for _, block := range blocks {
+			if end+block.BlockLength+512 > offset {
+				break
+			}
+			toSend = append(toSend, block)
+			end += block.BlockLength + 512
+		}
+		if len(toSend) == 0 {
+			release()
+			return errors.Errorf("can not read blocks of extent %d at %d", ex.ID, start)
+		}
+		_, err = client.ReplicateBlocks(ctx, &pb.ReplicateBlocksRequest{
+			ExtentID: ex.ID,
+			Commit:   start,
+			Blocks:   toSend,
+		})
+		release()
+		if err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+//写入通过diskQueue合并sync(group commit), 见disk_queue.go
 func (en *ExtentNode) Append(ctx context.Context, req *pb.AppendRequest) (*pb.AppendResponse, error) {
 	ex := en.getExtent(req.ExtentID)
 	if ex == nil {
 		xlog.Logger.Debugf("no extent %d", req.ExtentID)
 		return nil, errors.Errorf("not such extent")
 	}
+	if len(req.Peers) == 0 {
+		return nil, errors.Errorf("peers of extent %d is empty", req.ExtentID)
+	}
 	ex.Lock()
 	defer ex.Unlock()
 
-	pools, err := en.connPoolOfReplicates(req.Peers)
+	//peers[0] is primary
+	acks := len(req.Peers)
+	var pools []*conn.Pool
+	var err error
+	if req.Quorum {
+		acks = len(req.Peers)/2 + 1
+		pools, err = en.connPoolOfQuorum(req.Peers, acks)
+	} else {
+		pools, err = en.connPoolOfReplicates(req.Peers)
+	}
 	if err != nil {
 		return nil, err
 	}
 	offset := ex.CommitLength()
+	if req.ExpectedOffset != 0 {
+		//client knows where blocks should be, retried request is idempotent
+		offset = req.ExpectedOffset
+	}
 
-	pctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
+	//in quorum mode, slow replicas keep writing after Append returns,
+	//so pctx is canceled after all workers are done
+	parent := ctx
+	if req.Quorum {
+		parent = context.Background()
+	}
+	pctx, cancel := context.WithTimeout(parent, 5*time.Second)
 
 	//FIXME: put stopper into sync.Pool
 	stopper := utils.NewStopper()
@@ -108,33 +194,47 @@ func (en *ExtentNode) Append(ctx context.Context, req *pb.AppendRequest) (*pb.Ap
 	type Result struct {
 		Error   error
 		Offsets []uint32
+		Primary bool
 	}
-	retChan := make(chan Result, 3)
+	retChan := make(chan Result, len(pools))
 	//primary
 	stopper.RunWorker(func() {
 		//start := time.Now()
-		ret, err := ex.AppendBlocks(req.Blocks, &offset)
+		ret, err := en.appendBlocks(ex, req.Blocks, offset)
 		//fmt.Printf("len %d, %v\n", len(req.Blocks), time.Now().Sub(start))
 
 		if ret != nil {
-			retChan <- Result{Error: err, Offsets: ret}
+			retChan <- Result{Error: err, Offsets: ret, Primary: true}
 		} else {
-			retChan <- Result{Error: err}
+			retChan <- Result{Error: err, Primary: true}
 		}
 		xlog.Logger.Debugf("write primary done: %v, %v", ret, err)
 	})
 	//secondary
 
-	for i := 1; i < 3; i++ {
+	for i := 1; i < len(pools); i++ {
 		j := i
+		if pools[j] == nil {
+			retChan <- Result{Error: errors.Errorf("remote peer %s not healthy", req.Peers[j])}
+			continue
+		}
 		stopper.RunWorker(func() {
 			conn := pools[j].Get()
 			client := pb.NewExtentServiceClient(conn)
-			res, err := client.ReplicateBlocks(pctx, &pb.ReplicateBlocksRequest{
-				ExtentID: req.ExtentID,
-				Commit:   offset,
-				Blocks:   req.Blocks,
-			})
+			replicate := func() (*pb.ReplicateBlocksResponse, error) {
+				return client.ReplicateBlocks(pctx, &pb.ReplicateBlocksRequest{
+					ExtentID: req.ExtentID,
+					Commit:   offset,
+					Blocks:   req.Blocks,
+				})
+			}
+			res, err := replicate()
+			if err != nil && req.Quorum {
+				//replica could miss blocks of previous appends, catch up and retry
+				if err = en.catchUp(pctx, client, ex, offset); err == nil {
+					res, err = replicate()
+				}
+			}
 			if res != nil {
 				retChan <- Result{Error: err, Offsets: res.Offsets}
 			} else {
@@ -145,19 +245,47 @@ func (en *ExtentNode) Append(ctx context.Context, req *pb.AppendRequest) (*pb.Ap
 		})
 	}
 
-	stopper.Wait()
-	close(retChan)
+	go func() {
+		stopper.Wait()
+		cancel()
+	}()
+
 	var preOffsets []uint32
-	for result := range retChan {
-		if preOffsets == nil {
-			preOffsets = result.Offsets
+	var primaryDone bool
+	var appendErr error
+	success, failure := 0, 0
+	for i := 0; i < len(pools); i++ {
+		result := <-retChan
+		if result.Primary {
+			primaryDone = true
 		}
-		if result.Error != nil || !utils.EqualUint32(result.Offsets, preOffsets) {
-			return nil, result.Error
+		if result.Error == nil && preOffsets != nil && !utils.EqualUint32(result.Offsets, preOffsets) {
+			result.Error = errors.Errorf("block is not appended at the same offset [%v] vs [%v]", result.Offsets, preOffsets)
 		}
-		if !utils.EqualUint32(result.Offsets, preOffsets) {
-			return nil, errors.Errorf("block is not appended at the same offset [%v] vs [%v]", result.Offsets, preOffsets)
+		if result.Error != nil {
+			if result.Primary {
+				appendErr = result.Error
+			} else {
+				xlog.Logger.Warnf("replicate extent %d: %v", req.ExtentID, result.Error)
+				failure++
+				if failure > len(pools)-acks && appendErr == nil {
+					appendErr = result.Error
+				}
+			}
+		} else {
+			if preOffsets == nil {
+				preOffsets = result.Offsets
+			}
+			success++
 		}
+		//primary writes under ex's lock, so it must be done before unlock. only in quorum mode,
+		//slow replicas keep writing after Append returns
+		if req.Quorum && primaryDone && (appendErr != nil || success >= acks) {
+			break
+		}
+	}
+	if appendErr != nil {
+		return nil, appendErr
 	}
 	return &pb.AppendResponse{
 		Code:    pb.Code_OK,
@@ -183,11 +311,25 @@ func (en *ExtentNode) ReadBlocks(ctx context.Context, req *pb.ReadBlocksRequest)
 	if ex == nil {
 		return nil, errors.Errorf("no such extent")
 	}
-	blocks, err := ex.ReadBlocks(req.Offset, req.NumOfBlocks, (32 << 20))
+	if req.SealSize > 0 && req.Offset >= req.SealSize {
+		return &pb.ReadBlocksResponse{Code: pb.Code_EndOfExtent}, nil
+	}
+	//blocks of sealed extent refer to mmap, they are released after the response is sent
+	blocks, release, err := ex.ReadBlocksNoCopy(req.Offset, req.NumOfBlocks, (32 << 20))
+	if !releaseAfterSend(ctx, release) {
+		blocks = copyBlocks(blocks)
+		release()
+	}
 	if err != nil && err != extent.EndOfStream && err != extent.EndOfExtent {
 		xlog.Logger.Infof("request extentID: %d, offset: %d, numOfBlocks: %d: %v", req.ExtentID, req.Offset, req.NumOfBlocks, err)
 		return nil, err
 	}
+	if req.SealSize > 0 {
+		var reached bool
+		if blocks, reached = blocksBefore(blocks, req.Offset, req.SealSize); reached {
+			err = extent.EndOfExtent
+		}
+	}
 
 	xlog.Logger.Debugf("request extentID: %d, offset: %d, numOfBlocks: %d, response len(%d), %v ", req.ExtentID, req.Offset, req.NumOfBlocks,
 		len(blocks), err)
@@ -208,7 +350,7 @@ func (en *ExtentNode) AllocExtent(ctx context.Context, req *pb.AllocExtentReques
 		return nil, errors.Errorf("have extent, can not alloc new")
 	}
 
-	newEx, err := extent.CreateExtent(formatExtentName(en.baseFileDir, req.ExtentID), req.ExtentID)
+	newEx, err := extent.CreateExtentWithChecksum(formatExtentName(en.baseFileDir, req.ExtentID), req.ExtentID, en.checksumType)
 	if err != nil {
 		return nil, err
 	}
@@ -218,10 +360,27 @@ func (en *ExtentNode) AllocExtent(ctx context.Context, req *pb.AllocExtentReques
 	}, nil
 }
 
+//DeleteExtent is called by stream manager's gc, if the extent does not exist, it
+//has been deleted before, return OK
+func (en *ExtentNode) DeleteExtent(ctx context.Context, req *pb.DeleteExtentRequest) (*pb.DeleteExtentResponse, error) {
+	ex := en.getExtent(req.ExtentID)
+	if ex == nil {
+		return &pb.DeleteExtentResponse{Code: pb.Code_OK}, nil
+	}
+	en.extentMap.Delete(req.ExtentID)
+	en.forgetExtent(ex)
+	if err := ex.Remove(); err != nil {
+		xlog.Logger.Warnf("failed to remove extent %d: %v", req.ExtentID, err)
+		return nil, err
+	}
+	xlog.Logger.Infof("extent %d is deleted", req.ExtentID)
+	return &pb.DeleteExtentResponse{Code: pb.Code_OK}, nil
+}
+
 func (en *ExtentNode) Seal(ctx context.Context, req *pb.SealRequest) (*pb.SealResponse, error) {
 	ex := en.getExtent(req.ExtentID)
-	if ex != nil {
-		return nil, errors.Errorf("have extent, can not alloc new")
+	if ex == nil {
+		return nil, errors.Errorf("no such extent")
 	}
 	err := ex.Seal(req.CommitLength)
 	if err != nil {
@@ -233,14 +392,15 @@ func (en *ExtentNode) Seal(ctx context.Context, req *pb.SealRequest) (*pb.SealRe
 }
 func (en *ExtentNode) CommitLength(ctx context.Context, req *pb.CommitLengthRequest) (*pb.CommitLengthResponse, error) {
 	ex := en.getExtent(req.ExtentID)
-	if ex != nil {
-		return nil, errors.Errorf("have extent, can not alloc new")
+	if ex == nil {
+		return nil, errors.Errorf("no such extent")
 	}
 
 	l := ex.CommitLength()
 	return &pb.CommitLengthResponse{
 		Code:   pb.Code_OK,
 		Length: l,
+		Sealed: ex.IsSeal(),
 	}, nil
 
 }
@@ -266,3 +426,95 @@ func (en *ExtentNode) ReadEntries(ctx context.Context, req *pb.ReadEntriesReques
 		EndOffset: endOffset,
 	}, nil
 }
+
+//blocksBefore returns blocks which end before sealSize, a replicate could be longer than sealSize
+//before it is reconciled by sm. the second return value is true if sealSize is reached
+func blocksBefore(blocks []*pb.Block, offset uint32, sealSize uint32) ([]*pb.Block, bool) {
+	end := offset
+	for i, block := range blocks {
+		end += block.BlockLength + 512
+		if end > sealSize {
+			return blocks[:i], true
+		}
+	}
+	return blocks, end == sealSize
+}
+
+//RecoverExtent is called by sm if the replicate is shorter than sealSize, it copies missing blocks
+//from source and seals the extent. if req.Drop is set, the replicate is corrupt, it is replaced by
+//an empty extent before copying
+func (en *ExtentNode) RecoverExtent(ctx context.Context, req *pb.RecoverExtentRequest) (*pb.RecoverExtentResponse, error) {
+	ex := en.getExtent(req.ExtentID)
+	if req.Drop {
+		var err error
+		if ex, err = en.recreateExtent(req.ExtentID, ex); err != nil {
+			xlog.Logger.Warnf("failed to drop extent %d: %v", req.ExtentID, err)
+			return nil, err
+		}
+	}
+	if ex == nil {
+		return nil, errors.Errorf("no such extent")
+	}
+	pool := conn.GetPools().Connect(req.Source)
+	if pool == nil {
+		return nil, conn.ErrNoConnection
+	}
+	if err := en.copyBlocks(ctx, pb.NewExtentServiceClient(pool.Get()), ex, req.SealSize); err != nil {
+		xlog.Logger.Warnf("failed to recover extent %d from %s: %v", req.ExtentID, req.Source, err)
+		return nil, err
+	}
+	if err := ex.Seal(req.SealSize); err != nil {
+		return nil, err
+	}
+	xlog.Logger.Infof("extent %d is recovered from %s, seal size is %d", req.ExtentID, req.Source, req.SealSize)
+	return &pb.RecoverExtentResponse{Code: pb.Code_OK}, nil
+}
+
+//recreateExtent removes the corrupt replicate old(could be nil) and creates an empty one
+//forgetExtent removes ex from dirty extents of journal, ex is going to be closed
+func (en *ExtentNode) forgetExtent(ex *extent.Extent) {
+	if en.diskQueue != nil && en.diskQueue.journal != nil {
+		en.diskQueue.journal.forget(ex)
+	}
+}
+
+func (en *ExtentNode) recreateExtent(extentID uint64, old *extent.Extent) (*extent.Extent, error) {
+	if old != nil {
+		en.extentMap.Delete(extentID)
+		en.forgetExtent(old)
+		if err := old.Remove(); err != nil {
+			return nil, err
+		}
+		xlog.Logger.Infof("corrupt extent %d is dropped", extentID)
+	}
+	ex, err := extent.CreateExtentWithChecksum(formatExtentName(en.baseFileDir, extentID), extentID, en.checksumType)
+	if err != nil {
+		return nil, err
+	}
+	en.setExtent(extentID, ex)
+	return ex, nil
+}
+
+func (en *ExtentNode) copyBlocks(ctx context.Context, client pb.ExtentServiceClient, ex *extent.Extent, sealSize uint32) error {
+	ex.Lock()
+	defer ex.Unlock()
+	for offset := ex.CommitLength(); offset < sealSize; offset = ex.CommitLength() {
+		res, err := client.ReadBlocks(ctx, &pb.ReadBlocksRequest{
+			ExtentID:    ex.ID,
+			Offset:      offset,
+			NumOfBlocks: 4,
+			SealSize:    sealSize,
+		})
+		if err != nil {
+			return err
+		}
+		if len(res.Blocks) == 0 {
+			return errors.Errorf("can not read blocks of extent %d at %d", ex.ID, offset)
+		}
+		if _, err = en.appendBlocks(ex, res.Blocks, offset); err != nil {
+			return err
+		}
+	}
+	//journal is not replayed for sealed extents
+	return ex.Sync()
+}
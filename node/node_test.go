@@ -7,11 +7,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/journeymidnight/autumn/extent"
 	"github.com/journeymidnight/autumn/proto/pb"
 	"github.com/journeymidnight/autumn/utils"
 	"github.com/journeymidnight/autumn/xlog"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/stats"
 )
 
 var (
@@ -28,9 +30,9 @@ func TestBasicNode(t *testing.T) {
 	os.Mkdir("xnodestore2", 0744)
 	os.Mkdir("xnodestore3", 0744)
 
-	nodes[0] = NewExtentNode("xnodestore1", "127.0.0.1:3301", []string{"127.0.0.1:3401"})
-	nodes[1] = NewExtentNode("xnodestore2", "127.0.0.1:3302", []string{"127.0.0.1:3401"})
-	nodes[2] = NewExtentNode("xnodestore3", "127.0.0.1:3303", []string{"127.0.0.1:3401"})
+	nodes[0] = NewExtentNode("xnodestore1", "", "127.0.0.1:3301", []string{"127.0.0.1:3401"})
+	nodes[1] = NewExtentNode("xnodestore2", "", "127.0.0.1:3302", []string{"127.0.0.1:3401"})
+	nodes[2] = NewExtentNode("xnodestore3", "", "127.0.0.1:3303", []string{"127.0.0.1:3401"})
 
 	defer os.RemoveAll("xnodestore1")
 	defer os.RemoveAll("xnodestore2")
@@ -62,10 +64,162 @@ func TestBasicNode(t *testing.T) {
 	res, err := nodes[0].Append(context.Background(), &pb.AppendRequest{
 		ExtentID: 100,
 		Blocks:   []*pb.Block{block},
+		Peers:    []string{"127.0.0.1:3301", "127.0.0.1:3302", "127.0.0.1:3303"},
 	})
 	assert.Nil(t, err)
 	assert.Equal(t, uint32(512), res.Offsets[0])
 
+	//quorum append, extent 101 is missing on nodes[2]
+	for _, n := range nodes[:2] {
+		_, err := n.AllocExtent(context.Background(), &pb.AllocExtentRequest{
+			ExtentID: 101,
+		})
+		assert.Nil(t, err)
+	}
+	req := &pb.AppendRequest{
+		ExtentID: 101,
+		Blocks:   []*pb.Block{block},
+		Peers:    []string{"127.0.0.1:3301", "127.0.0.1:3302", "127.0.0.1:3303"},
+		Quorum:   true,
+	}
+	res, err = nodes[0].Append(context.Background(), req)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(512), res.Offsets[0])
+
+	//nodes[2] is lagging, it is caught up in next append
+	_, err = nodes[2].AllocExtent(context.Background(), &pb.AllocExtentRequest{
+		ExtentID: 101,
+	})
+	assert.Nil(t, err)
+	res, err = nodes[0].Append(context.Background(), req)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(512+4096+512), res.Offsets[0])
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, nodes[0].getExtent(101).CommitLength(), nodes[2].getExtent(101).CommitLength())
+
+	//blocks beyond sealSize are not returned
+	rres, err := nodes[2].ReadBlocks(context.Background(), &pb.ReadBlocksRequest{
+		ExtentID:    101,
+		Offset:      512,
+		NumOfBlocks: 10,
+		SealSize:    512 + 4096 + 512,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, pb.Code_EndOfExtent, rres.Code)
+	assert.Equal(t, 1, len(rres.Blocks))
+
+	//corrupt replicate on nodes[2] is dropped and copied from nodes[0]
+	sealSize := nodes[0].getExtent(100).CommitLength()
+	_, err = nodes[0].Seal(context.Background(), &pb.SealRequest{ExtentID: 100, CommitLength: sealSize})
+	assert.Nil(t, err)
+	old := nodes[2].getExtent(100)
+	_, err = nodes[2].RecoverExtent(context.Background(), &pb.RecoverExtentRequest{
+		ExtentID: 100,
+		SealSize: sealSize,
+		Source:   "127.0.0.1:3301",
+		Drop:     true,
+	})
+	assert.Nil(t, err)
+	ex := nodes[2].getExtent(100)
+	assert.True(t, ex != old)
+	assert.True(t, ex.IsSeal())
+	assert.Equal(t, sealSize, ex.CommitLength())
+
 	//_, err = node1.ReadBlocks(context.Background(), &pb.ReadBlocksRequest{ExtentID: 100, Offsets: []uint32{512}})
 
 }
+
+func TestJournalReplay(t *testing.T) {
+	os.Mkdir("xnodestore_j", 0744)
+	defer os.RemoveAll("xnodestore_j")
+
+	j, err := openJournal("xnodestore_j")
+	assert.Nil(t, err)
+	q, err := newDiskQueue("xnodestore_j", j)
+	assert.Nil(t, err)
+
+	fileName := formatExtentName("xnodestore_j", 100)
+	ex, err := extent.CreateExtent(fileName, 100)
+	assert.Nil(t, err)
+
+	data := make([]byte, 4096)
+	utils.SetRandStringBytes(data)
+	block := &pb.Block{
+		CheckSum:    utils.AdlerCheckSum(data),
+		BlockLength: 4096,
+		Data:        data,
+	}
+	ex.Lock()
+	offsets, err := q.append(ex, []*pb.Block{block, block}, 512)
+	ex.Unlock()
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{512, 512 + 4096 + 512}, offsets)
+	q.stopper.Stop()
+	assert.Equal(t, []*extent.Extent{ex}, j.dirtyExtents())
+	//removed extents are not synced by checkpoint
+	j.forget(ex)
+	assert.Equal(t, 0, len(j.dirtyExtents()))
+
+	//extent file lost data which was not synced
+	ex.Close()
+	assert.Nil(t, os.Truncate(fileName, 512))
+
+	ex, err = extent.OpenExtent(fileName)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(512), ex.CommitLength())
+	assert.Nil(t, j.replay(func(uint64) *extent.Extent { return ex }))
+	assert.Equal(t, uint32(512+2*(4096+512)), ex.CommitLength())
+	assert.Equal(t, int64(0), j.size)
+
+	//record after a hole is skipped
+	err = replayRecord(ex, &pb.ReplicateBlocksRequest{
+		ExtentID: 100,
+		Commit:   ex.CommitLength() + 4096 + 512,
+		Blocks:   []*pb.Block{block},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(512+2*(4096+512)), ex.CommitLength())
+}
+
+func TestReleaseAfterSend(t *testing.T) {
+	h := releaseHandler{}
+	released := false
+	release := func() { released = true }
+
+	//other rpcs are not tagged
+	ctx := h.TagRPC(context.Background(), &stats.RPCTagInfo{FullMethodName: "/pb.ExtentService/Append"})
+	assert.False(t, releaseAfterSend(ctx, release))
+
+	ctx = h.TagRPC(context.Background(), &stats.RPCTagInfo{FullMethodName: readBlocksMethod})
+	assert.True(t, releaseAfterSend(ctx, release))
+	h.HandleRPC(ctx, &stats.OutPayload{})
+	assert.False(t, released)
+	h.HandleRPC(ctx, &stats.End{})
+	assert.True(t, released)
+}
+
+func TestDiskQueueStopped(t *testing.T) {
+	os.Mkdir("xnodestore_q", 0744)
+	defer os.RemoveAll("xnodestore_q")
+
+	q, err := newDiskQueue("xnodestore_q", nil)
+	assert.Nil(t, err)
+	ex, err := extent.CreateExtent(formatExtentName("xnodestore_q", 100), 100)
+	assert.Nil(t, err)
+	defer ex.Close()
+	q.stopper.Stop()
+
+	data := make([]byte, 4096)
+	block := &pb.Block{
+		CheckSum:    utils.AdlerCheckSum(data),
+		BlockLength: 4096,
+		Data:        data,
+	}
+	//requests after run returns are queued or rejected, neither of them hangs
+	for i := 0; i < 10; i++ {
+		ex.Lock()
+		_, err = q.append(ex, []*pb.Block{block}, 512)
+		ex.Unlock()
+		assert.Equal(t, errQueueStopped, err)
+	}
+}
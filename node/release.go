@@ -0,0 +1,83 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless  by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"context"
+
+	"github.com/journeymidnight/autumn/proto/pb"
+	"google.golang.org/grpc/stats"
+)
+
+/*
+ReadBlocks零拷贝:
+1. sealed extent的block直接引用mmap, 见extent.ReadBlocksNoCopy
+2. grpc在ReadBlocks返回之后才序列化response, 所以ReadBlocks不能release mmap
+3. releaseHandler在ReadBlocks rpc开始时往ctx里放一个releaser, ReadBlocks把release放进去,
+grpc发送完response之后(stats.End)调用release
+4. ctx里没有releaser(比如直接调用ReadBlocks), 拷贝block之后马上release
+*/
+
+const readBlocksMethod = "/pb.ExtentService/ReadBlocks"
+
+type releaserKey struct{}
+
+//releaser is only used by the goroutine running the rpc, no lock
+type releaser struct {
+	release func()
+}
+
+//releaseHandler is a grpc stats handler, it releases blocks of ReadBlocks after they are sent
+type releaseHandler struct{}
+
+func (releaseHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if info.FullMethodName != readBlocksMethod {
+		return ctx
+	}
+	return context.WithValue(ctx, releaserKey{}, &releaser{})
+}
+
+func (releaseHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if _, ok := s.(*stats.End); !ok {
+		return
+	}
+	if r, ok := ctx.Value(releaserKey{}).(*releaser); ok && r.release != nil {
+		r.release()
+		r.release = nil
+	}
+}
+
+func (releaseHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (releaseHandler) HandleConn(ctx context.Context, s stats.ConnStats) {}
+
+//releaseAfterSend returns false if release can not be delayed until the response is sent
+func releaseAfterSend(ctx context.Context, release func()) bool {
+	r, ok := ctx.Value(releaserKey{}).(*releaser)
+	if !ok {
+		return false
+	}
+	r.release = release
+	return true
+}
+
+func copyBlocks(blocks []*pb.Block) []*pb.Block {
+	for _, block := range blocks {
+		block.Data = append([]byte(nil), block.Data...)
+	}
+	return blocks
+}
@@ -0,0 +1,183 @@
+/*
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless  by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/journeymidnight/autumn/extent"
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/utils"
+	"github.com/journeymidnight/autumn/xlog"
+	"github.com/pkg/errors"
+)
+
+/*
+scrub流程:
+1. 每隔scrubInterval, 遍历node上所有已经seal的extent. 没有seal的extent还在写入, 而且reconcile
+只修复seal之后的extent, 所以不检查
+2. 读出CommitLength之前的每个block, 检查checksum, 读的速度限制在scrubRate(bytes/s)以内,
+避免影响前台的读写
+3. 比较本地的长度和sm上ExtentInfo.SealSize
+4. 发现损坏的extent, 通过ReportCorruptExtent告诉sm, sm记录在ExtentInfo.Corrupts里面,
+sm的reconcile发送RecoverExtent(drop=true), node删除损坏的副本, 从健康的副本复制所有block后seal,
+成功后sm从Corrupts里去掉这个node(manager/streammanager/reconcile.go)
+*/
+
+const (
+	scrubInterval    = 24 * time.Hour
+	defaultScrubRate = 32 << 20 //32MB/s
+)
+
+var errScrubStopped = errors.New("scrubber is stopped")
+
+//scrubLimiter limits the read speed of one scrub round
+type scrubLimiter struct {
+	rate    uint64 //bytes per second
+	start   time.Time
+	scanned uint64
+	stopC   chan struct{}
+}
+
+//wait sleeps until n bytes are allowed, returns false if scrubber is stopped
+func (l *scrubLimiter) wait(n uint32) bool {
+	l.scanned += uint64(n)
+	expected := time.Duration(float64(l.scanned) / float64(l.rate) * float64(time.Second))
+	sleep := expected - time.Since(l.start)
+	if sleep <= 0 {
+		select {
+		case <-l.stopC:
+			return false
+		default:
+			return true
+		}
+	}
+	select {
+	case <-time.After(sleep):
+		return true
+	case <-l.stopC:
+		return false
+	}
+}
+
+//SetScrubRate sets the max read speed of scrubber in bytes per second, 0 disables scrubber
+func (en *ExtentNode) SetScrubRate(rate uint64) {
+	en.scrubRate = rate
+}
+
+func (en *ExtentNode) startScrub() {
+	if en.scrubRate == 0 {
+		return
+	}
+	en.scrubStopper = utils.NewStopper()
+	en.scrubStopper.RunWorker(en.runScrub)
+}
+
+func (en *ExtentNode) stopScrub() {
+	if en.scrubStopper != nil {
+		en.scrubStopper.Stop()
+		en.scrubStopper = nil
+	}
+}
+
+func (en *ExtentNode) runScrub() {
+	randTicker := utils.NewRandomTicker(scrubInterval, 2*scrubInterval)
+	defer randTicker.Stop()
+	for {
+		select {
+		case <-randTicker.C:
+			en.scrubAll()
+		case <-en.scrubStopper.ShouldStop():
+			return
+		}
+	}
+}
+
+func (en *ExtentNode) scrubAll() {
+	var extents []*extent.Extent
+	var sealed []uint64
+	en.extentMap.Range(func(k, v interface{}) bool {
+		ex := v.(*extent.Extent)
+		//corrupt unsealed extents could not be repaired by reconcile, they are sealed
+		//by stream client when append fails
+		if ex.IsSeal() {
+			extents = append(extents, ex)
+			sealed = append(sealed, ex.ID)
+		}
+		return true
+	})
+
+	var infos map[uint64]*pb.ExtentInfo
+	if len(sealed) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		var err error
+		if infos, err = en.smClient.ExtentInfo(ctx, sealed); err != nil {
+			//still verify checksums
+			xlog.Logger.Warnf("scrubber can not get extent info from sm: %v", err)
+		}
+		cancel()
+	}
+
+	limiter := &scrubLimiter{
+		rate:  en.scrubRate,
+		start: time.Now(),
+		stopC: en.scrubStopper.ShouldStop(),
+	}
+	for _, ex := range extents {
+		err := en.scrubExtent(ex, infos[ex.ID], limiter)
+		if err == errScrubStopped {
+			return
+		}
+		if err == nil {
+			continue
+		}
+		//extent could be deleted during scrubbing
+		if en.getExtent(ex.ID) != ex {
+			continue
+		}
+		xlog.Logger.Errorf("scrubber found extent %d is corrupt: %v", ex.ID, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err = en.smClient.ReportCorruptExtent(ctx, ex.ID, en.nodeID, err.Error()); err != nil {
+			xlog.Logger.Warnf("failed to report corrupt extent %d: %v", ex.ID, err)
+		}
+		cancel()
+	}
+	xlog.Logger.Infof("scrubber checked %d extents, %d bytes in %v", len(extents), limiter.scanned, time.Since(limiter.start))
+}
+
+//scrubExtent verifies all blocks of sealed extent, and compares sealed length with sealSize on sm
+func (en *ExtentNode) scrubExtent(ex *extent.Extent, info *pb.ExtentInfo, limiter *scrubLimiter) error {
+	end := ex.CommitLength()
+	//sealSize is 0 if sm does not record it
+	if info != nil && info.SealSize > 0 && uint64(end) != info.SealSize {
+		return errors.Errorf("sealed length is %d, but sealSize on sm is %d", end, info.SealSize)
+	}
+	offset := uint32(512)
+	for offset < end {
+		n, err := ex.VerifyBlock(offset)
+		if err != nil {
+			return err
+		}
+		offset += n
+		if !limiter.wait(n) {
+			return errScrubStopped
+		}
+	}
+	if offset != end {
+		return errors.Errorf("last block ends at %d, but commit length is %d", offset, end)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+// +build linux
+
+package node
+
+import (
+	"os"
+
+	"github.com/journeymidnight/autumn/extent"
+	"golang.org/x/sys/unix"
+)
+
+//syncDisk flushes all extents on the same filesystem with one syncfs
+func syncDisk(dir *os.File, extents []*extent.Extent) error {
+	return unix.Syncfs(int(dir.Fd()))
+}
@@ -0,0 +1,19 @@
+// +build !linux
+
+package node
+
+import (
+	"os"
+
+	"github.com/journeymidnight/autumn/extent"
+)
+
+//syncDisk syncs every extent if syncfs is not supported
+func syncDisk(dir *os.File, extents []*extent.Extent) error {
+	for _, ex := range extents {
+		if err := ex.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -80,6 +80,17 @@ func (ps *PartitionServer) Range(ctx context.Context, req *pspb.RangeRequest) (*
 	if rp == nil {
 		return nil, errors.New("no such partid")
 	}
+	if req.WithValues {
+		keys, values, err := rp.RangeValues(req.Prefix, req.Start, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		return &pspb.RangeResponse{
+			Truncated: 0,
+			Keys:      keys,
+			Values:    values,
+		}, nil
+	}
 	out := rp.Range(req.Prefix, req.Start, req.Limit)
 	return &pspb.RangeResponse{
 		Truncated: 0,
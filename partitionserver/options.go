@@ -0,0 +1,52 @@
+package partitionserver
+
+import (
+	"github.com/journeymidnight/autumn/rangepartition"
+	"github.com/pkg/errors"
+)
+
+//Options of a partition server, they are set by SetOptions before Init
+type Options struct {
+	//options of partitions, overridden by options of each partition saved in PM,
+	//Partition.Table.Compression is also the compression of log blocks
+	Partition rangepartition.Options
+	//size limit of extents in log streams and row streams
+	LogExtentSize uint32
+	RowExtentSize uint32
+	//data blocks of tables shared by all partitions, 0 means no cache
+	BlockCacheSize int64
+	//big values shared by all partitions, 0 means no cache
+	ValueCacheSize int64
+	//index and bloom filter of tables, 0 means they are kept in memory after loaded
+	IndexCacheSize int64
+	//extents of value log are reclaimed when discarded bytes are more than this ratio, 0 disables gc
+	GCDiscardRatio float64
+	//master keys to wrap data keys, empty means data is not encrypted
+	MasterKeyFile string
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Partition:      rangepartition.DefaultOptions(),
+		LogExtentSize:  defaultLogExtentSize,
+		RowExtentSize:  defaultRowExtentSize,
+		GCDiscardRatio: defaultGCDiscardRatio,
+	}
+}
+
+//Validate returns error if ps can not be started with opt
+func (opt Options) Validate() error {
+	if err := opt.Partition.Validate(); err != nil {
+		return err
+	}
+	if opt.LogExtentSize == 0 || opt.LogExtentSize > 2<<30 || opt.RowExtentSize == 0 || opt.RowExtentSize > 2<<30 {
+		return errors.Errorf("extent size must be in (0, 2048] MB")
+	}
+	if opt.BlockCacheSize < 0 || opt.ValueCacheSize < 0 || opt.IndexCacheSize < 0 {
+		return errors.Errorf("cache size is negative")
+	}
+	if opt.GCDiscardRatio < 0 || opt.GCDiscardRatio > 1 {
+		return errors.Errorf("discard ratio %v is not in [0, 1]", opt.GCDiscardRatio)
+	}
+	return nil
+}
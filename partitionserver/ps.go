@@ -1,6 +1,7 @@
 package partitionserver
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -12,12 +13,21 @@ import (
 	"github.com/journeymidnight/autumn/proto/pb"
 	"github.com/journeymidnight/autumn/proto/pspb"
 	"github.com/journeymidnight/autumn/rangepartition"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
+	"github.com/journeymidnight/autumn/rangepartition/table"
 	"github.com/journeymidnight/autumn/streamclient"
 	"github.com/journeymidnight/autumn/utils"
 	"github.com/journeymidnight/autumn/xlog"
+	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 )
 
+const (
+	defaultLogExtentSize  = 1 << 30   //1GB, extents of log are recycled by value log gc
+	defaultRowExtentSize  = 256 << 20 //256MB, extents of row stream are truncated after compaction
+	defaultGCDiscardRatio = 0.5
+)
+
 type partID_t = uint64
 type psID_t = uint64
 
@@ -33,6 +43,16 @@ type PartitionServer struct {
 	extentManager *streamclient.AutumnExtentManager
 	blockReader   *streamclient.AutumnBlockReader
 	grcpServer    *grpc.Server
+	//master keys to wrap data keys, nil means data is not encrypted
+	masterKeys *encryption.MasterKeys
+	//data blocks of tables shared by all partitions, nil means no cache
+	blockCache *table.BlockCache
+	//big values shared by all partitions, nil means no cache
+	valueCache *rangepartition.ValueCache
+	//index and bloom filter of tables, nil means they are kept in memory after loaded
+	indexCache *table.IndexCache
+	//options of ps and partitions, set by SetOptions
+	opt Options
 }
 
 func NewPartitionServer(smAddr []string, pmAddr []string, baseDir string, address string) *PartitionServer {
@@ -42,7 +62,66 @@ func NewPartitionServer(smAddr []string, pmAddr []string, baseDir string, addres
 		pmClient:        pmclient.NewAutumnPMClient(pmAddr),
 		baseFileDir:     baseDir,
 		address:         address,
+		opt:             DefaultOptions(),
+	}
+}
+
+//SetOptions validates opt, loads master keys and creates caches shared by all partitions.
+//old caches are closed, so it must be called before Init
+func (ps *PartitionServer) SetOptions(opt Options) error {
+	if err := opt.Validate(); err != nil {
+		return err
+	}
+	var mk *encryption.MasterKeys
+	var blockCache *table.BlockCache
+	var valueCache *rangepartition.ValueCache
+	var indexCache *table.IndexCache
+	var err error
+	if opt.MasterKeyFile != "" {
+		if mk, err = encryption.LoadMasterKeys(opt.MasterKeyFile); err != nil {
+			return err
+		}
+	}
+	closeCaches := func() {
+		blockCache.Close()
+		valueCache.Close()
+		indexCache.Close()
 	}
+	if opt.BlockCacheSize > 0 {
+		if blockCache, err = table.NewBlockCache(opt.BlockCacheSize); err != nil {
+			return err
+		}
+	}
+	if opt.ValueCacheSize > 0 {
+		if valueCache, err = rangepartition.NewValueCache(opt.ValueCacheSize); err != nil {
+			closeCaches()
+			return err
+		}
+	}
+	if opt.IndexCacheSize > 0 {
+		if indexCache, err = table.NewIndexCache(opt.IndexCacheSize); err != nil {
+			closeCaches()
+			return err
+		}
+	}
+
+	ps.blockCache.Close()
+	ps.valueCache.Close()
+	ps.indexCache.Close()
+	ps.blockCache, ps.valueCache, ps.indexCache = blockCache, valueCache, indexCache
+	ps.masterKeys = mk
+	ps.opt = opt
+	return nil
+}
+
+//BlockCacheMetrics returns hits and misses of block cache
+func (ps *PartitionServer) BlockCacheMetrics() (uint64, uint64) {
+	return ps.blockCache.Hits(), ps.blockCache.Misses()
+}
+
+//ValueCacheMetrics returns hits and misses of value cache
+func (ps *PartitionServer) ValueCacheMetrics() (uint64, uint64) {
+	return ps.valueCache.Hits(), ps.valueCache.Misses()
 }
 
 func (ps *PartitionServer) Init() {
@@ -80,7 +159,18 @@ func (ps *PartitionServer) startRangePartition(meta *pspb.PartitionMeta) error {
 	//1. pmclient get info
 	//2. streamclient connect
 	//3. open RangePartition
-	var row, log *streamclient.AutumnStreamClient
+	var row, log, blob *streamclient.AutumnStreamClient
+	var keys *encryption.KeyRegistry
+	opt := ps.opt.Partition.WithOverrides(meta.Opts)
+	if err := opt.Validate(); err != nil {
+		return errors.Wrapf(err, "options of partition %d", meta.PartID)
+	}
+	if ps.masterKeys != nil {
+		var err error
+		if keys, err = encryption.OpenKeyRegistry(ps.masterKeys, meta.Keys); err != nil {
+			return err
+		}
+	}
 
 	cleanup := func() {
 		if row != nil {
@@ -89,14 +179,21 @@ func (ps *PartitionServer) startRangePartition(meta *pspb.PartitionMeta) error {
 		if log != nil {
 			log.Close()
 		}
+		if blob != nil {
+			blob.Close()
+		}
 	}
 
 	row = streamclient.NewStreamClient(ps.smClient, ps.extentManager, meta.RowStream)
+	row.SetMaxExtentSize(ps.opt.RowExtentSize)
 	if err := row.Connect(); err != nil {
 		return err
 	}
 
 	log = streamclient.NewStreamClient(ps.smClient, ps.extentManager, meta.LogStream)
+	log.SetCompression(opt.Table.Compression)
+	log.SetEncryption(keys)
+	log.SetMaxExtentSize(ps.opt.LogExtentSize)
 
 	if err := log.Connect(); err != nil {
 		cleanup()
@@ -104,23 +201,67 @@ func (ps *PartitionServer) startRangePartition(meta *pspb.PartitionMeta) error {
 	}
 
 	openStream := func(si pb.StreamInfo) streamclient.StreamClient {
-		return streamclient.NewStreamClient(ps.smClient, ps.extentManager, si.StreamID)
+		sc := streamclient.NewStreamClient(ps.smClient, ps.extentManager, si.StreamID)
+		sc.SetCompression(opt.Table.Compression)
+		sc.SetEncryption(keys)
+		sc.SetMaxExtentSize(ps.opt.LogExtentSize)
+		return sc
 	}
 	var locs []*pspb.Location
 	if meta.Locs != nil {
 		locs = meta.Locs.Locs
 	}
 
-	var blobs []uint64
-	if meta.Blobs != nil {
-		blobs = meta.Blobs.Blob
+	var blobs []pb.StreamInfo
+	if meta.Blobs != nil && len(meta.Blobs.Blob) > 0 {
+		streams, _, err := ps.smClient.StreamInfo(context.Background(), meta.Blobs.Blob)
+		if err != nil {
+			cleanup()
+			return err
+		}
+		for _, id := range meta.Blobs.Blob {
+			if si, ok := streams[id]; ok {
+				blobs = append(blobs, *si)
+			}
+		}
+	}
+
+	//big values are written to the last blob stream, partitions bootstrapped without blob stream
+	//write them to log stream
+	var blobStream streamclient.StreamClient
+	if meta.Blobs != nil && len(meta.Blobs.Blob) > 0 {
+		blob = streamclient.NewStreamClient(ps.smClient, ps.extentManager, meta.Blobs.Blob[len(meta.Blobs.Blob)-1])
+		blob.SetCompression(opt.Table.Compression)
+		blob.SetEncryption(keys)
+		blob.SetMaxExtentSize(ps.opt.LogExtentSize)
+		if err := blob.Connect(); err != nil {
+			cleanup()
+			return err
+		}
+		blobStream = blob
+	}
+
+	var discard *pspb.DiscardStats
+	if len(meta.Discard) > 0 {
+		discard = new(pspb.DiscardStats)
+		if err := discard.Unmarshal(meta.Discard); err != nil {
+			cleanup()
+			return err
+		}
 	}
 
 	utils.AssertTrue(meta.Rg != nil)
 	utils.AssertTrue(meta.PartID != 0)
 
-	rp := rangepartition.OpenRangePartition(meta.PartID, row, log, ps.blockReader, meta.Rg.StartKey, meta.Rg.EndKey, locs,
-		blobs, ps.pmClient, openStream, nil)
+	rp := rangepartition.OpenRangePartition(meta.PartID, row, log, blobStream, ps.blockReader, meta.Rg.StartKey, meta.Rg.EndKey, locs,
+		blobs, discard, ps.pmClient, openStream, nil, rangepartition.Deps{
+			Keys:       keys,
+			BlockCache: ps.blockCache,
+			ValueCache: ps.valueCache,
+			IndexCache: ps.indexCache,
+		}, opt)
+
+	rp.StartGC(ps.opt.GCDiscardRatio)
 
 	//FIXME: check each partID is uniq
 	ps.Lock()
@@ -190,4 +331,10 @@ func (ps *PartitionServer) ServeGRPC() error {
 
 func (ps *PartitionServer) Shutdown() {
 	//FIXME
+	hits, misses := ps.BlockCacheMetrics()
+	xlog.Logger.Infof("block cache hits: %d, misses: %d", hits, misses)
+	hits, misses = ps.ValueCacheMetrics()
+	xlog.Logger.Infof("value cache hits: %d, misses: %d", hits, misses)
+	hits, misses = ps.indexCache.Hits(), ps.indexCache.Misses()
+	xlog.Logger.Infof("index cache hits: %d, misses: %d", hits, misses)
 }
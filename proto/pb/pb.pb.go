@@ -310,6 +310,11 @@ type AppendRequest struct {
 	ExtentID uint64   `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
 	Blocks   []*Block `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
 	Peers    []string `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`
+	//if expectedOffset is not 0, blocks must be appended at expectedOffset.
+	//if they have been appended at expectedOffset before, the request is a retry, return the same offsets
+	ExpectedOffset uint32 `protobuf:"varint,4,opt,name=expectedOffset,proto3" json:"expectedOffset,omitempty"`
+	//if quorum is true, append succeeds once a majority of peers acknowledge
+	Quorum bool `protobuf:"varint,5,opt,name=quorum,proto3" json:"quorum,omitempty"`
 }
 
 func (m *AppendRequest) Reset()         { *m = AppendRequest{} }
@@ -366,6 +371,20 @@ func (m *AppendRequest) GetPeers() []string {
 	return nil
 }
 
+func (m *AppendRequest) GetExpectedOffset() uint32 {
+	if m != nil {
+		return m.ExpectedOffset
+	}
+	return 0
+}
+
+func (m *AppendRequest) GetQuorum() bool {
+	if m != nil {
+		return m.Quorum
+	}
+	return false
+}
+
 type AppendResponse struct {
 	Code    Code     `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
 	Offsets []uint32 `protobuf:"varint,2,rep,packed,name=offsets,proto3" json:"offsets,omitempty"`
@@ -518,6 +537,7 @@ type ReadBlocksRequest struct {
 	ExtentID    uint64 `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
 	Offset      uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
 	NumOfBlocks uint32 `protobuf:"varint,3,opt,name=numOfBlocks,proto3" json:"numOfBlocks,omitempty"`
+	SealSize    uint32 `protobuf:"varint,4,opt,name=sealSize,proto3" json:"sealSize,omitempty"`
 }
 
 func (m *ReadBlocksRequest) Reset()         { *m = ReadBlocksRequest{} }
@@ -574,6 +594,13 @@ func (m *ReadBlocksRequest) GetNumOfBlocks() uint32 {
 	return 0
 }
 
+func (m *ReadBlocksRequest) GetSealSize() uint32 {
+	if m != nil {
+		return m.SealSize
+	}
+	return 0
+}
+
 type ReadBlocksResponse struct {
 	Code   Code     `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
 	Blocks []*Block `protobuf:"bytes,2,rep,name=blocks,proto3" json:"blocks,omitempty"`
@@ -717,6 +744,7 @@ func (m *CommitLengthRequest) GetExtentID() uint64 {
 type CommitLengthResponse struct {
 	Code   Code   `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
 	Length uint32 `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"`
+	Sealed bool   `protobuf:"varint,3,opt,name=sealed,proto3" json:"sealed,omitempty"`
 }
 
 func (m *CommitLengthResponse) Reset()         { *m = CommitLengthResponse{} }
@@ -766,6 +794,13 @@ func (m *CommitLengthResponse) GetLength() uint32 {
 	return 0
 }
 
+func (m *CommitLengthResponse) GetSealed() bool {
+	if m != nil {
+		return m.Sealed
+	}
+	return false
+}
+
 type SealRequest struct {
 	ExtentID     uint64 `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
 	CommitLength uint32 `protobuf:"varint,2,opt,name=commitLength,proto3" json:"commitLength,omitempty"`
@@ -862,6 +897,119 @@ func (m *SealResponse) GetCode() Code {
 	return Code_OK
 }
 
+//RecoverExtent copies blocks before sealSize from source, and seals the extent
+type RecoverExtentRequest struct {
+	ExtentID uint64 `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
+	SealSize uint32 `protobuf:"varint,2,opt,name=sealSize,proto3" json:"sealSize,omitempty"`
+	Source   string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Drop     bool   `protobuf:"varint,4,opt,name=drop,proto3" json:"drop,omitempty"`
+}
+
+func (m *RecoverExtentRequest) Reset()         { *m = RecoverExtentRequest{} }
+func (m *RecoverExtentRequest) String() string { return proto.CompactTextString(m) }
+func (*RecoverExtentRequest) ProtoMessage()    {}
+func (*RecoverExtentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{14}
+}
+func (m *RecoverExtentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecoverExtentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecoverExtentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecoverExtentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoverExtentRequest.Merge(m, src)
+}
+func (m *RecoverExtentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecoverExtentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoverExtentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoverExtentRequest proto.InternalMessageInfo
+
+func (m *RecoverExtentRequest) GetExtentID() uint64 {
+	if m != nil {
+		return m.ExtentID
+	}
+	return 0
+}
+
+func (m *RecoverExtentRequest) GetSealSize() uint32 {
+	if m != nil {
+		return m.SealSize
+	}
+	return 0
+}
+
+func (m *RecoverExtentRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *RecoverExtentRequest) GetDrop() bool {
+	if m != nil {
+		return m.Drop
+	}
+	return false
+}
+
+type RecoverExtentResponse struct {
+	Code Code `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
+}
+
+func (m *RecoverExtentResponse) Reset()         { *m = RecoverExtentResponse{} }
+func (m *RecoverExtentResponse) String() string { return proto.CompactTextString(m) }
+func (*RecoverExtentResponse) ProtoMessage()    {}
+func (*RecoverExtentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{15}
+}
+func (m *RecoverExtentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RecoverExtentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RecoverExtentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RecoverExtentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoverExtentResponse.Merge(m, src)
+}
+func (m *RecoverExtentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RecoverExtentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoverExtentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RecoverExtentResponse proto.InternalMessageInfo
+
+func (m *RecoverExtentResponse) GetCode() Code {
+	if m != nil {
+		return m.Code
+	}
+	return Code_OK
+}
+
 type ReadEntriesRequest struct {
 	ExtentID uint64 `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
 	Offset   uint32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
@@ -872,7 +1020,7 @@ func (m *ReadEntriesRequest) Reset()         { *m = ReadEntriesRequest{} }
 func (m *ReadEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadEntriesRequest) ProtoMessage()    {}
 func (*ReadEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{14}
+	return fileDescriptor_f80abaa17e25ccc8, []int{16}
 }
 func (m *ReadEntriesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -932,7 +1080,7 @@ func (m *ReadEntriesResponse) Reset()         { *m = ReadEntriesResponse{} }
 func (m *ReadEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadEntriesResponse) ProtoMessage()    {}
 func (*ReadEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{15}
+	return fileDescriptor_f80abaa17e25ccc8, []int{17}
 }
 func (m *ReadEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -992,7 +1140,7 @@ func (m *ReplicateBlocksRequest) Reset()         { *m = ReplicateBlocksRequest{}
 func (m *ReplicateBlocksRequest) String() string { return proto.CompactTextString(m) }
 func (*ReplicateBlocksRequest) ProtoMessage()    {}
 func (*ReplicateBlocksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{16}
+	return fileDescriptor_f80abaa17e25ccc8, []int{18}
 }
 func (m *ReplicateBlocksRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1051,7 +1199,7 @@ func (m *ReplicateBlocksResponse) Reset()         { *m = ReplicateBlocksResponse
 func (m *ReplicateBlocksResponse) String() string { return proto.CompactTextString(m) }
 func (*ReplicateBlocksResponse) ProtoMessage()    {}
 func (*ReplicateBlocksResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{17}
+	return fileDescriptor_f80abaa17e25ccc8, []int{19}
 }
 func (m *ReplicateBlocksResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1102,7 +1250,7 @@ func (m *AllocExtentRequest) Reset()         { *m = AllocExtentRequest{} }
 func (m *AllocExtentRequest) String() string { return proto.CompactTextString(m) }
 func (*AllocExtentRequest) ProtoMessage()    {}
 func (*AllocExtentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{18}
+	return fileDescriptor_f80abaa17e25ccc8, []int{20}
 }
 func (m *AllocExtentRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1146,7 +1294,7 @@ func (m *AllocExtentResponse) Reset()         { *m = AllocExtentResponse{} }
 func (m *AllocExtentResponse) String() string { return proto.CompactTextString(m) }
 func (*AllocExtentResponse) ProtoMessage()    {}
 func (*AllocExtentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{19}
+	return fileDescriptor_f80abaa17e25ccc8, []int{21}
 }
 func (m *AllocExtentResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1182,6 +1330,94 @@ func (m *AllocExtentResponse) GetCode() Code {
 	return Code_OK
 }
 
+type DeleteExtentRequest struct {
+	ExtentID uint64 `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
+}
+
+func (m *DeleteExtentRequest) Reset()         { *m = DeleteExtentRequest{} }
+func (m *DeleteExtentRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteExtentRequest) ProtoMessage()    {}
+func (*DeleteExtentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{22}
+}
+func (m *DeleteExtentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteExtentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteExtentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteExtentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteExtentRequest.Merge(m, src)
+}
+func (m *DeleteExtentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteExtentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteExtentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteExtentRequest proto.InternalMessageInfo
+
+func (m *DeleteExtentRequest) GetExtentID() uint64 {
+	if m != nil {
+		return m.ExtentID
+	}
+	return 0
+}
+
+type DeleteExtentResponse struct {
+	Code Code `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
+}
+
+func (m *DeleteExtentResponse) Reset()         { *m = DeleteExtentResponse{} }
+func (m *DeleteExtentResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteExtentResponse) ProtoMessage()    {}
+func (*DeleteExtentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{23}
+}
+func (m *DeleteExtentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DeleteExtentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DeleteExtentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DeleteExtentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteExtentResponse.Merge(m, src)
+}
+func (m *DeleteExtentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DeleteExtentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteExtentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteExtentResponse proto.InternalMessageInfo
+
+func (m *DeleteExtentResponse) GetCode() Code {
+	if m != nil {
+		return m.Code
+	}
+	return Code_OK
+}
+
 type StreamAllocExtentRequest struct {
 	StreamID     uint64 `protobuf:"varint,1,opt,name=streamID,proto3" json:"streamID,omitempty"`
 	ExtentToSeal uint64 `protobuf:"varint,2,opt,name=extentToSeal,proto3" json:"extentToSeal,omitempty"`
@@ -1191,7 +1427,7 @@ func (m *StreamAllocExtentRequest) Reset()         { *m = StreamAllocExtentReque
 func (m *StreamAllocExtentRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamAllocExtentRequest) ProtoMessage()    {}
 func (*StreamAllocExtentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{20}
+	return fileDescriptor_f80abaa17e25ccc8, []int{24}
 }
 func (m *StreamAllocExtentRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1243,7 +1479,7 @@ func (m *StreamAllocExtentResponse) Reset()         { *m = StreamAllocExtentResp
 func (m *StreamAllocExtentResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamAllocExtentResponse) ProtoMessage()    {}
 func (*StreamAllocExtentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{21}
+	return fileDescriptor_f80abaa17e25ccc8, []int{25}
 }
 func (m *StreamAllocExtentResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1294,7 +1530,7 @@ func (m *StreamInfoRequest) Reset()         { *m = StreamInfoRequest{} }
 func (m *StreamInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamInfoRequest) ProtoMessage()    {}
 func (*StreamInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{22}
+	return fileDescriptor_f80abaa17e25ccc8, []int{26}
 }
 func (m *StreamInfoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1340,7 +1576,7 @@ func (m *StreamInfoResponse) Reset()         { *m = StreamInfoResponse{} }
 func (m *StreamInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamInfoResponse) ProtoMessage()    {}
 func (*StreamInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{23}
+	return fileDescriptor_f80abaa17e25ccc8, []int{27}
 }
 func (m *StreamInfoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1398,7 +1634,7 @@ func (m *ExtentInfoRequest) Reset()         { *m = ExtentInfoRequest{} }
 func (m *ExtentInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*ExtentInfoRequest) ProtoMessage()    {}
 func (*ExtentInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{24}
+	return fileDescriptor_f80abaa17e25ccc8, []int{28}
 }
 func (m *ExtentInfoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1443,7 +1679,7 @@ func (m *ExtentInfoResponse) Reset()         { *m = ExtentInfoResponse{} }
 func (m *ExtentInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*ExtentInfoResponse) ProtoMessage()    {}
 func (*ExtentInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{25}
+	return fileDescriptor_f80abaa17e25ccc8, []int{29}
 }
 func (m *ExtentInfoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1493,7 +1729,7 @@ func (m *NodesInfoRequest) Reset()         { *m = NodesInfoRequest{} }
 func (m *NodesInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*NodesInfoRequest) ProtoMessage()    {}
 func (*NodesInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{26}
+	return fileDescriptor_f80abaa17e25ccc8, []int{30}
 }
 func (m *NodesInfoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1531,7 +1767,7 @@ func (m *NodesInfoResponse) Reset()         { *m = NodesInfoResponse{} }
 func (m *NodesInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*NodesInfoResponse) ProtoMessage()    {}
 func (*NodesInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{27}
+	return fileDescriptor_f80abaa17e25ccc8, []int{31}
 }
 func (m *NodesInfoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1582,7 +1818,7 @@ func (m *RegisterNodeRequest) Reset()         { *m = RegisterNodeRequest{} }
 func (m *RegisterNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterNodeRequest) ProtoMessage()    {}
 func (*RegisterNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{28}
+	return fileDescriptor_f80abaa17e25ccc8, []int{32}
 }
 func (m *RegisterNodeRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1627,7 +1863,7 @@ func (m *RegisterNodeResponse) Reset()         { *m = RegisterNodeResponse{} }
 func (m *RegisterNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*RegisterNodeResponse) ProtoMessage()    {}
 func (*RegisterNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{29}
+	return fileDescriptor_f80abaa17e25ccc8, []int{33}
 }
 func (m *RegisterNodeResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1671,13 +1907,15 @@ func (m *RegisterNodeResponse) GetNodeId() uint64 {
 }
 
 type CreateStreamRequest struct {
+	Replicates uint32 `protobuf:"varint,1,opt,name=replicates,proto3" json:"replicates,omitempty"`
+	Quorum     bool   `protobuf:"varint,2,opt,name=quorum,proto3" json:"quorum,omitempty"`
 }
 
 func (m *CreateStreamRequest) Reset()         { *m = CreateStreamRequest{} }
 func (m *CreateStreamRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateStreamRequest) ProtoMessage()    {}
 func (*CreateStreamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{30}
+	return fileDescriptor_f80abaa17e25ccc8, []int{34}
 }
 func (m *CreateStreamRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1706,6 +1944,20 @@ func (m *CreateStreamRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreateStreamRequest proto.InternalMessageInfo
 
+func (m *CreateStreamRequest) GetReplicates() uint32 {
+	if m != nil {
+		return m.Replicates
+	}
+	return 0
+}
+
+func (m *CreateStreamRequest) GetQuorum() bool {
+	if m != nil {
+		return m.Quorum
+	}
+	return false
+}
+
 type CreateStreamResponse struct {
 	Code   Code        `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
 	Stream *StreamInfo `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
@@ -1716,7 +1968,7 @@ func (m *CreateStreamResponse) Reset()         { *m = CreateStreamResponse{} }
 func (m *CreateStreamResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateStreamResponse) ProtoMessage()    {}
 func (*CreateStreamResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{31}
+	return fileDescriptor_f80abaa17e25ccc8, []int{35}
 }
 func (m *CreateStreamResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1775,7 +2027,7 @@ func (m *TruncateRequest) Reset()         { *m = TruncateRequest{} }
 func (m *TruncateRequest) String() string { return proto.CompactTextString(m) }
 func (*TruncateRequest) ProtoMessage()    {}
 func (*TruncateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{32}
+	return fileDescriptor_f80abaa17e25ccc8, []int{36}
 }
 func (m *TruncateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1826,7 +2078,7 @@ func (m *TruncateResponse) Reset()         { *m = TruncateResponse{} }
 func (m *TruncateResponse) String() string { return proto.CompactTextString(m) }
 func (*TruncateResponse) ProtoMessage()    {}
 func (*TruncateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{33}
+	return fileDescriptor_f80abaa17e25ccc8, []int{37}
 }
 func (m *TruncateResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1862,25 +2114,24 @@ func (m *TruncateResponse) GetCode() Code {
 	return Code_OK
 }
 
-//used in Etcd Campaign
-type MemberValue struct {
-	ID      uint64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Name    string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
-	GrpcURL string `protobuf:"bytes,3,opt,name=GrpcURL,proto3" json:"GrpcURL,omitempty"`
+//attach sealed extents of other streams to streamID, extents are shared and refcounted
+type StreamAttachExtentsRequest struct {
+	StreamID  uint64   `protobuf:"varint,1,opt,name=streamID,proto3" json:"streamID,omitempty"`
+	ExtentIDs []uint64 `protobuf:"varint,2,rep,packed,name=extentIDs,proto3" json:"extentIDs,omitempty"`
 }
 
-func (m *MemberValue) Reset()         { *m = MemberValue{} }
-func (m *MemberValue) String() string { return proto.CompactTextString(m) }
-func (*MemberValue) ProtoMessage()    {}
-func (*MemberValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{34}
+func (m *StreamAttachExtentsRequest) Reset()         { *m = StreamAttachExtentsRequest{} }
+func (m *StreamAttachExtentsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamAttachExtentsRequest) ProtoMessage()    {}
+func (*StreamAttachExtentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{38}
 }
-func (m *MemberValue) XXX_Unmarshal(b []byte) error {
+func (m *StreamAttachExtentsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MemberValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StreamAttachExtentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MemberValue.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StreamAttachExtentsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1890,57 +2141,49 @@ func (m *MemberValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *MemberValue) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MemberValue.Merge(m, src)
+func (m *StreamAttachExtentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamAttachExtentsRequest.Merge(m, src)
 }
-func (m *MemberValue) XXX_Size() int {
+func (m *StreamAttachExtentsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MemberValue) XXX_DiscardUnknown() {
-	xxx_messageInfo_MemberValue.DiscardUnknown(m)
+func (m *StreamAttachExtentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamAttachExtentsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MemberValue proto.InternalMessageInfo
+var xxx_messageInfo_StreamAttachExtentsRequest proto.InternalMessageInfo
 
-func (m *MemberValue) GetID() uint64 {
+func (m *StreamAttachExtentsRequest) GetStreamID() uint64 {
 	if m != nil {
-		return m.ID
+		return m.StreamID
 	}
 	return 0
 }
 
-func (m *MemberValue) GetName() string {
-	if m != nil {
-		return m.Name
-	}
-	return ""
-}
-
-func (m *MemberValue) GetGrpcURL() string {
+func (m *StreamAttachExtentsRequest) GetExtentIDs() []uint64 {
 	if m != nil {
-		return m.GrpcURL
+		return m.ExtentIDs
 	}
-	return ""
+	return nil
 }
 
-type ExtentInfo struct {
-	ExtentID   uint64   `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
-	Replicates []uint64 `protobuf:"varint,2,rep,packed,name=replicates,proto3" json:"replicates,omitempty"`
-	SealSize   uint64   `protobuf:"varint,3,opt,name=sealSize,proto3" json:"sealSize,omitempty"`
+type StreamAttachExtentsResponse struct {
+	Code   Code        `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
+	Stream *StreamInfo `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
 }
 
-func (m *ExtentInfo) Reset()         { *m = ExtentInfo{} }
-func (m *ExtentInfo) String() string { return proto.CompactTextString(m) }
-func (*ExtentInfo) ProtoMessage()    {}
-func (*ExtentInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{35}
+func (m *StreamAttachExtentsResponse) Reset()         { *m = StreamAttachExtentsResponse{} }
+func (m *StreamAttachExtentsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamAttachExtentsResponse) ProtoMessage()    {}
+func (*StreamAttachExtentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{39}
 }
-func (m *ExtentInfo) XXX_Unmarshal(b []byte) error {
+func (m *StreamAttachExtentsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ExtentInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StreamAttachExtentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ExtentInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StreamAttachExtentsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1950,17 +2193,237 @@ func (m *ExtentInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *ExtentInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExtentInfo.Merge(m, src)
+func (m *StreamAttachExtentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamAttachExtentsResponse.Merge(m, src)
 }
-func (m *ExtentInfo) XXX_Size() int {
+func (m *StreamAttachExtentsResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *ExtentInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExtentInfo.DiscardUnknown(m)
+func (m *StreamAttachExtentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamAttachExtentsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExtentInfo proto.InternalMessageInfo
+var xxx_messageInfo_StreamAttachExtentsResponse proto.InternalMessageInfo
+
+func (m *StreamAttachExtentsResponse) GetCode() Code {
+	if m != nil {
+		return m.Code
+	}
+	return Code_OK
+}
+
+func (m *StreamAttachExtentsResponse) GetStream() *StreamInfo {
+	if m != nil {
+		return m.Stream
+	}
+	return nil
+}
+
+type ReportCorruptExtentRequest struct {
+	ExtentID uint64 `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
+	NodeID   uint64 `protobuf:"varint,2,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Reason   string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (m *ReportCorruptExtentRequest) Reset()         { *m = ReportCorruptExtentRequest{} }
+func (m *ReportCorruptExtentRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportCorruptExtentRequest) ProtoMessage()    {}
+func (*ReportCorruptExtentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{40}
+}
+func (m *ReportCorruptExtentRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReportCorruptExtentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReportCorruptExtentRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReportCorruptExtentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportCorruptExtentRequest.Merge(m, src)
+}
+func (m *ReportCorruptExtentRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReportCorruptExtentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportCorruptExtentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportCorruptExtentRequest proto.InternalMessageInfo
+
+func (m *ReportCorruptExtentRequest) GetExtentID() uint64 {
+	if m != nil {
+		return m.ExtentID
+	}
+	return 0
+}
+
+func (m *ReportCorruptExtentRequest) GetNodeID() uint64 {
+	if m != nil {
+		return m.NodeID
+	}
+	return 0
+}
+
+func (m *ReportCorruptExtentRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ReportCorruptExtentResponse struct {
+	Code Code `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
+}
+
+func (m *ReportCorruptExtentResponse) Reset()         { *m = ReportCorruptExtentResponse{} }
+func (m *ReportCorruptExtentResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportCorruptExtentResponse) ProtoMessage()    {}
+func (*ReportCorruptExtentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{41}
+}
+func (m *ReportCorruptExtentResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReportCorruptExtentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReportCorruptExtentResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReportCorruptExtentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportCorruptExtentResponse.Merge(m, src)
+}
+func (m *ReportCorruptExtentResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReportCorruptExtentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportCorruptExtentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportCorruptExtentResponse proto.InternalMessageInfo
+
+func (m *ReportCorruptExtentResponse) GetCode() Code {
+	if m != nil {
+		return m.Code
+	}
+	return Code_OK
+}
+
+//used in Etcd Campaign
+type MemberValue struct {
+	ID      uint64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	GrpcURL string `protobuf:"bytes,3,opt,name=GrpcURL,proto3" json:"GrpcURL,omitempty"`
+}
+
+func (m *MemberValue) Reset()         { *m = MemberValue{} }
+func (m *MemberValue) String() string { return proto.CompactTextString(m) }
+func (*MemberValue) ProtoMessage()    {}
+func (*MemberValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{42}
+}
+func (m *MemberValue) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MemberValue.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MemberValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberValue.Merge(m, src)
+}
+func (m *MemberValue) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberValue proto.InternalMessageInfo
+
+func (m *MemberValue) GetID() uint64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *MemberValue) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MemberValue) GetGrpcURL() string {
+	if m != nil {
+		return m.GrpcURL
+	}
+	return ""
+}
+
+type ExtentInfo struct {
+	ExtentID   uint64   `protobuf:"varint,1,opt,name=extentID,proto3" json:"extentID,omitempty"`
+	Replicates []uint64 `protobuf:"varint,2,rep,packed,name=replicates,proto3" json:"replicates,omitempty"`
+	SealSize   uint64   `protobuf:"varint,3,opt,name=sealSize,proto3" json:"sealSize,omitempty"`
+	Refs       uint64   `protobuf:"varint,4,opt,name=refs,proto3" json:"refs,omitempty"`
+	Corrupts   []uint64 `protobuf:"varint,5,rep,packed,name=corrupts,proto3" json:"corrupts,omitempty"`
+}
+
+func (m *ExtentInfo) Reset()         { *m = ExtentInfo{} }
+func (m *ExtentInfo) String() string { return proto.CompactTextString(m) }
+func (*ExtentInfo) ProtoMessage()    {}
+func (*ExtentInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{43}
+}
+func (m *ExtentInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExtentInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExtentInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExtentInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtentInfo.Merge(m, src)
+}
+func (m *ExtentInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExtentInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtentInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtentInfo proto.InternalMessageInfo
 
 func (m *ExtentInfo) GetExtentID() uint64 {
 	if m != nil {
@@ -1983,16 +2446,32 @@ func (m *ExtentInfo) GetSealSize() uint64 {
 	return 0
 }
 
+func (m *ExtentInfo) GetRefs() uint64 {
+	if m != nil {
+		return m.Refs
+	}
+	return 0
+}
+
+func (m *ExtentInfo) GetCorrupts() []uint64 {
+	if m != nil {
+		return m.Corrupts
+	}
+	return nil
+}
+
 type StreamInfo struct {
-	StreamID  uint64   `protobuf:"varint,1,opt,name=streamID,proto3" json:"streamID,omitempty"`
-	ExtentIDs []uint64 `protobuf:"varint,2,rep,packed,name=extentIDs,proto3" json:"extentIDs,omitempty"`
+	StreamID   uint64   `protobuf:"varint,1,opt,name=streamID,proto3" json:"streamID,omitempty"`
+	ExtentIDs  []uint64 `protobuf:"varint,2,rep,packed,name=extentIDs,proto3" json:"extentIDs,omitempty"`
+	Replicates uint32   `protobuf:"varint,3,opt,name=replicates,proto3" json:"replicates,omitempty"`
+	Quorum     bool     `protobuf:"varint,4,opt,name=quorum,proto3" json:"quorum,omitempty"`
 }
 
 func (m *StreamInfo) Reset()         { *m = StreamInfo{} }
 func (m *StreamInfo) String() string { return proto.CompactTextString(m) }
 func (*StreamInfo) ProtoMessage()    {}
 func (*StreamInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{36}
+	return fileDescriptor_f80abaa17e25ccc8, []int{44}
 }
 func (m *StreamInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2035,6 +2514,20 @@ func (m *StreamInfo) GetExtentIDs() []uint64 {
 	return nil
 }
 
+func (m *StreamInfo) GetReplicates() uint32 {
+	if m != nil {
+		return m.Replicates
+	}
+	return 0
+}
+
+func (m *StreamInfo) GetQuorum() bool {
+	if m != nil {
+		return m.Quorum
+	}
+	return false
+}
+
 type NodeInfo struct {
 	NodeID  uint64 `protobuf:"varint,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
 	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
@@ -2044,7 +2537,7 @@ func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
 func (m *NodeInfo) String() string { return proto.CompactTextString(m) }
 func (*NodeInfo) ProtoMessage()    {}
 func (*NodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{37}
+	return fileDescriptor_f80abaa17e25ccc8, []int{45}
 }
 func (m *NodeInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2104,12 +2597,16 @@ func init() {
 	proto.RegisterType((*CommitLengthResponse)(nil), "pb.CommitLengthResponse")
 	proto.RegisterType((*SealRequest)(nil), "pb.SealRequest")
 	proto.RegisterType((*SealResponse)(nil), "pb.SealResponse")
+	proto.RegisterType((*RecoverExtentRequest)(nil), "pb.RecoverExtentRequest")
+	proto.RegisterType((*RecoverExtentResponse)(nil), "pb.RecoverExtentResponse")
 	proto.RegisterType((*ReadEntriesRequest)(nil), "pb.ReadEntriesRequest")
 	proto.RegisterType((*ReadEntriesResponse)(nil), "pb.ReadEntriesResponse")
 	proto.RegisterType((*ReplicateBlocksRequest)(nil), "pb.ReplicateBlocksRequest")
 	proto.RegisterType((*ReplicateBlocksResponse)(nil), "pb.ReplicateBlocksResponse")
 	proto.RegisterType((*AllocExtentRequest)(nil), "pb.AllocExtentRequest")
 	proto.RegisterType((*AllocExtentResponse)(nil), "pb.AllocExtentResponse")
+	proto.RegisterType((*DeleteExtentRequest)(nil), "pb.DeleteExtentRequest")
+	proto.RegisterType((*DeleteExtentResponse)(nil), "pb.DeleteExtentResponse")
 	proto.RegisterType((*StreamAllocExtentRequest)(nil), "pb.StreamAllocExtentRequest")
 	proto.RegisterType((*StreamAllocExtentResponse)(nil), "pb.StreamAllocExtentResponse")
 	proto.RegisterType((*StreamInfoRequest)(nil), "pb.StreamInfoRequest")
@@ -2128,6 +2625,10 @@ func init() {
 	proto.RegisterType((*CreateStreamResponse)(nil), "pb.CreateStreamResponse")
 	proto.RegisterType((*TruncateRequest)(nil), "pb.TruncateRequest")
 	proto.RegisterType((*TruncateResponse)(nil), "pb.TruncateResponse")
+	proto.RegisterType((*StreamAttachExtentsRequest)(nil), "pb.StreamAttachExtentsRequest")
+	proto.RegisterType((*StreamAttachExtentsResponse)(nil), "pb.StreamAttachExtentsResponse")
+	proto.RegisterType((*ReportCorruptExtentRequest)(nil), "pb.ReportCorruptExtentRequest")
+	proto.RegisterType((*ReportCorruptExtentResponse)(nil), "pb.ReportCorruptExtentResponse")
 	proto.RegisterType((*MemberValue)(nil), "pb.MemberValue")
 	proto.RegisterType((*ExtentInfo)(nil), "pb.ExtentInfo")
 	proto.RegisterType((*StreamInfo)(nil), "pb.StreamInfo")
@@ -2137,99 +2638,117 @@ func init() {
 func init() { proto.RegisterFile("pb.proto", fileDescriptor_f80abaa17e25ccc8) }
 
 var fileDescriptor_f80abaa17e25ccc8 = []byte{
-	// 1467 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xf7, 0xfa, 0x5f, 0xe2, 0x67, 0x3b, 0x71, 0x26, 0x4e, 0x6a, 0xb6, 0xa9, 0x65, 0x86, 0xaa,
-	0xa4, 0x05, 0x4a, 0x9b, 0x4a, 0x80, 0x2a, 0x2a, 0x91, 0x36, 0x2e, 0x35, 0x8d, 0x93, 0x30, 0x49,
-	0x10, 0x70, 0x29, 0x6b, 0xef, 0x24, 0xb5, 0x6a, 0x7b, 0x97, 0xdd, 0x75, 0xd5, 0x54, 0xe2, 0x82,
-	0xf8, 0x00, 0x7c, 0x15, 0x3e, 0x00, 0xf7, 0x1e, 0x7b, 0xe4, 0x88, 0x9a, 0x03, 0x57, 0x3e, 0x02,
-	0x9a, 0x7f, 0xbb, 0xb3, 0x5e, 0x3b, 0x2c, 0x2a, 0xb7, 0x7d, 0x6f, 0x66, 0xde, 0x9f, 0xdf, 0xfc,
-	0xe6, 0xbd, 0x67, 0xc3, 0xa2, 0xdb, 0xbb, 0xe9, 0x7a, 0x4e, 0xe0, 0xa0, 0xac, 0xdb, 0x33, 0xeb,
-	0xa7, 0xce, 0xa9, 0xc3, 0xc5, 0x8f, 0xd9, 0x97, 0x58, 0xc1, 0x3f, 0x41, 0xa1, 0x3d, 0x0e, 0xbc,
-	0x33, 0x54, 0x83, 0xdc, 0x33, 0x7a, 0xd6, 0x30, 0x5a, 0xc6, 0x66, 0x85, 0xb0, 0x4f, 0x54, 0x87,
-	0xc2, 0x73, 0x6b, 0x38, 0xa1, 0x8d, 0x2c, 0xd7, 0x09, 0x01, 0x21, 0xc8, 0x8f, 0x68, 0x60, 0x35,
-	0x72, 0x2d, 0x63, 0xb3, 0x4a, 0xf8, 0x37, 0x32, 0x61, 0xf1, 0xd8, 0xa7, 0x5e, 0x97, 0xe9, 0xf3,
-	0x5c, 0x1f, 0xca, 0x68, 0x03, 0x4a, 0xed, 0x17, 0xee, 0xc0, 0xa3, 0xfe, 0x76, 0xd0, 0x28, 0xb4,
-	0x8c, 0xcd, 0x3c, 0x89, 0x14, 0xf8, 0x67, 0x03, 0x4a, 0xdc, 0x7f, 0x67, 0x7c, 0xe2, 0xa0, 0xcb,
-	0x90, 0x1b, 0x3a, 0xa7, 0x3c, 0x86, 0xf2, 0x56, 0xe9, 0xa6, 0xdb, 0xbb, 0xc9, 0xd7, 0x08, 0xd3,
-	0x32, 0x27, 0xf4, 0x45, 0x40, 0xc7, 0x41, 0x67, 0x87, 0x47, 0x94, 0x27, 0xa1, 0x8c, 0xd6, 0xa1,
-	0xe8, 0x9c, 0x9c, 0xf8, 0x34, 0x90, 0x61, 0x49, 0x09, 0x5d, 0x85, 0x2a, 0xf5, 0x83, 0xc1, 0xc8,
-	0x0a, 0xa8, 0x7d, 0x38, 0x78, 0x49, 0x79, 0x74, 0x79, 0x12, 0x57, 0xe2, 0x09, 0x14, 0xee, 0x0f,
-	0x9d, 0xfe, 0x33, 0xe6, 0xa2, 0xff, 0x94, 0xf6, 0x9f, 0x1d, 0x4e, 0x46, 0x3c, 0x88, 0x2a, 0x09,
-	0x65, 0xd4, 0x82, 0x72, 0x8f, 0x6d, 0xda, 0xa5, 0xe3, 0xd3, 0xe0, 0x29, 0x8f, 0xa0, 0x4a, 0x74,
-	0x15, 0x3b, 0x3d, 0xf1, 0xa9, 0xb7, 0x63, 0x49, 0x74, 0x2a, 0x24, 0x94, 0x19, 0x6a, 0xb6, 0x25,
-	0xd1, 0xa9, 0x10, 0xfe, 0x8d, 0x6d, 0xa8, 0x6e, 0xbb, 0x2e, 0x1d, 0xdb, 0x84, 0xfe, 0x38, 0xa1,
-	0x7e, 0x10, 0xcb, 0xd0, 0x98, 0xca, 0xf0, 0x5d, 0x28, 0x72, 0x5f, 0x7e, 0x23, 0xdb, 0xca, 0x29,
-	0x74, 0x78, 0xd4, 0x44, 0x2e, 0xb0, 0xfb, 0x72, 0x29, 0xf5, 0xfc, 0x46, 0xae, 0x95, 0xdb, 0x2c,
-	0x11, 0x21, 0xe0, 0x47, 0xb0, 0xa4, 0xbc, 0xf8, 0xae, 0x33, 0xf6, 0x29, 0xda, 0x80, 0x7c, 0xdf,
-	0xb1, 0x29, 0x77, 0xb1, 0xb4, 0xb5, 0xc8, 0x0c, 0x3d, 0x70, 0x6c, 0x4a, 0xb8, 0x16, 0x35, 0x60,
-	0x41, 0x80, 0x27, 0x3c, 0x55, 0x89, 0x12, 0xf1, 0x6d, 0x58, 0x7d, 0xe0, 0x51, 0x2b, 0xa0, 0x6d,
-	0x1e, 0x94, 0x16, 0xb5, 0x1f, 0x78, 0xd4, 0x1a, 0x45, 0x51, 0x2b, 0x19, 0x1f, 0x40, 0x3d, 0x7e,
-	0x24, 0x55, 0x08, 0x17, 0xdc, 0x34, 0x1e, 0xc0, 0x0a, 0xa1, 0x96, 0xcd, 0x33, 0xf7, 0xd3, 0x00,
-	0x17, 0x51, 0x23, 0x1b, 0xa3, 0x46, 0x0b, 0xca, 0xe3, 0xc9, 0x68, 0xff, 0x44, 0x58, 0x92, 0xbc,
-	0xd1, 0x55, 0xf8, 0x18, 0x90, 0xee, 0x2a, 0x55, 0xe8, 0xff, 0x7e, 0x4d, 0xf8, 0x0a, 0x2c, 0x1c,
-	0x58, 0x67, 0x43, 0xc7, 0xb2, 0x19, 0x2b, 0x38, 0x5b, 0xc4, 0xa3, 0xe3, 0xdf, 0x1c, 0x65, 0x67,
-	0x34, 0x1a, 0x04, 0x82, 0x55, 0x29, 0x52, 0xc4, 0xbb, 0x50, 0x8f, 0x1f, 0x49, 0x15, 0xea, 0x3a,
-	0x14, 0x87, 0x3a, 0x97, 0xa5, 0x84, 0xbb, 0x50, 0x3e, 0xa4, 0xd6, 0x30, 0x0d, 0xb6, 0x18, 0x2a,
-	0x7d, 0xcd, 0xb1, 0x34, 0x14, 0xd3, 0xe1, 0x0f, 0xa1, 0x22, 0xcc, 0xa5, 0x09, 0x0a, 0xff, 0x20,
-	0x30, 0x67, 0xcf, 0x7e, 0x40, 0xdf, 0xea, 0x7e, 0xd7, 0xa1, 0xe8, 0x51, 0x77, 0x68, 0x9d, 0xa9,
-	0x92, 0x20, 0x24, 0xfc, 0x12, 0x56, 0x63, 0x1e, 0x52, 0x61, 0xf5, 0x3e, 0x2c, 0x50, 0x71, 0x40,
-	0xde, 0x6b, 0x35, 0x2c, 0x4e, 0xac, 0x70, 0x11, 0xb5, 0xca, 0xaa, 0x1d, 0x1d, 0xdb, 0xfb, 0x7a,
-	0x2d, 0x8a, 0x14, 0xd8, 0x81, 0x75, 0x42, 0xdd, 0xe1, 0xa0, 0x6f, 0x05, 0xf4, 0x3f, 0x31, 0x58,
-	0x20, 0xaa, 0x32, 0x14, 0x92, 0xc6, 0xb5, 0xdc, 0x3c, 0xae, 0x7d, 0x0d, 0x97, 0x12, 0x0e, 0xdf,
-	0xb2, 0x0a, 0xdc, 0x02, 0xb4, 0x3d, 0x1c, 0x3a, 0xfd, 0x44, 0x11, 0x98, 0x4b, 0xcf, 0x3b, 0xb0,
-	0x1a, 0x3b, 0x91, 0x8a, 0x08, 0xdf, 0x43, 0xe3, 0x90, 0x57, 0x91, 0xd9, 0xce, 0xe6, 0x55, 0x1c,
-	0x46, 0x49, 0xe1, 0xf8, 0xc8, 0x61, 0xb4, 0x93, 0xf5, 0x23, 0xa6, 0xc3, 0x4f, 0xe0, 0x9d, 0x19,
-	0xb6, 0x65, 0x58, 0x17, 0x19, 0xbf, 0x06, 0x45, 0x61, 0x88, 0x9b, 0x2d, 0x6f, 0x2d, 0x71, 0x16,
-	0x88, 0x3c, 0x19, 0x0d, 0xe4, 0x2a, 0xbe, 0x0d, 0x2b, 0xc2, 0x01, 0xd7, 0xca, 0xa8, 0x37, 0xa0,
-	0xa4, 0x0c, 0xf9, 0x0d, 0xa3, 0x95, 0x63, 0x8d, 0x30, 0x54, 0xe0, 0x57, 0x59, 0x40, 0xfa, 0x99,
-	0x54, 0xb7, 0x74, 0x0f, 0x16, 0x84, 0x05, 0x45, 0xcb, 0xf7, 0xd8, 0x86, 0xa4, 0x19, 0xa9, 0xf2,
-	0x45, 0x37, 0x55, 0x67, 0xd8, 0x71, 0x11, 0xb0, 0x62, 0xd0, 0xbc, 0xe3, 0x22, 0x45, 0x75, 0x5c,
-	0x9e, 0x31, 0xbf, 0x82, 0x8a, 0x6e, 0x57, 0x9f, 0x20, 0xf2, 0x62, 0x82, 0xb8, 0xaa, 0x4f, 0x10,
-	0x12, 0x2e, 0xcd, 0xbc, 0x58, 0xbc, 0x9b, 0xfd, 0xcc, 0x60, 0xb6, 0x74, 0x27, 0x29, 0x6d, 0x69,
-	0xd0, 0x47, 0xb6, 0xf0, 0x47, 0xb0, 0xa2, 0x2d, 0x48, 0xf4, 0x1b, 0x51, 0xae, 0x02, 0x7b, 0x25,
-	0xe2, 0xdf, 0x0d, 0x40, 0xfa, 0xfe, 0xb4, 0xc8, 0x2b, 0x73, 0x1a, 0xf2, 0x49, 0x33, 0xf3, 0xa1,
-	0xfb, 0xdf, 0xd2, 0x45, 0x50, 0xdb, 0x73, 0x6c, 0xea, 0x6b, 0xd9, 0xe2, 0xdf, 0x0c, 0x58, 0xd1,
-	0x94, 0xa9, 0x52, 0xfa, 0x04, 0x0a, 0x63, 0x76, 0x44, 0x26, 0xd4, 0x62, 0xcb, 0x09, 0x1b, 0x42,
-	0x23, 0xb2, 0x11, 0xdb, 0xcd, 0x87, 0x00, 0x91, 0x72, 0x46, 0x26, 0x38, 0x9e, 0x49, 0x45, 0xd9,
-	0x9d, 0xce, 0xe3, 0x3a, 0x2b, 0xcc, 0xa7, 0x03, 0x3f, 0xa0, 0x1e, 0x5b, 0x56, 0x17, 0x87, 0x20,
-	0x6f, 0xd9, 0xb6, 0xc7, 0x2d, 0x96, 0x08, 0xff, 0x66, 0x0d, 0x2f, 0xbe, 0x35, 0x6d, 0xc3, 0x63,
-	0x11, 0x77, 0x6c, 0x59, 0x14, 0xa4, 0x84, 0xd7, 0xd4, 0x5c, 0x23, 0xa8, 0xa9, 0x30, 0xfc, 0xc5,
-	0x50, 0xc3, 0x8b, 0xd2, 0xa7, 0xf2, 0x72, 0x0d, 0x8a, 0xe2, 0x7d, 0xcd, 0x21, 0xbd, 0x5c, 0xd5,
-	0x6a, 0x49, 0xee, 0xc2, 0x5a, 0xd2, 0x81, 0xe5, 0x23, 0x6f, 0x32, 0x66, 0x15, 0x3c, 0x4d, 0xfd,
-	0xbb, 0x68, 0x76, 0xba, 0x05, 0xb5, 0xc8, 0x54, 0xaa, 0x2a, 0xfc, 0x18, 0xca, 0x5d, 0x3a, 0xea,
-	0x51, 0xef, 0x1b, 0x3e, 0xfb, 0x2f, 0x41, 0x36, 0x74, 0x99, 0xed, 0xec, 0xb0, 0xbb, 0xd9, 0xb3,
-	0x46, 0xe2, 0x66, 0x4b, 0x84, 0x7f, 0xb3, 0x87, 0xf6, 0xa5, 0xe7, 0xf6, 0x8f, 0xc9, 0x2e, 0x4f,
-	0xac, 0x44, 0x94, 0x88, 0x6d, 0x80, 0x28, 0xbf, 0x0b, 0x3b, 0x5e, 0x13, 0xc0, 0x53, 0x6d, 0x4b,
-	0xf0, 0x31, 0x4f, 0x34, 0x0d, 0x07, 0x80, 0x5a, 0x43, 0x3e, 0xd1, 0xe7, 0x24, 0x00, 0x52, 0xc6,
-	0x0f, 0x01, 0x22, 0xb4, 0x2f, 0x84, 0x8a, 0xf5, 0x6a, 0xe9, 0x51, 0x39, 0x89, 0x14, 0xf8, 0x73,
-	0x58, 0x54, 0x2c, 0x0d, 0x99, 0xa3, 0x6c, 0x48, 0x89, 0xe5, 0xca, 0xf8, 0x48, 0x7d, 0x5f, 0x42,
-	0xa0, 0xc4, 0x1b, 0x1e, 0xe4, 0x19, 0x8c, 0xa8, 0x08, 0xd9, 0xfd, 0xc7, 0xb5, 0x0c, 0x5a, 0x02,
-	0xd8, 0xdb, 0x3f, 0x7a, 0xb2, 0xdb, 0xde, 0xde, 0x69, 0x93, 0x9a, 0x81, 0x96, 0xa1, 0xcc, 0xe4,
-	0x03, 0xd2, 0xe9, 0x6e, 0x93, 0xef, 0x6a, 0x59, 0x54, 0x82, 0x42, 0x9b, 0x90, 0x7d, 0x52, 0xcb,
-	0xb1, 0xb5, 0x36, 0x1b, 0x19, 0x04, 0x58, 0xb5, 0x7c, 0xa8, 0x10, 0x79, 0xd5, 0x0a, 0xa8, 0x1e,
-	0x5d, 0xe4, 0x9e, 0x13, 0x74, 0xad, 0xa0, 0xff, 0xb4, 0x56, 0xbc, 0xd1, 0x82, 0x12, 0xef, 0xf1,
-	0x47, 0x67, 0x2e, 0x65, 0xf6, 0xba, 0x9d, 0x6f, 0xdb, 0x3b, 0xb5, 0x0c, 0x5a, 0x84, 0xfc, 0xc1,
-	0x31, 0x69, 0xd7, 0x8c, 0xad, 0xbf, 0x73, 0x50, 0x15, 0x56, 0x0f, 0xa9, 0xf7, 0x7c, 0xd0, 0xa7,
-	0xe8, 0x36, 0x14, 0xc5, 0xaf, 0x03, 0xb4, 0xc2, 0xae, 0x3e, 0xf6, 0x7b, 0xc4, 0x44, 0xba, 0x4a,
-	0xf0, 0x05, 0x67, 0xd0, 0x3d, 0x80, 0x68, 0x2c, 0x46, 0x6b, 0x6c, 0x4f, 0x62, 0x22, 0x37, 0xd7,
-	0xa7, 0xd5, 0xe1, 0xf1, 0x2f, 0xa0, 0xac, 0xcd, 0x5f, 0x28, 0xdc, 0x18, 0x1f, 0xf9, 0xcc, 0x4b,
-	0x09, 0x7d, 0x68, 0xe1, 0x03, 0xc8, 0xb3, 0x36, 0x8e, 0x96, 0xf9, 0xcb, 0x8a, 0x46, 0x55, 0xb3,
-	0x16, 0x29, 0xc2, 0xcd, 0x0f, 0xa0, 0xa2, 0xcf, 0xc6, 0xe8, 0x92, 0x60, 0x78, 0x62, 0xc0, 0x36,
-	0x1b, 0xc9, 0x85, 0xd0, 0xc8, 0x75, 0x28, 0x3d, 0xa2, 0x96, 0x17, 0xf4, 0xa8, 0x15, 0xa0, 0x32,
-	0xdb, 0x28, 0x27, 0x78, 0x53, 0x17, 0x70, 0xe6, 0x96, 0x81, 0x76, 0x61, 0x79, 0x6a, 0xe2, 0x42,
-	0xa6, 0x48, 0x65, 0xd6, 0xdc, 0x67, 0x5e, 0x9e, 0xb9, 0xa6, 0x83, 0xa5, 0xcd, 0x28, 0x02, 0xac,
-	0xe4, 0x40, 0x24, 0xc0, 0x9a, 0x31, 0xcc, 0xe0, 0xcc, 0xd6, 0x5f, 0x39, 0xa8, 0x0b, 0xde, 0x74,
-	0xad, 0xb1, 0x75, 0x4a, 0x3d, 0x75, 0xf3, 0xf7, 0x62, 0xef, 0x64, 0x6d, 0xba, 0xf3, 0x6b, 0xd7,
-	0x98, 0x1c, 0x08, 0x04, 0x0b, 0xb4, 0xc7, 0xbc, 0x36, 0xdd, 0xfd, 0xb4, 0xe3, 0xc9, 0xa6, 0x88,
-	0x33, 0xe8, 0x2e, 0x94, 0xc2, 0xde, 0x82, 0xea, 0x53, 0xad, 0x46, 0x1c, 0x5e, 0x9b, 0xd9, 0x80,
-	0x70, 0x06, 0x11, 0x35, 0x5d, 0xe9, 0xd0, 0x6c, 0x44, 0x91, 0xce, 0x00, 0xe8, 0xca, 0x9c, 0xd5,
-	0x18, 0x4d, 0xb4, 0x5a, 0x2f, 0x69, 0x92, 0xec, 0x0a, 0x92, 0x26, 0x33, 0xda, 0x82, 0x30, 0xa2,
-	0xb7, 0x25, 0x24, 0x39, 0x9c, 0xe8, 0x69, 0xc2, 0xc8, 0xac, 0x0e, 0x86, 0x33, 0xe8, 0x53, 0x58,
-	0x54, 0x6f, 0x1b, 0xad, 0xb2, 0x7d, 0x53, 0xd5, 0xdf, 0xac, 0xc7, 0x95, 0xea, 0xe0, 0xfd, 0xc6,
-	0xab, 0x37, 0x4d, 0xe3, 0xf5, 0x9b, 0xa6, 0xf1, 0xe7, 0x9b, 0xa6, 0xf1, 0xeb, 0x79, 0x33, 0xf3,
-	0xfa, 0xbc, 0x99, 0xf9, 0xe3, 0xbc, 0x99, 0xe9, 0x15, 0xf9, 0x5f, 0x3d, 0x77, 0xfe, 0x09, 0x00,
-	0x00, 0xff, 0xff, 0xe0, 0xc9, 0xac, 0x95, 0x10, 0x12, 0x00, 0x00,
+	// 1747 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x72, 0xdb, 0xc8,
+	0x11, 0x26, 0xf8, 0x27, 0xb2, 0x49, 0x4a, 0xd4, 0x88, 0x92, 0x60, 0x48, 0xa6, 0x99, 0x89, 0xcb,
+	0x91, 0x9d, 0xc4, 0xb1, 0xe4, 0xfc, 0x95, 0x13, 0x57, 0x45, 0x16, 0xe9, 0x58, 0xb1, 0x28, 0x29,
+	0x23, 0xc9, 0xe5, 0xe4, 0xe2, 0x40, 0xe0, 0x48, 0x62, 0x99, 0x24, 0x60, 0x00, 0x74, 0x59, 0xae,
+	0x4a, 0x0e, 0xa9, 0xe4, 0x9e, 0x43, 0x6e, 0xb9, 0xe6, 0x05, 0xf6, 0x01, 0xf6, 0xee, 0xa3, 0x8f,
+	0x7b, 0xdc, 0xb2, 0x9f, 0x63, 0xab, 0xb6, 0xe6, 0x07, 0xc0, 0x80, 0x20, 0xb5, 0xd8, 0xb5, 0x6f,
+	0xe8, 0xee, 0x99, 0x9e, 0xee, 0x9e, 0xaf, 0x9b, 0xdf, 0x10, 0x4a, 0xce, 0xe9, 0x5d, 0xc7, 0xb5,
+	0x7d, 0x1b, 0x65, 0x9d, 0x53, 0xa3, 0x71, 0x6e, 0x9f, 0xdb, 0x5c, 0xfc, 0x05, 0xfb, 0x12, 0x16,
+	0xfc, 0x77, 0x28, 0x74, 0x46, 0xbe, 0x7b, 0x89, 0xea, 0x90, 0x7b, 0x49, 0x2f, 0x75, 0xad, 0xa5,
+	0x6d, 0x54, 0x09, 0xfb, 0x44, 0x0d, 0x28, 0xbc, 0x36, 0x07, 0x63, 0xaa, 0x67, 0xb9, 0x4e, 0x08,
+	0x08, 0x41, 0x7e, 0x48, 0x7d, 0x53, 0xcf, 0xb5, 0xb4, 0x8d, 0x1a, 0xe1, 0xdf, 0xc8, 0x80, 0xd2,
+	0x89, 0x47, 0xdd, 0x2e, 0xd3, 0xe7, 0xb9, 0x3e, 0x94, 0xd1, 0x3a, 0x94, 0x3b, 0x6f, 0x9c, 0xbe,
+	0x4b, 0xbd, 0x6d, 0x5f, 0x2f, 0xb4, 0xb4, 0x8d, 0x3c, 0x89, 0x14, 0xf8, 0x9f, 0x1a, 0x94, 0xf9,
+	0xf9, 0xbb, 0xa3, 0x33, 0x1b, 0xad, 0x41, 0x6e, 0x60, 0x9f, 0xf3, 0x18, 0x2a, 0x5b, 0xe5, 0xbb,
+	0xce, 0xe9, 0x5d, 0x6e, 0x23, 0x4c, 0xcb, 0x0e, 0xa1, 0x6f, 0x7c, 0x3a, 0xf2, 0x77, 0xdb, 0x3c,
+	0xa2, 0x3c, 0x09, 0x65, 0xb4, 0x02, 0x45, 0xfb, 0xec, 0xcc, 0xa3, 0xbe, 0x0c, 0x4b, 0x4a, 0xe8,
+	0x26, 0xd4, 0xa8, 0xe7, 0xf7, 0x87, 0xa6, 0x4f, 0x7b, 0x47, 0xfd, 0xb7, 0x94, 0x47, 0x97, 0x27,
+	0x71, 0x25, 0x1e, 0x43, 0xe1, 0xd1, 0xc0, 0xb6, 0x5e, 0xb2, 0x23, 0xac, 0x0b, 0x6a, 0xbd, 0x3c,
+	0x1a, 0x0f, 0x79, 0x10, 0x35, 0x12, 0xca, 0xa8, 0x05, 0x95, 0x53, 0xb6, 0x68, 0x8f, 0x8e, 0xce,
+	0xfd, 0x0b, 0x1e, 0x41, 0x8d, 0xa8, 0x2a, 0xb6, 0x7b, 0xec, 0x51, 0xb7, 0x6d, 0xca, 0xea, 0x54,
+	0x49, 0x28, 0xb3, 0xaa, 0xf5, 0x4c, 0x59, 0x9d, 0x2a, 0xe1, 0xdf, 0xf8, 0xff, 0x1a, 0xd4, 0xb6,
+	0x1d, 0x87, 0x8e, 0x7a, 0x84, 0xbe, 0x1a, 0x53, 0xcf, 0x8f, 0xa5, 0xa8, 0x4d, 0xa4, 0xf8, 0x23,
+	0x28, 0xf2, 0xc3, 0x3c, 0x3d, 0xdb, 0xca, 0x05, 0xe5, 0xe1, 0x61, 0x13, 0x69, 0x60, 0x17, 0xe6,
+	0x50, 0xea, 0x7a, 0x7a, 0xae, 0x95, 0xdb, 0x28, 0x13, 0x21, 0xa0, 0x5b, 0x30, 0x4f, 0xdf, 0x38,
+	0xd4, 0xf2, 0x69, 0xef, 0x40, 0xd4, 0x48, 0x5c, 0xd1, 0x84, 0x96, 0xd5, 0xf0, 0xd5, 0xd8, 0x76,
+	0xc7, 0x43, 0x7e, 0x4b, 0x25, 0x22, 0x25, 0xfc, 0x04, 0xe6, 0x83, 0x28, 0x3d, 0xc7, 0x1e, 0x79,
+	0x14, 0xad, 0x43, 0xde, 0xb2, 0x7b, 0x94, 0x87, 0x38, 0xbf, 0x55, 0x62, 0x81, 0xec, 0xd8, 0x3d,
+	0x4a, 0xb8, 0x16, 0xe9, 0x30, 0x27, 0xaa, 0x2f, 0x22, 0xad, 0x91, 0x40, 0xc4, 0x9b, 0xb0, 0xb4,
+	0xe3, 0x52, 0xd3, 0xa7, 0x1d, 0x9e, 0x94, 0x92, 0xb5, 0xe7, 0xbb, 0xd4, 0x1c, 0x46, 0x59, 0x07,
+	0x32, 0x3e, 0x84, 0x46, 0x7c, 0x4b, 0xaa, 0x10, 0xae, 0x80, 0x0a, 0xfe, 0xb7, 0x06, 0x8b, 0x84,
+	0x9a, 0x3d, 0x5e, 0x3a, 0x2f, 0x4d, 0xe5, 0x23, 0x70, 0x65, 0x63, 0xe0, 0x6a, 0x41, 0x65, 0x34,
+	0x1e, 0x1e, 0x9c, 0x09, 0x4f, 0x12, 0x79, 0xaa, 0x8a, 0x67, 0x46, 0xcd, 0x41, 0x88, 0xbc, 0x1a,
+	0x09, 0x65, 0x7c, 0x02, 0x48, 0x0d, 0x23, 0x55, 0x5e, 0xdf, 0x8d, 0x01, 0x7c, 0x1d, 0xe6, 0x0e,
+	0xcd, 0xcb, 0x81, 0x6d, 0xf6, 0x18, 0xe6, 0x38, 0x16, 0x45, 0x4b, 0xf3, 0x6f, 0x7e, 0x05, 0xf6,
+	0x70, 0xd8, 0xf7, 0x05, 0x66, 0x53, 0xa4, 0x8f, 0x7b, 0xd0, 0x88, 0x6f, 0x49, 0x15, 0xea, 0x0a,
+	0x14, 0x07, 0x6a, 0xa7, 0x48, 0x89, 0xe9, 0x59, 0x09, 0x68, 0x8f, 0xd7, 0xab, 0x44, 0xa4, 0x84,
+	0xbb, 0x50, 0x39, 0xa2, 0xe6, 0x20, 0xcd, 0x7d, 0x60, 0xa8, 0x5a, 0x4a, 0x40, 0xf2, 0x80, 0x98,
+	0x0e, 0xff, 0x0c, 0xaa, 0xc2, 0x5d, 0x9a, 0x60, 0xf1, 0x5b, 0x68, 0x10, 0x6a, 0xd9, 0xaf, 0xa9,
+	0x9b, 0x40, 0xe6, 0xcc, 0x28, 0xd4, 0xbb, 0xcd, 0xc6, 0xef, 0x96, 0x27, 0x69, 0x8f, 0x5d, 0x8b,
+	0xf2, 0x24, 0xcb, 0x44, 0x4a, 0x7c, 0x0a, 0xb8, 0xb6, 0xc3, 0xb1, 0x50, 0x22, 0xfc, 0x1b, 0xff,
+	0x0a, 0x96, 0x27, 0xce, 0x4e, 0x15, 0xf2, 0xdf, 0x04, 0x7c, 0xd8, 0x7c, 0xec, 0xd3, 0x4f, 0x82,
+	0xf1, 0x0a, 0x14, 0x5d, 0xea, 0x0c, 0xcc, 0xcb, 0x60, 0x76, 0x0a, 0x09, 0xbf, 0x85, 0xa5, 0xd8,
+	0x09, 0xa9, 0xae, 0xfd, 0x27, 0x30, 0x47, 0xc5, 0x06, 0x09, 0xd1, 0x5a, 0x38, 0xc5, 0xd9, 0x84,
+	0x27, 0x81, 0x95, 0xfd, 0x2c, 0xd0, 0x51, 0x30, 0x90, 0xc4, 0xc1, 0x91, 0x02, 0xdb, 0xb0, 0x42,
+	0xa8, 0x33, 0xe8, 0x5b, 0xa6, 0x4f, 0xbf, 0x57, 0xa3, 0x0a, 0x10, 0x04, 0x19, 0x0a, 0x49, 0x69,
+	0x9b, 0xdc, 0xac, 0xb6, 0xf9, 0x33, 0xac, 0x26, 0x0e, 0xfc, 0xc4, 0x69, 0x77, 0x0f, 0xd0, 0xf6,
+	0x60, 0x60, 0x5b, 0xa9, 0x21, 0x85, 0xef, 0xc3, 0x52, 0x6c, 0x47, 0x2a, 0x20, 0x6c, 0xc2, 0x52,
+	0x9b, 0x0e, 0xe8, 0x94, 0xa1, 0x3a, 0xf3, 0x9c, 0x5f, 0x42, 0x23, 0xbe, 0x25, 0xd5, 0x41, 0x7f,
+	0x05, 0xfd, 0x88, 0x8f, 0xe5, 0xe9, 0x59, 0xcd, 0x1a, 0xe1, 0xac, 0x5d, 0xc5, 0xc9, 0xc7, 0x36,
+	0x6b, 0x49, 0x39, 0x90, 0x63, 0x3a, 0xfc, 0x02, 0xae, 0x4d, 0xf1, 0x2d, 0xc3, 0xba, 0xca, 0xf9,
+	0x2d, 0x28, 0x0a, 0x47, 0xdc, 0x6d, 0x65, 0x6b, 0x9e, 0xc3, 0x4d, 0x24, 0xca, 0xf0, 0x26, 0xad,
+	0x78, 0x13, 0x16, 0xc5, 0x01, 0x5c, 0x2b, 0xa3, 0x5e, 0x87, 0x72, 0xe0, 0xc8, 0xd3, 0xb5, 0x56,
+	0x8e, 0x51, 0x93, 0x50, 0x81, 0xdf, 0x65, 0x01, 0xa9, 0x7b, 0x52, 0xc1, 0xe1, 0x21, 0xcc, 0x09,
+	0x0f, 0x01, 0xfe, 0x7f, 0xcc, 0x16, 0x24, 0xdd, 0x48, 0x95, 0x27, 0xf8, 0x4d, 0xb0, 0x87, 0x6d,
+	0x17, 0x01, 0x07, 0x50, 0x9d, 0xb5, 0x5d, 0xa4, 0x18, 0x6c, 0x97, 0x7b, 0x8c, 0x3f, 0x41, 0x55,
+	0xf5, 0xab, 0x72, 0xba, 0xbc, 0xe0, 0x74, 0x37, 0x55, 0x4e, 0x27, 0xcb, 0xa5, 0xb8, 0x17, 0xc6,
+	0x07, 0xd9, 0xdf, 0x6a, 0xcc, 0x97, 0x7a, 0x48, 0x4a, 0x5f, 0x4a, 0xe9, 0x23, 0x5f, 0xf8, 0xe7,
+	0xb0, 0xa8, 0x18, 0x64, 0xf5, 0xf5, 0x28, 0x57, 0x51, 0xfb, 0x40, 0xc4, 0x5f, 0x6a, 0x80, 0xd4,
+	0xf5, 0x69, 0x2b, 0x1f, 0xb8, 0x53, 0x2a, 0x9f, 0x74, 0x33, 0xbb, 0x74, 0x9f, 0x2d, 0x5d, 0x04,
+	0xf5, 0x7d, 0xbb, 0x47, 0x3d, 0x25, 0x5b, 0xfc, 0x85, 0x06, 0x8b, 0x8a, 0x32, 0x55, 0x4a, 0xbf,
+	0x86, 0xc2, 0x88, 0x6d, 0x91, 0x09, 0xb5, 0x98, 0x39, 0xe1, 0x43, 0x68, 0x44, 0x36, 0x62, 0xb9,
+	0xf1, 0x18, 0x20, 0x52, 0x4e, 0xc9, 0x04, 0xc7, 0x33, 0xa9, 0x06, 0x7e, 0x27, 0xf3, 0xb8, 0xcd,
+	0x7e, 0x01, 0xce, 0xfb, 0x9e, 0x4f, 0x5d, 0x66, 0x0e, 0x2e, 0x0e, 0x41, 0xde, 0xec, 0xf5, 0x5c,
+	0xee, 0xb1, 0x4c, 0xf8, 0x37, 0xde, 0x83, 0x46, 0x7c, 0x69, 0x5a, 0x92, 0xc0, 0x22, 0xde, 0xed,
+	0xc9, 0xa1, 0x20, 0x25, 0xdc, 0x0d, 0x88, 0xa2, 0x80, 0x66, 0x70, 0x70, 0x13, 0xc0, 0x0d, 0x86,
+	0xb4, 0x27, 0x09, 0xba, 0xa2, 0x51, 0x18, 0x6c, 0x36, 0xc6, 0x60, 0xff, 0xa5, 0x41, 0x23, 0xee,
+	0x2f, 0x55, 0x74, 0xb7, 0xa0, 0x28, 0xfa, 0x72, 0x46, 0xb3, 0x48, 0xab, 0x32, 0x83, 0x72, 0x57,
+	0xce, 0xa0, 0x5d, 0x58, 0x38, 0x76, 0xc7, 0x23, 0x16, 0x6b, 0x9a, 0xb9, 0x79, 0x15, 0x89, 0xbd,
+	0x07, 0xf5, 0xc8, 0x55, 0xaa, 0xe9, 0xfd, 0x0c, 0x0c, 0x39, 0x61, 0x7d, 0xdf, 0xb4, 0x2e, 0x24,
+	0xd6, 0xd3, 0xc4, 0xc1, 0x7e, 0xa9, 0xe5, 0xb9, 0x02, 0x89, 0x79, 0x12, 0x29, 0xb0, 0x05, 0x6b,
+	0x53, 0xfd, 0x7e, 0xce, 0x0a, 0xe3, 0x0b, 0x30, 0x08, 0x75, 0x6c, 0xd7, 0xdf, 0xb1, 0x5d, 0x77,
+	0xec, 0xf8, 0xe9, 0x59, 0x5a, 0x80, 0xb0, 0x76, 0x0c, 0x61, 0x6d, 0x41, 0x7a, 0x4c, 0xcf, 0x1e,
+	0x05, 0x0c, 0x4d, 0x48, 0xf8, 0x77, 0xb0, 0x36, 0xf5, 0xa4, 0x54, 0x35, 0x7e, 0x0a, 0x95, 0x2e,
+	0x1d, 0x9e, 0x52, 0xf7, 0x19, 0x7f, 0x29, 0xcf, 0x43, 0x36, 0x8c, 0x28, 0xbb, 0xdb, 0x66, 0x7d,
+	0xb3, 0x6f, 0x0e, 0x45, 0xd7, 0x95, 0x09, 0xff, 0x66, 0x43, 0xf0, 0x8f, 0xae, 0x63, 0x9d, 0x90,
+	0x3d, 0x19, 0x48, 0x20, 0xe2, 0xff, 0x6a, 0x00, 0x11, 0x88, 0xae, 0x4c, 0x32, 0xde, 0x17, 0xe2,
+	0x8a, 0x14, 0x4d, 0x8c, 0xaa, 0xe6, 0xe4, 0xed, 0x4a, 0x99, 0x05, 0xe5, 0xd2, 0x33, 0x4f, 0x3e,
+	0x8c, 0xf9, 0x37, 0x5b, 0x6f, 0x89, 0xf4, 0x3d, 0xbd, 0xc0, 0xbd, 0x85, 0x32, 0xfe, 0x07, 0x40,
+	0x74, 0x41, 0x3f, 0x1c, 0x37, 0x13, 0x31, 0xe7, 0xae, 0xe8, 0xe5, 0x7c, 0xac, 0x97, 0x7f, 0x0f,
+	0xa5, 0x60, 0x54, 0x29, 0x97, 0xab, 0xc5, 0x2e, 0x57, 0x87, 0x39, 0x36, 0x94, 0xa8, 0xe7, 0xc9,
+	0x5a, 0x07, 0xe2, 0x1d, 0x17, 0xf2, 0xec, 0xbe, 0x50, 0x11, 0xb2, 0x07, 0x4f, 0xeb, 0x19, 0x34,
+	0x0f, 0xb0, 0x7f, 0x70, 0xfc, 0x62, 0xaf, 0xb3, 0xdd, 0xee, 0x90, 0xba, 0x86, 0x16, 0xa0, 0xc2,
+	0xe4, 0x43, 0xb2, 0xdb, 0xdd, 0x26, 0x7f, 0xa9, 0x67, 0x51, 0x19, 0x0a, 0x1d, 0x42, 0x0e, 0x48,
+	0x3d, 0xc7, 0x6c, 0x1d, 0x46, 0x50, 0xc5, 0xa5, 0xd4, 0xf3, 0xa1, 0x42, 0xd4, 0xa3, 0x5e, 0x40,
+	0x8d, 0xa8, 0x2b, 0xf7, 0x6d, 0xbf, 0x6b, 0xfa, 0xd6, 0x45, 0xbd, 0x78, 0xa7, 0x05, 0x65, 0xce,
+	0x28, 0x8f, 0x2f, 0x1d, 0xca, 0xfc, 0x75, 0x77, 0x9f, 0x77, 0xda, 0xf5, 0x0c, 0x2a, 0x41, 0xfe,
+	0xf0, 0x84, 0x74, 0xea, 0xda, 0xd6, 0x37, 0x79, 0xa8, 0x09, 0xaf, 0x47, 0xd4, 0x7d, 0xdd, 0xb7,
+	0x28, 0xda, 0x84, 0xa2, 0x78, 0x73, 0xa3, 0x45, 0x86, 0xb1, 0xd8, 0xbf, 0x04, 0x06, 0x52, 0x55,
+	0x02, 0x98, 0x38, 0x83, 0x1e, 0x02, 0x44, 0xef, 0x49, 0xb4, 0xcc, 0xd6, 0x24, 0x9e, 0xb9, 0xc6,
+	0xca, 0xa4, 0x3a, 0xdc, 0xfe, 0x07, 0xa8, 0x28, 0x6c, 0x1f, 0x85, 0x0b, 0xe3, 0x0f, 0x0c, 0x63,
+	0x35, 0xa1, 0x0f, 0x3d, 0xfc, 0x14, 0xf2, 0x8c, 0xcb, 0xa1, 0x05, 0xde, 0xc4, 0xd1, 0x5b, 0xce,
+	0xa8, 0x47, 0x8a, 0x70, 0xf1, 0x0e, 0x54, 0xd5, 0x47, 0x25, 0x5a, 0x15, 0xad, 0x94, 0x78, 0x99,
+	0x1a, 0x7a, 0xd2, 0x10, 0x3a, 0xb9, 0x0d, 0xe5, 0x27, 0xd4, 0x74, 0xfd, 0x53, 0x6a, 0xfa, 0xa8,
+	0xc2, 0x16, 0xca, 0xa7, 0xaf, 0xa1, 0x0a, 0x38, 0x73, 0x4f, 0x43, 0x7b, 0xb0, 0x30, 0xc1, 0xef,
+	0x91, 0x21, 0x52, 0x99, 0xf6, 0xca, 0x30, 0xd6, 0xa6, 0xda, 0xd4, 0x62, 0x29, 0x44, 0x55, 0x14,
+	0x2b, 0xc9, 0x8a, 0x8d, 0xd5, 0x84, 0x5e, 0xcd, 0x5f, 0xa5, 0xe0, 0x22, 0xff, 0x29, 0x3c, 0xde,
+	0xd0, 0x93, 0x86, 0xd0, 0xc9, 0x63, 0xa8, 0xc5, 0x9e, 0x8e, 0x48, 0x17, 0x61, 0x27, 0x5f, 0xb2,
+	0xc6, 0xb5, 0x29, 0x96, 0xc0, 0xcf, 0xd6, 0xff, 0x0a, 0xd0, 0x10, 0x20, 0xee, 0x9a, 0x23, 0xf3,
+	0x9c, 0xba, 0x01, 0x0c, 0x1f, 0xc6, 0x9a, 0x7d, 0x79, 0x92, 0x8b, 0x2a, 0x98, 0x4a, 0x52, 0x54,
+	0x01, 0x49, 0x65, 0x82, 0x2d, 0x4f, 0xf2, 0x31, 0x65, 0x7b, 0x92, 0xa6, 0xe1, 0x0c, 0x7a, 0x00,
+	0xe5, 0x90, 0xed, 0xa0, 0xc6, 0x04, 0xf9, 0x11, 0x9b, 0x97, 0xa7, 0x52, 0x22, 0x9c, 0x41, 0x24,
+	0xe0, 0xfb, 0xea, 0x3d, 0xad, 0x47, 0x91, 0x4e, 0xb9, 0xad, 0xeb, 0x33, 0xac, 0x31, 0xcc, 0x2a,
+	0x2c, 0x42, 0x62, 0x36, 0xc9, 0x53, 0x0c, 0x3d, 0x69, 0x50, 0x9d, 0xa8, 0x44, 0x09, 0xc9, 0x86,
+	0x4a, 0xb0, 0x2c, 0x43, 0x4f, 0x1a, 0x42, 0x27, 0xbf, 0x81, 0x52, 0x30, 0x68, 0xd0, 0x12, 0x5b,
+	0x37, 0xc1, 0x2b, 0x8c, 0x46, 0x5c, 0x19, 0x6e, 0x7c, 0x0e, 0x4b, 0x53, 0x7e, 0xad, 0x51, 0x53,
+	0x49, 0x7d, 0x0a, 0x3d, 0x30, 0x6e, 0xcc, 0xb4, 0xab, 0x9e, 0xa7, 0xfc, 0x70, 0x0a, 0xcf, 0xb3,
+	0x7f, 0xbb, 0x8d, 0x1b, 0x33, 0xed, 0x81, 0xe7, 0x47, 0xfa, 0xbb, 0x0f, 0x4d, 0xed, 0xfd, 0x87,
+	0xa6, 0xf6, 0xf5, 0x87, 0xa6, 0xf6, 0x9f, 0x8f, 0xcd, 0xcc, 0xfb, 0x8f, 0xcd, 0xcc, 0x57, 0x1f,
+	0x9b, 0x99, 0xd3, 0x22, 0xff, 0x0b, 0xfb, 0xfe, 0xb7, 0x03, 0x00, 0x12, 0xac, 0x10, 0xd7, 0xe8,
+	0x16, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2252,6 +2771,8 @@ type ExtentServiceClient interface {
 	Heartbeat(ctx context.Context, in *Payload, opts ...grpc.CallOption) (ExtentService_HeartbeatClient, error)
 	ReplicateBlocks(ctx context.Context, in *ReplicateBlocksRequest, opts ...grpc.CallOption) (*ReplicateBlocksResponse, error)
 	AllocExtent(ctx context.Context, in *AllocExtentRequest, opts ...grpc.CallOption) (*AllocExtentResponse, error)
+	DeleteExtent(ctx context.Context, in *DeleteExtentRequest, opts ...grpc.CallOption) (*DeleteExtentResponse, error)
+	RecoverExtent(ctx context.Context, in *RecoverExtentRequest, opts ...grpc.CallOption) (*RecoverExtentResponse, error)
 }
 
 type extentServiceClient struct {
@@ -2357,6 +2878,24 @@ func (c *extentServiceClient) AllocExtent(ctx context.Context, in *AllocExtentRe
 	return out, nil
 }
 
+func (c *extentServiceClient) DeleteExtent(ctx context.Context, in *DeleteExtentRequest, opts ...grpc.CallOption) (*DeleteExtentResponse, error) {
+	out := new(DeleteExtentResponse)
+	err := c.cc.Invoke(ctx, "/pb.ExtentService/DeleteExtent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extentServiceClient) RecoverExtent(ctx context.Context, in *RecoverExtentRequest, opts ...grpc.CallOption) (*RecoverExtentResponse, error) {
+	out := new(RecoverExtentResponse)
+	err := c.cc.Invoke(ctx, "/pb.ExtentService/RecoverExtent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExtentServiceServer is the server API for ExtentService service.
 type ExtentServiceServer interface {
 	Append(context.Context, *AppendRequest) (*AppendResponse, error)
@@ -2367,6 +2906,8 @@ type ExtentServiceServer interface {
 	Heartbeat(*Payload, ExtentService_HeartbeatServer) error
 	ReplicateBlocks(context.Context, *ReplicateBlocksRequest) (*ReplicateBlocksResponse, error)
 	AllocExtent(context.Context, *AllocExtentRequest) (*AllocExtentResponse, error)
+	DeleteExtent(context.Context, *DeleteExtentRequest) (*DeleteExtentResponse, error)
+	RecoverExtent(context.Context, *RecoverExtentRequest) (*RecoverExtentResponse, error)
 }
 
 // UnimplementedExtentServiceServer can be embedded to have forward compatible implementations.
@@ -2397,6 +2938,12 @@ func (*UnimplementedExtentServiceServer) ReplicateBlocks(ctx context.Context, re
 func (*UnimplementedExtentServiceServer) AllocExtent(ctx context.Context, req *AllocExtentRequest) (*AllocExtentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AllocExtent not implemented")
 }
+func (*UnimplementedExtentServiceServer) DeleteExtent(ctx context.Context, req *DeleteExtentRequest) (*DeleteExtentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteExtent not implemented")
+}
+func (*UnimplementedExtentServiceServer) RecoverExtent(ctx context.Context, req *RecoverExtentRequest) (*RecoverExtentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverExtent not implemented")
+}
 
 func RegisterExtentServiceServer(s *grpc.Server, srv ExtentServiceServer) {
 	s.RegisterService(&_ExtentService_serviceDesc, srv)
@@ -2549,6 +3096,42 @@ func _ExtentService_AllocExtent_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExtentService_DeleteExtent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteExtentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtentServiceServer).DeleteExtent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ExtentService/DeleteExtent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtentServiceServer).DeleteExtent(ctx, req.(*DeleteExtentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtentService_RecoverExtent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverExtentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtentServiceServer).RecoverExtent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ExtentService/RecoverExtent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtentServiceServer).RecoverExtent(ctx, req.(*RecoverExtentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ExtentService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.ExtentService",
 	HandlerType: (*ExtentServiceServer)(nil),
@@ -2581,6 +3164,14 @@ var _ExtentService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AllocExtent",
 			Handler:    _ExtentService_AllocExtent_Handler,
 		},
+		{
+			MethodName: "DeleteExtent",
+			Handler:    _ExtentService_DeleteExtent_Handler,
+		},
+		{
+			MethodName: "RecoverExtent",
+			Handler:    _ExtentService_RecoverExtent_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2603,6 +3194,8 @@ type StreamManagerServiceClient interface {
 	CreateStream(ctx context.Context, in *CreateStreamRequest, opts ...grpc.CallOption) (*CreateStreamResponse, error)
 	RegisterNode(ctx context.Context, in *RegisterNodeRequest, opts ...grpc.CallOption) (*RegisterNodeResponse, error)
 	Truncate(ctx context.Context, in *TruncateRequest, opts ...grpc.CallOption) (*TruncateResponse, error)
+	StreamAttachExtents(ctx context.Context, in *StreamAttachExtentsRequest, opts ...grpc.CallOption) (*StreamAttachExtentsResponse, error)
+	ReportCorruptExtent(ctx context.Context, in *ReportCorruptExtentRequest, opts ...grpc.CallOption) (*ReportCorruptExtentResponse, error)
 }
 
 type streamManagerServiceClient struct {
@@ -2676,17 +3269,37 @@ func (c *streamManagerServiceClient) Truncate(ctx context.Context, in *TruncateR
 	return out, nil
 }
 
-// StreamManagerServiceServer is the server API for StreamManagerService service.
-type StreamManagerServiceServer interface {
-	StreamInfo(context.Context, *StreamInfoRequest) (*StreamInfoResponse, error)
-	ExtentInfo(context.Context, *ExtentInfoRequest) (*ExtentInfoResponse, error)
-	NodesInfo(context.Context, *NodesInfoRequest) (*NodesInfoResponse, error)
-	StreamAllocExtent(context.Context, *StreamAllocExtentRequest) (*StreamAllocExtentResponse, error)
-	CreateStream(context.Context, *CreateStreamRequest) (*CreateStreamResponse, error)
-	RegisterNode(context.Context, *RegisterNodeRequest) (*RegisterNodeResponse, error)
-	Truncate(context.Context, *TruncateRequest) (*TruncateResponse, error)
-}
-
+func (c *streamManagerServiceClient) StreamAttachExtents(ctx context.Context, in *StreamAttachExtentsRequest, opts ...grpc.CallOption) (*StreamAttachExtentsResponse, error) {
+	out := new(StreamAttachExtentsResponse)
+	err := c.cc.Invoke(ctx, "/pb.StreamManagerService/StreamAttachExtents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamManagerServiceClient) ReportCorruptExtent(ctx context.Context, in *ReportCorruptExtentRequest, opts ...grpc.CallOption) (*ReportCorruptExtentResponse, error) {
+	out := new(ReportCorruptExtentResponse)
+	err := c.cc.Invoke(ctx, "/pb.StreamManagerService/ReportCorruptExtent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreamManagerServiceServer is the server API for StreamManagerService service.
+type StreamManagerServiceServer interface {
+	StreamInfo(context.Context, *StreamInfoRequest) (*StreamInfoResponse, error)
+	ExtentInfo(context.Context, *ExtentInfoRequest) (*ExtentInfoResponse, error)
+	NodesInfo(context.Context, *NodesInfoRequest) (*NodesInfoResponse, error)
+	StreamAllocExtent(context.Context, *StreamAllocExtentRequest) (*StreamAllocExtentResponse, error)
+	CreateStream(context.Context, *CreateStreamRequest) (*CreateStreamResponse, error)
+	RegisterNode(context.Context, *RegisterNodeRequest) (*RegisterNodeResponse, error)
+	Truncate(context.Context, *TruncateRequest) (*TruncateResponse, error)
+	StreamAttachExtents(context.Context, *StreamAttachExtentsRequest) (*StreamAttachExtentsResponse, error)
+	ReportCorruptExtent(context.Context, *ReportCorruptExtentRequest) (*ReportCorruptExtentResponse, error)
+}
+
 // UnimplementedStreamManagerServiceServer can be embedded to have forward compatible implementations.
 type UnimplementedStreamManagerServiceServer struct {
 }
@@ -2712,6 +3325,12 @@ func (*UnimplementedStreamManagerServiceServer) RegisterNode(ctx context.Context
 func (*UnimplementedStreamManagerServiceServer) Truncate(ctx context.Context, req *TruncateRequest) (*TruncateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Truncate not implemented")
 }
+func (*UnimplementedStreamManagerServiceServer) StreamAttachExtents(ctx context.Context, req *StreamAttachExtentsRequest) (*StreamAttachExtentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StreamAttachExtents not implemented")
+}
+func (*UnimplementedStreamManagerServiceServer) ReportCorruptExtent(ctx context.Context, req *ReportCorruptExtentRequest) (*ReportCorruptExtentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportCorruptExtent not implemented")
+}
 
 func RegisterStreamManagerServiceServer(s *grpc.Server, srv StreamManagerServiceServer) {
 	s.RegisterService(&_StreamManagerService_serviceDesc, srv)
@@ -2843,6 +3462,42 @@ func _StreamManagerService_Truncate_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StreamManagerService_StreamAttachExtents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StreamAttachExtentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamManagerServiceServer).StreamAttachExtents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.StreamManagerService/StreamAttachExtents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamManagerServiceServer).StreamAttachExtents(ctx, req.(*StreamAttachExtentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamManagerService_ReportCorruptExtent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportCorruptExtentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamManagerServiceServer).ReportCorruptExtent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.StreamManagerService/ReportCorruptExtent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamManagerServiceServer).ReportCorruptExtent(ctx, req.(*ReportCorruptExtentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _StreamManagerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.StreamManagerService",
 	HandlerType: (*StreamManagerServiceServer)(nil),
@@ -2875,6 +3530,14 @@ var _StreamManagerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Truncate",
 			Handler:    _StreamManagerService_Truncate_Handler,
 		},
+		{
+			MethodName: "StreamAttachExtents",
+			Handler:    _StreamManagerService_StreamAttachExtents_Handler,
+		},
+		{
+			MethodName: "ReportCorruptExtent",
+			Handler:    _StreamManagerService_ReportCorruptExtent_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb.proto",
@@ -3049,6 +3712,21 @@ func (m *AppendRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Quorum {
+		i--
+		if m.Quorum {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ExpectedOffset != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ExpectedOffset))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Peers) > 0 {
 		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Peers[iNdEx])
@@ -3207,6 +3885,11 @@ func (m *ReadBlocksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.SealSize != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.SealSize))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.NumOfBlocks != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.NumOfBlocks))
 		i--
@@ -3345,6 +4028,16 @@ func (m *CommitLengthResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Sealed {
+		i--
+		if m.Sealed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Length != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.Length))
 		i--
@@ -3419,6 +4112,84 @@ func (m *SealResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RecoverExtentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecoverExtentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecoverExtentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Drop {
+		i--
+		if m.Drop {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SealSize != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.SealSize))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ExtentID != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ExtentID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RecoverExtentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RecoverExtentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RecoverExtentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ReadEntriesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3653,6 +4424,62 @@ func (m *AllocExtentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *DeleteExtentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteExtentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteExtentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExtentID != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ExtentID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteExtentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteExtentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteExtentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *StreamAllocExtentRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -4094,6 +4921,21 @@ func (m *CreateStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Quorum {
+		i--
+		if m.Quorum {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Replicates != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Replicates))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -4210,49 +5052,7 @@ func (m *TruncateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *MemberValue) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *MemberValue) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *MemberValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.GrpcURL) > 0 {
-		i -= len(m.GrpcURL)
-		copy(dAtA[i:], m.GrpcURL)
-		i = encodeVarintPb(dAtA, i, uint64(len(m.GrpcURL)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintPb(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.ID != 0 {
-		i = encodeVarintPb(dAtA, i, uint64(m.ID))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ExtentInfo) Marshal() (dAtA []byte, err error) {
+func (m *StreamAttachExtentsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4262,25 +5062,20 @@ func (m *ExtentInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ExtentInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *StreamAttachExtentsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ExtentInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *StreamAttachExtentsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.SealSize != 0 {
-		i = encodeVarintPb(dAtA, i, uint64(m.SealSize))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Replicates) > 0 {
-		dAtA18 := make([]byte, len(m.Replicates)*10)
+	if len(m.ExtentIDs) > 0 {
+		dAtA18 := make([]byte, len(m.ExtentIDs)*10)
 		var j17 int
-		for _, num := range m.Replicates {
+		for _, num := range m.ExtentIDs {
 			for num >= 1<<7 {
 				dAtA18[j17] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
@@ -4295,15 +5090,15 @@ func (m *ExtentInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x12
 	}
-	if m.ExtentID != 0 {
-		i = encodeVarintPb(dAtA, i, uint64(m.ExtentID))
+	if m.StreamID != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.StreamID))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *StreamInfo) Marshal() (dAtA []byte, err error) {
+func (m *StreamAttachExtentsResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4313,31 +5108,270 @@ func (m *StreamInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *StreamInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *StreamAttachExtentsResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *StreamInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *StreamAttachExtentsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.ExtentIDs) > 0 {
-		dAtA20 := make([]byte, len(m.ExtentIDs)*10)
-		var j19 int
+	if m.Stream != nil {
+		{
+			size, err := m.Stream.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Code != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReportCorruptExtentRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportCorruptExtentRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReportCorruptExtentRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Reason) > 0 {
+		i -= len(m.Reason)
+		copy(dAtA[i:], m.Reason)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Reason)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.NodeID != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.NodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ExtentID != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ExtentID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReportCorruptExtentResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportCorruptExtentResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReportCorruptExtentResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberValue) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberValue) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.GrpcURL) > 0 {
+		i -= len(m.GrpcURL)
+		copy(dAtA[i:], m.GrpcURL)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.GrpcURL)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ID != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExtentInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExtentInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExtentInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Corrupts) > 0 {
+		dAtA21 := make([]byte, len(m.Corrupts)*10)
+		var j20 int
+		for _, num := range m.Corrupts {
+			for num >= 1<<7 {
+				dAtA21[j20] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j20++
+			}
+			dAtA21[j20] = uint8(num)
+			j20++
+		}
+		i -= j20
+		copy(dAtA[i:], dAtA21[:j20])
+		i = encodeVarintPb(dAtA, i, uint64(j20))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Refs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Refs))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SealSize != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.SealSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Replicates) > 0 {
+		dAtA23 := make([]byte, len(m.Replicates)*10)
+		var j22 int
+		for _, num := range m.Replicates {
+			for num >= 1<<7 {
+				dAtA23[j22] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j22++
+			}
+			dAtA23[j22] = uint8(num)
+			j22++
+		}
+		i -= j22
+		copy(dAtA[i:], dAtA23[:j22])
+		i = encodeVarintPb(dAtA, i, uint64(j22))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ExtentID != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ExtentID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Quorum {
+		i--
+		if m.Quorum {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Replicates != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Replicates))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ExtentIDs) > 0 {
+		dAtA25 := make([]byte, len(m.ExtentIDs)*10)
+		var j24 int
 		for _, num := range m.ExtentIDs {
 			for num >= 1<<7 {
-				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA25[j24] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j19++
+				j24++
 			}
-			dAtA20[j19] = uint8(num)
-			j19++
+			dAtA25[j24] = uint8(num)
+			j24++
 		}
-		i -= j19
-		copy(dAtA[i:], dAtA20[:j19])
-		i = encodeVarintPb(dAtA, i, uint64(j19))
+		i -= j24
+		copy(dAtA[i:], dAtA25[:j24])
+		i = encodeVarintPb(dAtA, i, uint64(j24))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -4487,6 +5521,12 @@ func (m *AppendRequest) Size() (n int) {
 			n += 1 + l + sovPb(uint64(l))
 		}
 	}
+	if m.ExpectedOffset != 0 {
+		n += 1 + sovPb(uint64(m.ExpectedOffset))
+	}
+	if m.Quorum {
+		n += 2
+	}
 	return n
 }
 
@@ -4551,6 +5591,9 @@ func (m *ReadBlocksRequest) Size() (n int) {
 	if m.NumOfBlocks != 0 {
 		n += 1 + sovPb(uint64(m.NumOfBlocks))
 	}
+	if m.SealSize != 0 {
+		n += 1 + sovPb(uint64(m.SealSize))
+	}
 	return n
 }
 
@@ -4609,6 +5652,9 @@ func (m *CommitLengthResponse) Size() (n int) {
 	if m.Length != 0 {
 		n += 1 + sovPb(uint64(m.Length))
 	}
+	if m.Sealed {
+		n += 2
+	}
 	return n
 }
 
@@ -4639,6 +5685,40 @@ func (m *SealResponse) Size() (n int) {
 	return n
 }
 
+func (m *RecoverExtentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExtentID != 0 {
+		n += 1 + sovPb(uint64(m.ExtentID))
+	}
+	if m.SealSize != 0 {
+		n += 1 + sovPb(uint64(m.SealSize))
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Drop {
+		n += 2
+	}
+	return n
+}
+
+func (m *RecoverExtentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPb(uint64(m.Code))
+	}
+	return n
+}
+
 func (m *ReadEntriesRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4742,45 +5822,69 @@ func (m *AllocExtentResponse) Size() (n int) {
 	return n
 }
 
-func (m *StreamAllocExtentRequest) Size() (n int) {
+func (m *DeleteExtentRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.StreamID != 0 {
-		n += 1 + sovPb(uint64(m.StreamID))
-	}
-	if m.ExtentToSeal != 0 {
-		n += 1 + sovPb(uint64(m.ExtentToSeal))
+	if m.ExtentID != 0 {
+		n += 1 + sovPb(uint64(m.ExtentID))
 	}
 	return n
 }
 
-func (m *StreamAllocExtentResponse) Size() (n int) {
+func (m *DeleteExtentResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.StreamID != 0 {
-		n += 1 + sovPb(uint64(m.StreamID))
-	}
-	if m.Extent != nil {
-		l = m.Extent.Size()
-		n += 1 + l + sovPb(uint64(l))
+	if m.Code != 0 {
+		n += 1 + sovPb(uint64(m.Code))
 	}
 	return n
 }
 
-func (m *StreamInfoRequest) Size() (n int) {
+func (m *StreamAllocExtentRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.StreamIDs) > 0 {
-		l = 0
+	if m.StreamID != 0 {
+		n += 1 + sovPb(uint64(m.StreamID))
+	}
+	if m.ExtentToSeal != 0 {
+		n += 1 + sovPb(uint64(m.ExtentToSeal))
+	}
+	return n
+}
+
+func (m *StreamAllocExtentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StreamID != 0 {
+		n += 1 + sovPb(uint64(m.StreamID))
+	}
+	if m.Extent != nil {
+		l = m.Extent.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
+	return n
+}
+
+func (m *StreamInfoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.StreamIDs) > 0 {
+		l = 0
 		for _, e := range m.StreamIDs {
 			l += sovPb(uint64(e))
 		}
@@ -4936,6 +6040,12 @@ func (m *CreateStreamRequest) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if m.Replicates != 0 {
+		n += 1 + sovPb(uint64(m.Replicates))
+	}
+	if m.Quorum {
+		n += 2
+	}
 	return n
 }
 
@@ -4986,6 +6096,72 @@ func (m *TruncateResponse) Size() (n int) {
 	return n
 }
 
+func (m *StreamAttachExtentsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StreamID != 0 {
+		n += 1 + sovPb(uint64(m.StreamID))
+	}
+	if len(m.ExtentIDs) > 0 {
+		l = 0
+		for _, e := range m.ExtentIDs {
+			l += sovPb(uint64(e))
+		}
+		n += 1 + sovPb(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *StreamAttachExtentsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPb(uint64(m.Code))
+	}
+	if m.Stream != nil {
+		l = m.Stream.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
+	return n
+}
+
+func (m *ReportCorruptExtentRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExtentID != 0 {
+		n += 1 + sovPb(uint64(m.ExtentID))
+	}
+	if m.NodeID != 0 {
+		n += 1 + sovPb(uint64(m.NodeID))
+	}
+	l = len(m.Reason)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	return n
+}
+
+func (m *ReportCorruptExtentResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPb(uint64(m.Code))
+	}
+	return n
+}
+
 func (m *MemberValue) Size() (n int) {
 	if m == nil {
 		return 0
@@ -5025,6 +6201,16 @@ func (m *ExtentInfo) Size() (n int) {
 	if m.SealSize != 0 {
 		n += 1 + sovPb(uint64(m.SealSize))
 	}
+	if m.Refs != 0 {
+		n += 1 + sovPb(uint64(m.Refs))
+	}
+	if len(m.Corrupts) > 0 {
+		l = 0
+		for _, e := range m.Corrupts {
+			l += sovPb(uint64(e))
+		}
+		n += 1 + sovPb(uint64(l)) + l
+	}
 	return n
 }
 
@@ -5044,6 +6230,12 @@ func (m *StreamInfo) Size() (n int) {
 		}
 		n += 1 + sovPb(uint64(l)) + l
 	}
+	if m.Replicates != 0 {
+		n += 1 + sovPb(uint64(m.Replicates))
+	}
+	if m.Quorum {
+		n += 2
+	}
 	return n
 }
 
@@ -5666,6 +6858,45 @@ func (m *AppendRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Peers = append(m.Peers, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedOffset", wireType)
+			}
+			m.ExpectedOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedOffset |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quorum", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Quorum = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -6087,6 +7318,25 @@ func (m *ReadBlocksRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SealSize", wireType)
+			}
+			m.SealSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SealSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -6443,6 +7693,26 @@ func (m *CommitLengthResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sealed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Sealed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -6630,7 +7900,7 @@ func (m *SealResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReadEntriesRequest) Unmarshal(dAtA []byte) error {
+func (m *RecoverExtentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6653,10 +7923,10 @@ func (m *ReadEntriesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReadEntriesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RecoverExtentRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReadEntriesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RecoverExtentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6680,9 +7950,9 @@ func (m *ReadEntriesRequest) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SealSize", wireType)
 			}
-			m.Offset = 0
+			m.SealSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -6692,16 +7962,48 @@ func (m *ReadEntriesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Offset |= uint32(b&0x7F) << shift
+				m.SealSize |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replay", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Drop", wireType)
 			}
-			m.Replay = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -6711,11 +8013,12 @@ func (m *ReadEntriesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Replay |= uint32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Drop = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -6740,7 +8043,7 @@ func (m *ReadEntriesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ReadEntriesResponse) Unmarshal(dAtA []byte) error {
+func (m *RecoverExtentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6763,10 +8066,10 @@ func (m *ReadEntriesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ReadEntriesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: RecoverExtentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ReadEntriesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RecoverExtentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6788,47 +8091,229 @@ func (m *ReadEntriesResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthPb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Entries = append(m.Entries, &EntryInfo{})
-			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndOffset", wireType)
-			}
-			m.EndOffset = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadEntriesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadEntriesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadEntriesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
+			}
+			m.ExtentID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExtentID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replay", wireType)
+			}
+			m.Replay = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Replay |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReadEntriesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReadEntriesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReadEntriesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= Code(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &EntryInfo{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndOffset", wireType)
+			}
+			m.EndOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
 					return ErrIntOverflowPb
 				}
 				if iNdEx >= l {
@@ -7282,7 +8767,7 @@ func (m *AllocExtentResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StreamAllocExtentRequest) Unmarshal(dAtA []byte) error {
+func (m *DeleteExtentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7305,36 +8790,17 @@ func (m *StreamAllocExtentRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamAllocExtentRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteExtentRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StreamAllocExtentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteExtentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
-			}
-			m.StreamID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StreamID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExtentToSeal", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
 			}
-			m.ExtentToSeal = 0
+			m.ExtentID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -7344,7 +8810,7 @@ func (m *StreamAllocExtentRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExtentToSeal |= uint64(b&0x7F) << shift
+				m.ExtentID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -7373,7 +8839,7 @@ func (m *StreamAllocExtentRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StreamAllocExtentResponse) Unmarshal(dAtA []byte) error {
+func (m *DeleteExtentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7396,17 +8862,17 @@ func (m *StreamAllocExtentResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamAllocExtentResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DeleteExtentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StreamAllocExtentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DeleteExtentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.StreamID = 0
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -7416,18 +8882,181 @@ func (m *StreamAllocExtentResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StreamID |= uint64(b&0x7F) << shift
+				m.Code |= Code(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Extent", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamAllocExtentRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamAllocExtentRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamAllocExtentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+			}
+			m.StreamID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StreamID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtentToSeal", wireType)
+			}
+			m.ExtentToSeal = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExtentToSeal |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamAllocExtentResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamAllocExtentResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamAllocExtentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+			}
+			m.StreamID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StreamID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
 					return ErrIntOverflowPb
 				}
 				if iNdEx >= l {
@@ -8663,16 +10292,510 @@ func (m *CreateStreamRequest) Unmarshal(dAtA []byte) error {
 			if b < 0x80 {
 				break
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CreateStreamRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateStreamRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicates", wireType)
+			}
+			m.Replicates = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Replicates |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quorum", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Quorum = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CreateStreamResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CreateStreamResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CreateStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= Code(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stream", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Stream == nil {
+				m.Stream = &StreamInfo{}
+			}
+			if err := m.Stream.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Extent == nil {
+				m.Extent = &ExtentInfo{}
+			}
+			if err := m.Extent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TruncateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TruncateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TruncateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+			}
+			m.StreamID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StreamID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
+			}
+			m.ExtentID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExtentID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TruncateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TruncateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TruncateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= Code(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamAttachExtentsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamAttachExtentsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamAttachExtentsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+			}
+			m.StreamID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StreamID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ExtentIDs = append(m.ExtentIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ExtentIDs) == 0 {
+					m.ExtentIDs = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ExtentIDs = append(m.ExtentIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtentIDs", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -8697,7 +10820,7 @@ func (m *CreateStreamRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CreateStreamResponse) Unmarshal(dAtA []byte) error {
+func (m *StreamAttachExtentsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8720,10 +10843,10 @@ func (m *CreateStreamResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CreateStreamResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: StreamAttachExtentsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CreateStreamResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StreamAttachExtentsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -8781,42 +10904,6 @@ func (m *CreateStreamResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Extent", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Extent == nil {
-				m.Extent = &ExtentInfo{}
-			}
-			if err := m.Extent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -8841,7 +10928,7 @@ func (m *CreateStreamResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TruncateRequest) Unmarshal(dAtA []byte) error {
+func (m *ReportCorruptExtentRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8864,17 +10951,17 @@ func (m *TruncateRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TruncateRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReportCorruptExtentRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TruncateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReportCorruptExtentRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
 			}
-			m.StreamID = 0
+			m.ExtentID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -8884,16 +10971,16 @@ func (m *TruncateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StreamID |= uint64(b&0x7F) << shift
+				m.ExtentID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeID", wireType)
 			}
-			m.ExtentID = 0
+			m.NodeID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -8903,11 +10990,43 @@ func (m *TruncateRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExtentID |= uint64(b&0x7F) << shift
+				m.NodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Reason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -8932,7 +11051,7 @@ func (m *TruncateRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TruncateResponse) Unmarshal(dAtA []byte) error {
+func (m *ReportCorruptExtentResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -8955,10 +11074,10 @@ func (m *TruncateResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TruncateResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReportCorruptExtentResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TruncateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReportCorruptExtentResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -9283,6 +11402,101 @@ func (m *ExtentInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Refs", wireType)
+			}
+			m.Refs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Refs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Corrupts = append(m.Corrupts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Corrupts) == 0 {
+					m.Corrupts = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Corrupts = append(m.Corrupts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Corrupts", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -9431,6 +11645,45 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field ExtentIDs", wireType)
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicates", wireType)
+			}
+			m.Replicates = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Replicates |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Quorum", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Quorum = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
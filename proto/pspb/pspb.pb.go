@@ -27,21 +27,52 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type CompressionType int32
+
+const (
+	CompressionType_None   CompressionType = 0
+	CompressionType_Snappy CompressionType = 1
+)
+
+var CompressionType_name = map[int32]string{
+	0: "None",
+	1: "Snappy",
+}
+
+var CompressionType_value = map[string]int32{
+	"None":   0,
+	"Snappy": 1,
+}
+
+func (x CompressionType) String() string {
+	return proto.EnumName(CompressionType_name, int32(x))
+}
+
+func (CompressionType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{0}
+}
+
 type RawBlockType int32
 
 const (
-	RawBlockType_data RawBlockType = 0
-	RawBlockType_meta RawBlockType = 1
+	RawBlockType_data  RawBlockType = 0
+	RawBlockType_meta  RawBlockType = 1
+	RawBlockType_index RawBlockType = 2
+	RawBlockType_bloom RawBlockType = 3
 )
 
 var RawBlockType_name = map[int32]string{
 	0: "data",
 	1: "meta",
+	2: "index",
+	3: "bloom",
 }
 
 var RawBlockType_value = map[string]int32{
-	"data": 0,
-	"meta": 1,
+	"data":  0,
+	"meta":  1,
+	"index": 2,
+	"bloom": 3,
 }
 
 func (x RawBlockType) String() string {
@@ -49,11 +80,13 @@ func (x RawBlockType) String() string {
 }
 
 func (RawBlockType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{0}
+	return fileDescriptor_3e3c719c85d382a4, []int{1}
 }
 
 type MixedLog struct {
-	Offsets []uint32 `protobuf:"varint,1,rep,packed,name=offsets,proto3" json:"offsets,omitempty"`
+	Offsets        []uint32        `protobuf:"varint,1,rep,packed,name=offsets,proto3" json:"offsets,omitempty"`
+	Compression    CompressionType `protobuf:"varint,2,opt,name=compression,proto3,enum=pspb.CompressionType" json:"compression,omitempty"`
+	CompressedSize uint32          `protobuf:"varint,3,opt,name=compressedSize,proto3" json:"compressedSize,omitempty"`
 }
 
 func (m *MixedLog) Reset()         { *m = MixedLog{} }
@@ -96,6 +129,20 @@ func (m *MixedLog) GetOffsets() []uint32 {
 	return nil
 }
 
+func (m *MixedLog) GetCompression() CompressionType {
+	if m != nil {
+		return m.Compression
+	}
+	return CompressionType_None
+}
+
+func (m *MixedLog) GetCompressedSize() uint32 {
+	if m != nil {
+		return m.CompressedSize
+	}
+	return 0
+}
+
 type Range struct {
 	StartKey []byte `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
 	EndKey   []byte `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
@@ -244,6 +291,51 @@ func (m *BlobStreams) GetBlob() []uint64 {
 	return nil
 }
 
+//discarded bytes of big values in each extent, updated by compaction, used by value log gc
+type DiscardStats struct {
+	Discard map[uint64]int64 `protobuf:"bytes,1,rep,name=discard,proto3" json:"discard,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *DiscardStats) Reset()         { *m = DiscardStats{} }
+func (m *DiscardStats) String() string { return proto.CompactTextString(m) }
+func (*DiscardStats) ProtoMessage()    {}
+func (*DiscardStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{4}
+}
+func (m *DiscardStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiscardStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiscardStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DiscardStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiscardStats.Merge(m, src)
+}
+func (m *DiscardStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiscardStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiscardStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiscardStats proto.InternalMessageInfo
+
+func (m *DiscardStats) GetDiscard() map[uint64]int64 {
+	if m != nil {
+		return m.Discard
+	}
+	return nil
+}
+
 type TableLocations struct {
 	Locs []*Location `protobuf:"bytes,1,rep,name=locs,proto3" json:"locs,omitempty"`
 }
@@ -252,7 +344,7 @@ func (m *TableLocations) Reset()         { *m = TableLocations{} }
 func (m *TableLocations) String() string { return proto.CompactTextString(m) }
 func (*TableLocations) ProtoMessage()    {}
 func (*TableLocations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{4}
+	return fileDescriptor_3e3c719c85d382a4, []int{5}
 }
 func (m *TableLocations) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -288,22 +380,230 @@ func (m *TableLocations) GetLocs() []*Location {
 	return nil
 }
 
+//data key of a partition, wrapped by master key of ps
+type DataKey struct {
+	KeyID       uint64 `protobuf:"varint,1,opt,name=keyID,proto3" json:"keyID,omitempty"`
+	MasterKeyID uint32 `protobuf:"varint,2,opt,name=masterKeyID,proto3" json:"masterKeyID,omitempty"`
+	Wrapped     []byte `protobuf:"bytes,3,opt,name=wrapped,proto3" json:"wrapped,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (m *DataKey) Reset()         { *m = DataKey{} }
+func (m *DataKey) String() string { return proto.CompactTextString(m) }
+func (*DataKey) ProtoMessage()    {}
+func (*DataKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{6}
+}
+func (m *DataKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DataKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataKey.Merge(m, src)
+}
+func (m *DataKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataKey proto.InternalMessageInfo
+
+func (m *DataKey) GetKeyID() uint64 {
+	if m != nil {
+		return m.KeyID
+	}
+	return 0
+}
+
+func (m *DataKey) GetMasterKeyID() uint32 {
+	if m != nil {
+		return m.MasterKeyID
+	}
+	return 0
+}
+
+func (m *DataKey) GetWrapped() []byte {
+	if m != nil {
+		return m.Wrapped
+	}
+	return nil
+}
+
+func (m *DataKey) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+type DataKeys struct {
+	Keys []*DataKey `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (m *DataKeys) Reset()         { *m = DataKeys{} }
+func (m *DataKeys) String() string { return proto.CompactTextString(m) }
+func (*DataKeys) ProtoMessage()    {}
+func (*DataKeys) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{7}
+}
+func (m *DataKeys) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataKeys) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataKeys.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DataKeys) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataKeys.Merge(m, src)
+}
+func (m *DataKeys) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataKeys) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataKeys.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataKeys proto.InternalMessageInfo
+
+func (m *DataKeys) GetKeys() []*DataKey {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+//options of a partition, which override options of ps, 0 means the option of ps
+type PartitionOptions struct {
+	MemtableSize    uint64 `protobuf:"varint,1,opt,name=memtableSize,proto3" json:"memtableSize,omitempty"`
+	WriteChCapacity uint32 `protobuf:"varint,2,opt,name=writeChCapacity,proto3" json:"writeChCapacity,omitempty"`
+	MaxReplaySize   uint64 `protobuf:"varint,3,opt,name=maxReplaySize,proto3" json:"maxReplaySize,omitempty"`
+	FlushInterval   int64  `protobuf:"varint,4,opt,name=flushInterval,proto3" json:"flushInterval,omitempty"`
+	TableBlockSize  uint32 `protobuf:"varint,5,opt,name=tableBlockSize,proto3" json:"tableBlockSize,omitempty"`
+	ValueThreshold  uint32 `protobuf:"varint,6,opt,name=valueThreshold,proto3" json:"valueThreshold,omitempty"`
+	MixedBlockSize  uint32 `protobuf:"varint,7,opt,name=mixedBlockSize,proto3" json:"mixedBlockSize,omitempty"`
+}
+
+func (m *PartitionOptions) Reset()         { *m = PartitionOptions{} }
+func (m *PartitionOptions) String() string { return proto.CompactTextString(m) }
+func (*PartitionOptions) ProtoMessage()    {}
+func (*PartitionOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{8}
+}
+func (m *PartitionOptions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PartitionOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PartitionOptions.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PartitionOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionOptions.Merge(m, src)
+}
+func (m *PartitionOptions) XXX_Size() int {
+	return m.Size()
+}
+func (m *PartitionOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionOptions proto.InternalMessageInfo
+
+func (m *PartitionOptions) GetMemtableSize() uint64 {
+	if m != nil {
+		return m.MemtableSize
+	}
+	return 0
+}
+
+func (m *PartitionOptions) GetWriteChCapacity() uint32 {
+	if m != nil {
+		return m.WriteChCapacity
+	}
+	return 0
+}
+
+func (m *PartitionOptions) GetMaxReplaySize() uint64 {
+	if m != nil {
+		return m.MaxReplaySize
+	}
+	return 0
+}
+
+func (m *PartitionOptions) GetFlushInterval() int64 {
+	if m != nil {
+		return m.FlushInterval
+	}
+	return 0
+}
+
+func (m *PartitionOptions) GetTableBlockSize() uint32 {
+	if m != nil {
+		return m.TableBlockSize
+	}
+	return 0
+}
+
+func (m *PartitionOptions) GetValueThreshold() uint32 {
+	if m != nil {
+		return m.ValueThreshold
+	}
+	return 0
+}
+
+func (m *PartitionOptions) GetMixedBlockSize() uint32 {
+	if m != nil {
+		return m.MixedBlockSize
+	}
+	return 0
+}
+
 type PartitionMeta struct {
-	Blobs     *BlobStreams    `protobuf:"bytes,1,opt,name=blobs,proto3" json:"blobs,omitempty"`
-	LogStream uint64          `protobuf:"varint,2,opt,name=logStream,proto3" json:"logStream,omitempty"`
-	RowStream uint64          `protobuf:"varint,3,opt,name=rowStream,proto3" json:"rowStream,omitempty"`
-	Locs      *TableLocations `protobuf:"bytes,4,opt,name=locs,proto3" json:"locs,omitempty"`
-	Parent    uint64          `protobuf:"varint,5,opt,name=parent,proto3" json:"parent,omitempty"`
-	Discard   []byte          `protobuf:"bytes,6,opt,name=discard,proto3" json:"discard,omitempty"`
-	Rg        *Range          `protobuf:"bytes,7,opt,name=rg,proto3" json:"rg,omitempty"`
-	PartID    uint64          `protobuf:"varint,8,opt,name=PartID,proto3" json:"PartID,omitempty"`
+	Blobs     *BlobStreams      `protobuf:"bytes,1,opt,name=blobs,proto3" json:"blobs,omitempty"`
+	LogStream uint64            `protobuf:"varint,2,opt,name=logStream,proto3" json:"logStream,omitempty"`
+	RowStream uint64            `protobuf:"varint,3,opt,name=rowStream,proto3" json:"rowStream,omitempty"`
+	Locs      *TableLocations   `protobuf:"bytes,4,opt,name=locs,proto3" json:"locs,omitempty"`
+	Parent    uint64            `protobuf:"varint,5,opt,name=parent,proto3" json:"parent,omitempty"`
+	Discard   []byte            `protobuf:"bytes,6,opt,name=discard,proto3" json:"discard,omitempty"`
+	Rg        *Range            `protobuf:"bytes,7,opt,name=rg,proto3" json:"rg,omitempty"`
+	PartID    uint64            `protobuf:"varint,8,opt,name=PartID,proto3" json:"PartID,omitempty"`
+	Keys      *DataKeys         `protobuf:"bytes,9,opt,name=keys,proto3" json:"keys,omitempty"`
+	Opts      *PartitionOptions `protobuf:"bytes,10,opt,name=opts,proto3" json:"opts,omitempty"`
 }
 
 func (m *PartitionMeta) Reset()         { *m = PartitionMeta{} }
 func (m *PartitionMeta) String() string { return proto.CompactTextString(m) }
 func (*PartitionMeta) ProtoMessage()    {}
 func (*PartitionMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{5}
+	return fileDescriptor_3e3c719c85d382a4, []int{9}
 }
 func (m *PartitionMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -388,6 +688,20 @@ func (m *PartitionMeta) GetPartID() uint64 {
 	return 0
 }
 
+func (m *PartitionMeta) GetKeys() *DataKeys {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (m *PartitionMeta) GetOpts() *PartitionOptions {
+	if m != nil {
+		return m.Opts
+	}
+	return nil
+}
+
 type PSDetail struct {
 	PSID    uint64 `protobuf:"varint,1,opt,name=PSID,proto3" json:"PSID,omitempty"`
 	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
@@ -397,7 +711,7 @@ func (m *PSDetail) Reset()         { *m = PSDetail{} }
 func (m *PSDetail) String() string { return proto.CompactTextString(m) }
 func (*PSDetail) ProtoMessage()    {}
 func (*PSDetail) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{6}
+	return fileDescriptor_3e3c719c85d382a4, []int{10}
 }
 func (m *PSDetail) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -451,7 +765,7 @@ func (m *RegionInfo) Reset()         { *m = RegionInfo{} }
 func (m *RegionInfo) String() string { return proto.CompactTextString(m) }
 func (*RegionInfo) ProtoMessage()    {}
 func (*RegionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{7}
+	return fileDescriptor_3e3c719c85d382a4, []int{11}
 }
 func (m *RegionInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -510,19 +824,22 @@ func (m *RegionInfo) GetAddr() string {
 
 //BlockMeta will be marshaled into pb.Block.userdata
 type RawBlockMeta struct {
-	Type             RawBlockType `protobuf:"varint,1,opt,name=type,proto3,enum=pspb.RawBlockType" json:"type,omitempty"`
-	CompressedSize   uint32       `protobuf:"varint,2,opt,name=CompressedSize,proto3" json:"CompressedSize,omitempty"`
-	UnCompressedSize uint32       `protobuf:"varint,3,opt,name=UnCompressedSize,proto3" json:"UnCompressedSize,omitempty"`
-	VpExtentID       uint64       `protobuf:"varint,4,opt,name=vpExtentID,proto3" json:"vpExtentID,omitempty"`
-	VpOffset         uint32       `protobuf:"varint,5,opt,name=vpOffset,proto3" json:"vpOffset,omitempty"`
-	SeqNum           uint64       `protobuf:"varint,6,opt,name=seqNum,proto3" json:"seqNum,omitempty"`
+	Type             RawBlockType    `protobuf:"varint,1,opt,name=type,proto3,enum=pspb.RawBlockType" json:"type,omitempty"`
+	CompressedSize   uint32          `protobuf:"varint,2,opt,name=CompressedSize,proto3" json:"CompressedSize,omitempty"`
+	UnCompressedSize uint32          `protobuf:"varint,3,opt,name=UnCompressedSize,proto3" json:"UnCompressedSize,omitempty"`
+	VpExtentID       uint64          `protobuf:"varint,4,opt,name=vpExtentID,proto3" json:"vpExtentID,omitempty"`
+	VpOffset         uint32          `protobuf:"varint,5,opt,name=vpOffset,proto3" json:"vpOffset,omitempty"`
+	SeqNum           uint64          `protobuf:"varint,6,opt,name=seqNum,proto3" json:"seqNum,omitempty"`
+	Compression      CompressionType `protobuf:"varint,7,opt,name=compression,proto3,enum=pspb.CompressionType" json:"compression,omitempty"`
+	KeyID            uint64          `protobuf:"varint,8,opt,name=keyID,proto3" json:"keyID,omitempty"`
+	EncryptedSize    uint32          `protobuf:"varint,9,opt,name=encryptedSize,proto3" json:"encryptedSize,omitempty"`
 }
 
 func (m *RawBlockMeta) Reset()         { *m = RawBlockMeta{} }
 func (m *RawBlockMeta) String() string { return proto.CompactTextString(m) }
 func (*RawBlockMeta) ProtoMessage()    {}
 func (*RawBlockMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{8}
+	return fileDescriptor_3e3c719c85d382a4, []int{12}
 }
 func (m *RawBlockMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -593,6 +910,27 @@ func (m *RawBlockMeta) GetSeqNum() uint64 {
 	return 0
 }
 
+func (m *RawBlockMeta) GetCompression() CompressionType {
+	if m != nil {
+		return m.Compression
+	}
+	return CompressionType_None
+}
+
+func (m *RawBlockMeta) GetKeyID() uint64 {
+	if m != nil {
+		return m.KeyID
+	}
+	return 0
+}
+
+func (m *RawBlockMeta) GetEncryptedSize() uint32 {
+	if m != nil {
+		return m.EncryptedSize
+	}
+	return 0
+}
+
 type BlockOffset struct {
 	Key      []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 	ExtentID uint64 `protobuf:"varint,2,opt,name=extentID,proto3" json:"extentID,omitempty"`
@@ -603,7 +941,7 @@ func (m *BlockOffset) Reset()         { *m = BlockOffset{} }
 func (m *BlockOffset) String() string { return proto.CompactTextString(m) }
 func (*BlockOffset) ProtoMessage()    {}
 func (*BlockOffset) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{9}
+	return fileDescriptor_3e3c719c85d382a4, []int{13}
 }
 func (m *BlockOffset) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -658,13 +996,18 @@ type TableIndex struct {
 	BloomFilter   []byte         `protobuf:"bytes,2,opt,name=bloomFilter,proto3" json:"bloomFilter,omitempty"`
 	EstimatedSize uint64         `protobuf:"varint,3,opt,name=estimatedSize,proto3" json:"estimatedSize,omitempty"`
 	NumOfBlocks   uint32         `protobuf:"varint,4,opt,name=numOfBlocks,proto3" json:"numOfBlocks,omitempty"`
+	//new tables: offsets are in index block, bloom filter is in bloom block, both are loaded lazily
+	IndexLoc *Location `protobuf:"bytes,5,opt,name=indexLoc,proto3" json:"indexLoc,omitempty"`
+	BloomLoc *Location `protobuf:"bytes,6,opt,name=bloomLoc,proto3" json:"bloomLoc,omitempty"`
+	Smallest []byte    `protobuf:"bytes,7,opt,name=smallest,proto3" json:"smallest,omitempty"`
+	Biggest  []byte    `protobuf:"bytes,8,opt,name=biggest,proto3" json:"biggest,omitempty"`
 }
 
 func (m *TableIndex) Reset()         { *m = TableIndex{} }
 func (m *TableIndex) String() string { return proto.CompactTextString(m) }
 func (*TableIndex) ProtoMessage()    {}
 func (*TableIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{10}
+	return fileDescriptor_3e3c719c85d382a4, []int{14}
 }
 func (m *TableIndex) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -721,6 +1064,34 @@ func (m *TableIndex) GetNumOfBlocks() uint32 {
 	return 0
 }
 
+func (m *TableIndex) GetIndexLoc() *Location {
+	if m != nil {
+		return m.IndexLoc
+	}
+	return nil
+}
+
+func (m *TableIndex) GetBloomLoc() *Location {
+	if m != nil {
+		return m.BloomLoc
+	}
+	return nil
+}
+
+func (m *TableIndex) GetSmallest() []byte {
+	if m != nil {
+		return m.Smallest
+	}
+	return nil
+}
+
+func (m *TableIndex) GetBiggest() []byte {
+	if m != nil {
+		return m.Biggest
+	}
+	return nil
+}
+
 type GetPartitionMetaRequest struct {
 	PSID uint64 `protobuf:"varint,1,opt,name=PSID,proto3" json:"PSID,omitempty"`
 }
@@ -729,7 +1100,7 @@ func (m *GetPartitionMetaRequest) Reset()         { *m = GetPartitionMetaRequest
 func (m *GetPartitionMetaRequest) String() string { return proto.CompactTextString(m) }
 func (*GetPartitionMetaRequest) ProtoMessage()    {}
 func (*GetPartitionMetaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{11}
+	return fileDescriptor_3e3c719c85d382a4, []int{15}
 }
 func (m *GetPartitionMetaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -774,7 +1145,7 @@ func (m *GetPartitionMetaResponse) Reset()         { *m = GetPartitionMetaRespon
 func (m *GetPartitionMetaResponse) String() string { return proto.CompactTextString(m) }
 func (*GetPartitionMetaResponse) ProtoMessage()    {}
 func (*GetPartitionMetaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{12}
+	return fileDescriptor_3e3c719c85d382a4, []int{16}
 }
 func (m *GetPartitionMetaResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -826,7 +1197,7 @@ func (m *SetRowStreamTablesRequest) Reset()         { *m = SetRowStreamTablesReq
 func (m *SetRowStreamTablesRequest) String() string { return proto.CompactTextString(m) }
 func (*SetRowStreamTablesRequest) ProtoMessage()    {}
 func (*SetRowStreamTablesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{13}
+	return fileDescriptor_3e3c719c85d382a4, []int{17}
 }
 func (m *SetRowStreamTablesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -877,7 +1248,7 @@ func (m *SetRowStreamTablesResponse) Reset()         { *m = SetRowStreamTablesRe
 func (m *SetRowStreamTablesResponse) String() string { return proto.CompactTextString(m) }
 func (*SetRowStreamTablesResponse) ProtoMessage()    {}
 func (*SetRowStreamTablesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{14}
+	return fileDescriptor_3e3c719c85d382a4, []int{18}
 }
 func (m *SetRowStreamTablesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -913,21 +1284,23 @@ func (m *SetRowStreamTablesResponse) GetCode() pb.Code {
 	return pb.Code_OK
 }
 
-type GetRegionsRequest struct {
+type SetDataKeysRequest struct {
+	PartitionID uint64    `protobuf:"varint,1,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
+	Keys        *DataKeys `protobuf:"bytes,2,opt,name=keys,proto3" json:"keys,omitempty"`
 }
 
-func (m *GetRegionsRequest) Reset()         { *m = GetRegionsRequest{} }
-func (m *GetRegionsRequest) String() string { return proto.CompactTextString(m) }
-func (*GetRegionsRequest) ProtoMessage()    {}
-func (*GetRegionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{15}
+func (m *SetDataKeysRequest) Reset()         { *m = SetDataKeysRequest{} }
+func (m *SetDataKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDataKeysRequest) ProtoMessage()    {}
+func (*SetDataKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{19}
 }
-func (m *GetRegionsRequest) XXX_Unmarshal(b []byte) error {
+func (m *SetDataKeysRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetRegionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetDataKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetRegionsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetDataKeysRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -937,35 +1310,48 @@ func (m *GetRegionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *GetRegionsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRegionsRequest.Merge(m, src)
+func (m *SetDataKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDataKeysRequest.Merge(m, src)
 }
-func (m *GetRegionsRequest) XXX_Size() int {
+func (m *SetDataKeysRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetRegionsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRegionsRequest.DiscardUnknown(m)
+func (m *SetDataKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDataKeysRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRegionsRequest proto.InternalMessageInfo
+var xxx_messageInfo_SetDataKeysRequest proto.InternalMessageInfo
 
-type GetRegionsResponse struct {
-	Code    pb.Code       `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
-	Regions []*RegionInfo `protobuf:"bytes,2,rep,name=regions,proto3" json:"regions,omitempty"`
+func (m *SetDataKeysRequest) GetPartitionID() uint64 {
+	if m != nil {
+		return m.PartitionID
+	}
+	return 0
 }
 
-func (m *GetRegionsResponse) Reset()         { *m = GetRegionsResponse{} }
-func (m *GetRegionsResponse) String() string { return proto.CompactTextString(m) }
-func (*GetRegionsResponse) ProtoMessage()    {}
-func (*GetRegionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{16}
+func (m *SetDataKeysRequest) GetKeys() *DataKeys {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
 }
-func (m *GetRegionsResponse) XXX_Unmarshal(b []byte) error {
+
+type SetDataKeysResponse struct {
+	Code pb.Code `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
+}
+
+func (m *SetDataKeysResponse) Reset()         { *m = SetDataKeysResponse{} }
+func (m *SetDataKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*SetDataKeysResponse) ProtoMessage()    {}
+func (*SetDataKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{20}
+}
+func (m *SetDataKeysResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetRegionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetDataKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetRegionsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetDataKeysResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -975,48 +1361,42 @@ func (m *GetRegionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *GetRegionsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRegionsResponse.Merge(m, src)
+func (m *SetDataKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDataKeysResponse.Merge(m, src)
 }
-func (m *GetRegionsResponse) XXX_Size() int {
+func (m *SetDataKeysResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetRegionsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRegionsResponse.DiscardUnknown(m)
+func (m *SetDataKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDataKeysResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRegionsResponse proto.InternalMessageInfo
+var xxx_messageInfo_SetDataKeysResponse proto.InternalMessageInfo
 
-func (m *GetRegionsResponse) GetCode() pb.Code {
+func (m *SetDataKeysResponse) GetCode() pb.Code {
 	if m != nil {
 		return m.Code
 	}
 	return pb.Code_OK
 }
 
-func (m *GetRegionsResponse) GetRegions() []*RegionInfo {
-	if m != nil {
-		return m.Regions
-	}
-	return nil
-}
-
-type RegisterPSRequest struct {
-	Addr string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+type SetDiscardStatsRequest struct {
+	PartitionID uint64        `protobuf:"varint,1,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
+	Stats       *DiscardStats `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
 }
 
-func (m *RegisterPSRequest) Reset()         { *m = RegisterPSRequest{} }
-func (m *RegisterPSRequest) String() string { return proto.CompactTextString(m) }
-func (*RegisterPSRequest) ProtoMessage()    {}
-func (*RegisterPSRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{17}
+func (m *SetDiscardStatsRequest) Reset()         { *m = SetDiscardStatsRequest{} }
+func (m *SetDiscardStatsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetDiscardStatsRequest) ProtoMessage()    {}
+func (*SetDiscardStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{21}
 }
-func (m *RegisterPSRequest) XXX_Unmarshal(b []byte) error {
+func (m *SetDiscardStatsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RegisterPSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetDiscardStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RegisterPSRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetDiscardStatsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1026,42 +1406,48 @@ func (m *RegisterPSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *RegisterPSRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RegisterPSRequest.Merge(m, src)
+func (m *SetDiscardStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDiscardStatsRequest.Merge(m, src)
 }
-func (m *RegisterPSRequest) XXX_Size() int {
+func (m *SetDiscardStatsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *RegisterPSRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RegisterPSRequest.DiscardUnknown(m)
+func (m *SetDiscardStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDiscardStatsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RegisterPSRequest proto.InternalMessageInfo
+var xxx_messageInfo_SetDiscardStatsRequest proto.InternalMessageInfo
 
-func (m *RegisterPSRequest) GetAddr() string {
+func (m *SetDiscardStatsRequest) GetPartitionID() uint64 {
 	if m != nil {
-		return m.Addr
+		return m.PartitionID
 	}
-	return ""
+	return 0
 }
 
-type RegisterPSResponse struct {
+func (m *SetDiscardStatsRequest) GetStats() *DiscardStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type SetDiscardStatsResponse struct {
 	Code pb.Code `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
-	Id   uint64  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (m *RegisterPSResponse) Reset()         { *m = RegisterPSResponse{} }
-func (m *RegisterPSResponse) String() string { return proto.CompactTextString(m) }
-func (*RegisterPSResponse) ProtoMessage()    {}
-func (*RegisterPSResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{18}
+func (m *SetDiscardStatsResponse) Reset()         { *m = SetDiscardStatsResponse{} }
+func (m *SetDiscardStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetDiscardStatsResponse) ProtoMessage()    {}
+func (*SetDiscardStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{22}
 }
-func (m *RegisterPSResponse) XXX_Unmarshal(b []byte) error {
+func (m *SetDiscardStatsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RegisterPSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetDiscardStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RegisterPSResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetDiscardStatsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1071,47 +1457,42 @@ func (m *RegisterPSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *RegisterPSResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RegisterPSResponse.Merge(m, src)
+func (m *SetDiscardStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetDiscardStatsResponse.Merge(m, src)
 }
-func (m *RegisterPSResponse) XXX_Size() int {
+func (m *SetDiscardStatsResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *RegisterPSResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RegisterPSResponse.DiscardUnknown(m)
+func (m *SetDiscardStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetDiscardStatsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RegisterPSResponse proto.InternalMessageInfo
+var xxx_messageInfo_SetDiscardStatsResponse proto.InternalMessageInfo
 
-func (m *RegisterPSResponse) GetCode() pb.Code {
+func (m *SetDiscardStatsResponse) GetCode() pb.Code {
 	if m != nil {
 		return m.Code
 	}
 	return pb.Code_OK
 }
 
-func (m *RegisterPSResponse) GetId() uint64 {
-	if m != nil {
-		return m.Id
-	}
-	return 0
-}
-
-type GetPSInfoRequest struct {
+type SetBlobStreamsRequest struct {
+	PartitionID uint64       `protobuf:"varint,1,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
+	Blobs       *BlobStreams `protobuf:"bytes,2,opt,name=blobs,proto3" json:"blobs,omitempty"`
 }
 
-func (m *GetPSInfoRequest) Reset()         { *m = GetPSInfoRequest{} }
-func (m *GetPSInfoRequest) String() string { return proto.CompactTextString(m) }
-func (*GetPSInfoRequest) ProtoMessage()    {}
-func (*GetPSInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{19}
+func (m *SetBlobStreamsRequest) Reset()         { *m = SetBlobStreamsRequest{} }
+func (m *SetBlobStreamsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetBlobStreamsRequest) ProtoMessage()    {}
+func (*SetBlobStreamsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{23}
 }
-func (m *GetPSInfoRequest) XXX_Unmarshal(b []byte) error {
+func (m *SetBlobStreamsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetPSInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetBlobStreamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetPSInfoRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetBlobStreamsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1121,34 +1502,48 @@ func (m *GetPSInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *GetPSInfoRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetPSInfoRequest.Merge(m, src)
+func (m *SetBlobStreamsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBlobStreamsRequest.Merge(m, src)
 }
-func (m *GetPSInfoRequest) XXX_Size() int {
+func (m *SetBlobStreamsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetPSInfoRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetPSInfoRequest.DiscardUnknown(m)
+func (m *SetBlobStreamsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBlobStreamsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetPSInfoRequest proto.InternalMessageInfo
+var xxx_messageInfo_SetBlobStreamsRequest proto.InternalMessageInfo
 
-type GetPSInfoResponse struct {
-	Servers []*PSDetail `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+func (m *SetBlobStreamsRequest) GetPartitionID() uint64 {
+	if m != nil {
+		return m.PartitionID
+	}
+	return 0
 }
 
-func (m *GetPSInfoResponse) Reset()         { *m = GetPSInfoResponse{} }
-func (m *GetPSInfoResponse) String() string { return proto.CompactTextString(m) }
-func (*GetPSInfoResponse) ProtoMessage()    {}
-func (*GetPSInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{20}
+func (m *SetBlobStreamsRequest) GetBlobs() *BlobStreams {
+	if m != nil {
+		return m.Blobs
+	}
+	return nil
 }
-func (m *GetPSInfoResponse) XXX_Unmarshal(b []byte) error {
+
+type SetBlobStreamsResponse struct {
+	Code pb.Code `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
+}
+
+func (m *SetBlobStreamsResponse) Reset()         { *m = SetBlobStreamsResponse{} }
+func (m *SetBlobStreamsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetBlobStreamsResponse) ProtoMessage()    {}
+func (*SetBlobStreamsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{24}
+}
+func (m *SetBlobStreamsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetPSInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetBlobStreamsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetPSInfoResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetBlobStreamsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1158,43 +1553,42 @@ func (m *GetPSInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *GetPSInfoResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetPSInfoResponse.Merge(m, src)
+func (m *SetBlobStreamsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBlobStreamsResponse.Merge(m, src)
 }
-func (m *GetPSInfoResponse) XXX_Size() int {
+func (m *SetBlobStreamsResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetPSInfoResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetPSInfoResponse.DiscardUnknown(m)
+func (m *SetBlobStreamsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBlobStreamsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetPSInfoResponse proto.InternalMessageInfo
+var xxx_messageInfo_SetBlobStreamsResponse proto.InternalMessageInfo
 
-func (m *GetPSInfoResponse) GetServers() []*PSDetail {
+func (m *SetBlobStreamsResponse) GetCode() pb.Code {
 	if m != nil {
-		return m.Servers
+		return m.Code
 	}
-	return nil
+	return pb.Code_OK
 }
 
-type BootstrapRequest struct {
-	LogID  uint64 `protobuf:"varint,1,opt,name=logID,proto3" json:"logID,omitempty"`
-	RowID  uint64 `protobuf:"varint,2,opt,name=rowID,proto3" json:"rowID,omitempty"`
-	Parent uint64 `protobuf:"varint,3,opt,name=parent,proto3" json:"parent,omitempty"`
+type SetPartitionOptionsRequest struct {
+	PartitionID uint64            `protobuf:"varint,1,opt,name=partitionID,proto3" json:"partitionID,omitempty"`
+	Opts        *PartitionOptions `protobuf:"bytes,2,opt,name=opts,proto3" json:"opts,omitempty"`
 }
 
-func (m *BootstrapRequest) Reset()         { *m = BootstrapRequest{} }
-func (m *BootstrapRequest) String() string { return proto.CompactTextString(m) }
-func (*BootstrapRequest) ProtoMessage()    {}
-func (*BootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{21}
+func (m *SetPartitionOptionsRequest) Reset()         { *m = SetPartitionOptionsRequest{} }
+func (m *SetPartitionOptionsRequest) String() string { return proto.CompactTextString(m) }
+func (*SetPartitionOptionsRequest) ProtoMessage()    {}
+func (*SetPartitionOptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{25}
 }
-func (m *BootstrapRequest) XXX_Unmarshal(b []byte) error {
+func (m *SetPartitionOptionsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BootstrapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetPartitionOptionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BootstrapRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetPartitionOptionsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1204,55 +1598,48 @@ func (m *BootstrapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *BootstrapRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BootstrapRequest.Merge(m, src)
+func (m *SetPartitionOptionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPartitionOptionsRequest.Merge(m, src)
 }
-func (m *BootstrapRequest) XXX_Size() int {
+func (m *SetPartitionOptionsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *BootstrapRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_BootstrapRequest.DiscardUnknown(m)
+func (m *SetPartitionOptionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPartitionOptionsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BootstrapRequest proto.InternalMessageInfo
-
-func (m *BootstrapRequest) GetLogID() uint64 {
-	if m != nil {
-		return m.LogID
-	}
-	return 0
-}
+var xxx_messageInfo_SetPartitionOptionsRequest proto.InternalMessageInfo
 
-func (m *BootstrapRequest) GetRowID() uint64 {
+func (m *SetPartitionOptionsRequest) GetPartitionID() uint64 {
 	if m != nil {
-		return m.RowID
+		return m.PartitionID
 	}
 	return 0
 }
 
-func (m *BootstrapRequest) GetParent() uint64 {
+func (m *SetPartitionOptionsRequest) GetOpts() *PartitionOptions {
 	if m != nil {
-		return m.Parent
+		return m.Opts
 	}
-	return 0
+	return nil
 }
 
-type BootstrapResponse struct {
-	PartID uint64 `protobuf:"varint,1,opt,name=partID,proto3" json:"partID,omitempty"`
+type SetPartitionOptionsResponse struct {
+	Code pb.Code `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
 }
 
-func (m *BootstrapResponse) Reset()         { *m = BootstrapResponse{} }
-func (m *BootstrapResponse) String() string { return proto.CompactTextString(m) }
-func (*BootstrapResponse) ProtoMessage()    {}
-func (*BootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{22}
+func (m *SetPartitionOptionsResponse) Reset()         { *m = SetPartitionOptionsResponse{} }
+func (m *SetPartitionOptionsResponse) String() string { return proto.CompactTextString(m) }
+func (*SetPartitionOptionsResponse) ProtoMessage()    {}
+func (*SetPartitionOptionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{26}
 }
-func (m *BootstrapResponse) XXX_Unmarshal(b []byte) error {
+func (m *SetPartitionOptionsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BootstrapResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SetPartitionOptionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BootstrapResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SetPartitionOptionsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1262,45 +1649,40 @@ func (m *BootstrapResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *BootstrapResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BootstrapResponse.Merge(m, src)
+func (m *SetPartitionOptionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetPartitionOptionsResponse.Merge(m, src)
 }
-func (m *BootstrapResponse) XXX_Size() int {
+func (m *SetPartitionOptionsResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *BootstrapResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_BootstrapResponse.DiscardUnknown(m)
+func (m *SetPartitionOptionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetPartitionOptionsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BootstrapResponse proto.InternalMessageInfo
+var xxx_messageInfo_SetPartitionOptionsResponse proto.InternalMessageInfo
 
-func (m *BootstrapResponse) GetPartID() uint64 {
+func (m *SetPartitionOptionsResponse) GetCode() pb.Code {
 	if m != nil {
-		return m.PartID
+		return m.Code
 	}
-	return 0
+	return pb.Code_OK
 }
 
-type PutRequest struct {
-	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value     []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	ExpiresAt uint64 `protobuf:"varint,3,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
-	Psversion uint64 `protobuf:"varint,4,opt,name=psversion,proto3" json:"psversion,omitempty"`
-	Partid    uint64 `protobuf:"varint,5,opt,name=partid,proto3" json:"partid,omitempty"`
+type GetRegionsRequest struct {
 }
 
-func (m *PutRequest) Reset()         { *m = PutRequest{} }
-func (m *PutRequest) String() string { return proto.CompactTextString(m) }
-func (*PutRequest) ProtoMessage()    {}
-func (*PutRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{23}
+func (m *GetRegionsRequest) Reset()         { *m = GetRegionsRequest{} }
+func (m *GetRegionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRegionsRequest) ProtoMessage()    {}
+func (*GetRegionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{27}
 }
-func (m *PutRequest) XXX_Unmarshal(b []byte) error {
+func (m *GetRegionsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetRegionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PutRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetRegionsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1310,69 +1692,86 @@ func (m *PutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PutRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PutRequest.Merge(m, src)
+func (m *GetRegionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRegionsRequest.Merge(m, src)
 }
-func (m *PutRequest) XXX_Size() int {
+func (m *GetRegionsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PutRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PutRequest.DiscardUnknown(m)
+func (m *GetRegionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRegionsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PutRequest proto.InternalMessageInfo
-
-func (m *PutRequest) GetKey() []byte {
-	if m != nil {
-		return m.Key
-	}
-	return nil
-}
+var xxx_messageInfo_GetRegionsRequest proto.InternalMessageInfo
 
-func (m *PutRequest) GetValue() []byte {
-	if m != nil {
-		return m.Value
-	}
-	return nil
+type GetRegionsResponse struct {
+	Code    pb.Code       `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
+	Regions []*RegionInfo `protobuf:"bytes,2,rep,name=regions,proto3" json:"regions,omitempty"`
 }
 
-func (m *PutRequest) GetExpiresAt() uint64 {
-	if m != nil {
-		return m.ExpiresAt
+func (m *GetRegionsResponse) Reset()         { *m = GetRegionsResponse{} }
+func (m *GetRegionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRegionsResponse) ProtoMessage()    {}
+func (*GetRegionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{28}
+}
+func (m *GetRegionsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRegionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRegionsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
+}
+func (m *GetRegionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRegionsResponse.Merge(m, src)
+}
+func (m *GetRegionsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRegionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRegionsResponse.DiscardUnknown(m)
 }
 
-func (m *PutRequest) GetPsversion() uint64 {
+var xxx_messageInfo_GetRegionsResponse proto.InternalMessageInfo
+
+func (m *GetRegionsResponse) GetCode() pb.Code {
 	if m != nil {
-		return m.Psversion
+		return m.Code
 	}
-	return 0
+	return pb.Code_OK
 }
 
-func (m *PutRequest) GetPartid() uint64 {
+func (m *GetRegionsResponse) GetRegions() []*RegionInfo {
 	if m != nil {
-		return m.Partid
+		return m.Regions
 	}
-	return 0
+	return nil
 }
 
-type PutResponse struct {
-	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+type RegisterPSRequest struct {
+	Addr string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
 }
 
-func (m *PutResponse) Reset()         { *m = PutResponse{} }
-func (m *PutResponse) String() string { return proto.CompactTextString(m) }
-func (*PutResponse) ProtoMessage()    {}
-func (*PutResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{24}
+func (m *RegisterPSRequest) Reset()         { *m = RegisterPSRequest{} }
+func (m *RegisterPSRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterPSRequest) ProtoMessage()    {}
+func (*RegisterPSRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{29}
 }
-func (m *PutResponse) XXX_Unmarshal(b []byte) error {
+func (m *RegisterPSRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RegisterPSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PutResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RegisterPSRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1382,43 +1781,42 @@ func (m *PutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *PutResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PutResponse.Merge(m, src)
+func (m *RegisterPSRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterPSRequest.Merge(m, src)
 }
-func (m *PutResponse) XXX_Size() int {
+func (m *RegisterPSRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PutResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PutResponse.DiscardUnknown(m)
+func (m *RegisterPSRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterPSRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PutResponse proto.InternalMessageInfo
+var xxx_messageInfo_RegisterPSRequest proto.InternalMessageInfo
 
-func (m *PutResponse) GetKey() []byte {
+func (m *RegisterPSRequest) GetAddr() string {
 	if m != nil {
-		return m.Key
+		return m.Addr
 	}
-	return nil
+	return ""
 }
 
-type DeleteRequest struct {
-	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Psversion uint64 `protobuf:"varint,2,opt,name=psversion,proto3" json:"psversion,omitempty"`
-	Partid    uint64 `protobuf:"varint,5,opt,name=partid,proto3" json:"partid,omitempty"`
+type RegisterPSResponse struct {
+	Code pb.Code `protobuf:"varint,1,opt,name=code,proto3,enum=pb.Code" json:"code,omitempty"`
+	Id   uint64  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
-func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteRequest) ProtoMessage()    {}
-func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{25}
+func (m *RegisterPSResponse) Reset()         { *m = RegisterPSResponse{} }
+func (m *RegisterPSResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterPSResponse) ProtoMessage()    {}
+func (*RegisterPSResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{30}
 }
-func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
+func (m *RegisterPSResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RegisterPSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RegisterPSResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1428,56 +1826,47 @@ func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *DeleteRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteRequest.Merge(m, src)
+func (m *RegisterPSResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterPSResponse.Merge(m, src)
 }
-func (m *DeleteRequest) XXX_Size() int {
+func (m *RegisterPSResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
+func (m *RegisterPSResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterPSResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
-
-func (m *DeleteRequest) GetKey() []byte {
-	if m != nil {
-		return m.Key
-	}
-	return nil
-}
+var xxx_messageInfo_RegisterPSResponse proto.InternalMessageInfo
 
-func (m *DeleteRequest) GetPsversion() uint64 {
+func (m *RegisterPSResponse) GetCode() pb.Code {
 	if m != nil {
-		return m.Psversion
+		return m.Code
 	}
-	return 0
+	return pb.Code_OK
 }
 
-func (m *DeleteRequest) GetPartid() uint64 {
+func (m *RegisterPSResponse) GetId() uint64 {
 	if m != nil {
-		return m.Partid
+		return m.Id
 	}
 	return 0
 }
 
-type DeleteResponse struct {
-	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Psversion uint64 `protobuf:"varint,2,opt,name=psversion,proto3" json:"psversion,omitempty"`
+type GetPSInfoRequest struct {
 }
 
-func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
-func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteResponse) ProtoMessage()    {}
-func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{26}
+func (m *GetPSInfoRequest) Reset()         { *m = GetPSInfoRequest{} }
+func (m *GetPSInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPSInfoRequest) ProtoMessage()    {}
+func (*GetPSInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{31}
 }
-func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
+func (m *GetPSInfoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetPSInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetPSInfoRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1487,50 +1876,34 @@ func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *DeleteResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteResponse.Merge(m, src)
+func (m *GetPSInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPSInfoRequest.Merge(m, src)
 }
-func (m *DeleteResponse) XXX_Size() int {
+func (m *GetPSInfoRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DeleteResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
-
-func (m *DeleteResponse) GetKey() []byte {
-	if m != nil {
-		return m.Key
-	}
-	return nil
+func (m *GetPSInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPSInfoRequest.DiscardUnknown(m)
 }
 
-func (m *DeleteResponse) GetPsversion() uint64 {
-	if m != nil {
-		return m.Psversion
-	}
-	return 0
-}
+var xxx_messageInfo_GetPSInfoRequest proto.InternalMessageInfo
 
-type GetRequest struct {
-	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Psversion uint64 `protobuf:"varint,2,opt,name=psversion,proto3" json:"psversion,omitempty"`
-	Partid    uint64 `protobuf:"varint,5,opt,name=partid,proto3" json:"partid,omitempty"`
+type GetPSInfoResponse struct {
+	Servers []*PSDetail `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
 }
 
-func (m *GetRequest) Reset()         { *m = GetRequest{} }
-func (m *GetRequest) String() string { return proto.CompactTextString(m) }
-func (*GetRequest) ProtoMessage()    {}
-func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{27}
+func (m *GetPSInfoResponse) Reset()         { *m = GetPSInfoResponse{} }
+func (m *GetPSInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPSInfoResponse) ProtoMessage()    {}
+func (*GetPSInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{32}
 }
-func (m *GetRequest) XXX_Unmarshal(b []byte) error {
+func (m *GetPSInfoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetPSInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetPSInfoResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1540,56 +1913,44 @@ func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *GetRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRequest.Merge(m, src)
+func (m *GetPSInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPSInfoResponse.Merge(m, src)
 }
-func (m *GetRequest) XXX_Size() int {
+func (m *GetPSInfoResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+func (m *GetPSInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPSInfoResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+var xxx_messageInfo_GetPSInfoResponse proto.InternalMessageInfo
 
-func (m *GetRequest) GetKey() []byte {
+func (m *GetPSInfoResponse) GetServers() []*PSDetail {
 	if m != nil {
-		return m.Key
+		return m.Servers
 	}
 	return nil
 }
 
-func (m *GetRequest) GetPsversion() uint64 {
-	if m != nil {
-		return m.Psversion
-	}
-	return 0
-}
-
-func (m *GetRequest) GetPartid() uint64 {
-	if m != nil {
-		return m.Partid
-	}
-	return 0
-}
-
-type GetResponse struct {
-	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+type BootstrapRequest struct {
+	LogID  uint64 `protobuf:"varint,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	RowID  uint64 `protobuf:"varint,2,opt,name=rowID,proto3" json:"rowID,omitempty"`
+	Parent uint64 `protobuf:"varint,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	BlobID uint64 `protobuf:"varint,4,opt,name=blobID,proto3" json:"blobID,omitempty"`
 }
 
-func (m *GetResponse) Reset()         { *m = GetResponse{} }
-func (m *GetResponse) String() string { return proto.CompactTextString(m) }
-func (*GetResponse) ProtoMessage()    {}
-func (*GetResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{28}
+func (m *BootstrapRequest) Reset()         { *m = BootstrapRequest{} }
+func (m *BootstrapRequest) String() string { return proto.CompactTextString(m) }
+func (*BootstrapRequest) ProtoMessage()    {}
+func (*BootstrapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{33}
 }
-func (m *GetResponse) XXX_Unmarshal(b []byte) error {
+func (m *BootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BootstrapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BootstrapRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1599,52 +1960,62 @@ func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *GetResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetResponse.Merge(m, src)
+func (m *BootstrapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BootstrapRequest.Merge(m, src)
 }
-func (m *GetResponse) XXX_Size() int {
+func (m *BootstrapRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *GetResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetResponse.DiscardUnknown(m)
+func (m *BootstrapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BootstrapRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetResponse proto.InternalMessageInfo
+var xxx_messageInfo_BootstrapRequest proto.InternalMessageInfo
 
-func (m *GetResponse) GetKey() []byte {
+func (m *BootstrapRequest) GetLogID() uint64 {
 	if m != nil {
-		return m.Key
+		return m.LogID
 	}
-	return nil
+	return 0
 }
 
-func (m *GetResponse) GetValue() []byte {
+func (m *BootstrapRequest) GetRowID() uint64 {
 	if m != nil {
-		return m.Value
+		return m.RowID
 	}
-	return nil
+	return 0
 }
 
-type RequestOp struct {
-	// Types that are valid to be assigned to Request:
-	//	*RequestOp_RequestPut
-	//	*RequestOp_RequestDelete
-	//	*RequestOp_RequestGet
-	Request isRequestOp_Request `protobuf_oneof:"request"`
+func (m *BootstrapRequest) GetParent() uint64 {
+	if m != nil {
+		return m.Parent
+	}
+	return 0
 }
 
-func (m *RequestOp) Reset()         { *m = RequestOp{} }
-func (m *RequestOp) String() string { return proto.CompactTextString(m) }
-func (*RequestOp) ProtoMessage()    {}
-func (*RequestOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{29}
+func (m *BootstrapRequest) GetBlobID() uint64 {
+	if m != nil {
+		return m.BlobID
+	}
+	return 0
 }
-func (m *RequestOp) XXX_Unmarshal(b []byte) error {
+
+type BootstrapResponse struct {
+	PartID uint64 `protobuf:"varint,1,opt,name=partID,proto3" json:"partID,omitempty"`
+}
+
+func (m *BootstrapResponse) Reset()         { *m = BootstrapResponse{} }
+func (m *BootstrapResponse) String() string { return proto.CompactTextString(m) }
+func (*BootstrapResponse) ProtoMessage()    {}
+func (*BootstrapResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{34}
+}
+func (m *BootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RequestOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BootstrapResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RequestOp.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BootstrapResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1654,95 +2025,45 @@ func (m *RequestOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *RequestOp) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RequestOp.Merge(m, src)
+func (m *BootstrapResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BootstrapResponse.Merge(m, src)
 }
-func (m *RequestOp) XXX_Size() int {
+func (m *BootstrapResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *RequestOp) XXX_DiscardUnknown() {
-	xxx_messageInfo_RequestOp.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_RequestOp proto.InternalMessageInfo
-
-type isRequestOp_Request interface {
-	isRequestOp_Request()
-	MarshalTo([]byte) (int, error)
-	Size() int
-}
-
-type RequestOp_RequestPut struct {
-	RequestPut *PutRequest `protobuf:"bytes,1,opt,name=request_put,json=requestPut,proto3,oneof" json:"request_put,omitempty"`
-}
-type RequestOp_RequestDelete struct {
-	RequestDelete *DeleteRequest `protobuf:"bytes,2,opt,name=request_delete,json=requestDelete,proto3,oneof" json:"request_delete,omitempty"`
-}
-type RequestOp_RequestGet struct {
-	RequestGet *GetRequest `protobuf:"bytes,3,opt,name=request_get,json=requestGet,proto3,oneof" json:"request_get,omitempty"`
+func (m *BootstrapResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BootstrapResponse.DiscardUnknown(m)
 }
 
-func (*RequestOp_RequestPut) isRequestOp_Request()    {}
-func (*RequestOp_RequestDelete) isRequestOp_Request() {}
-func (*RequestOp_RequestGet) isRequestOp_Request()    {}
+var xxx_messageInfo_BootstrapResponse proto.InternalMessageInfo
 
-func (m *RequestOp) GetRequest() isRequestOp_Request {
+func (m *BootstrapResponse) GetPartID() uint64 {
 	if m != nil {
-		return m.Request
-	}
-	return nil
-}
-
-func (m *RequestOp) GetRequestPut() *PutRequest {
-	if x, ok := m.GetRequest().(*RequestOp_RequestPut); ok {
-		return x.RequestPut
-	}
-	return nil
-}
-
-func (m *RequestOp) GetRequestDelete() *DeleteRequest {
-	if x, ok := m.GetRequest().(*RequestOp_RequestDelete); ok {
-		return x.RequestDelete
-	}
-	return nil
-}
-
-func (m *RequestOp) GetRequestGet() *GetRequest {
-	if x, ok := m.GetRequest().(*RequestOp_RequestGet); ok {
-		return x.RequestGet
-	}
-	return nil
-}
-
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*RequestOp) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*RequestOp_RequestPut)(nil),
-		(*RequestOp_RequestDelete)(nil),
-		(*RequestOp_RequestGet)(nil),
+		return m.PartID
 	}
+	return 0
 }
 
-type ResponseOp struct {
-	// Types that are valid to be assigned to Response:
-	//	*ResponseOp_ResponsePut
-	//	*ResponseOp_ResponseDelete
-	//	*ResponseOp_ResponseGet
-	Response isResponseOp_Response `protobuf_oneof:"response"`
+type PutRequest struct {
+	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value     []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	ExpiresAt uint64 `protobuf:"varint,3,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"`
+	Psversion uint64 `protobuf:"varint,4,opt,name=psversion,proto3" json:"psversion,omitempty"`
+	Partid    uint64 `protobuf:"varint,5,opt,name=partid,proto3" json:"partid,omitempty"`
 }
 
-func (m *ResponseOp) Reset()         { *m = ResponseOp{} }
-func (m *ResponseOp) String() string { return proto.CompactTextString(m) }
-func (*ResponseOp) ProtoMessage()    {}
-func (*ResponseOp) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{30}
+func (m *PutRequest) Reset()         { *m = PutRequest{} }
+func (m *PutRequest) String() string { return proto.CompactTextString(m) }
+func (*PutRequest) ProtoMessage()    {}
+func (*PutRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{35}
 }
-func (m *ResponseOp) XXX_Unmarshal(b []byte) error {
+func (m *PutRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ResponseOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PutRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ResponseOp.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PutRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1752,91 +2073,69 @@ func (m *ResponseOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *ResponseOp) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResponseOp.Merge(m, src)
+func (m *PutRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutRequest.Merge(m, src)
 }
-func (m *ResponseOp) XXX_Size() int {
+func (m *PutRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ResponseOp) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResponseOp.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_ResponseOp proto.InternalMessageInfo
-
-type isResponseOp_Response interface {
-	isResponseOp_Response()
-	MarshalTo([]byte) (int, error)
-	Size() int
-}
-
-type ResponseOp_ResponsePut struct {
-	ResponsePut *PutResponse `protobuf:"bytes,1,opt,name=response_put,json=responsePut,proto3,oneof" json:"response_put,omitempty"`
-}
-type ResponseOp_ResponseDelete struct {
-	ResponseDelete *DeleteResponse `protobuf:"bytes,2,opt,name=response_delete,json=responseDelete,proto3,oneof" json:"response_delete,omitempty"`
-}
-type ResponseOp_ResponseGet struct {
-	ResponseGet *GetResponse `protobuf:"bytes,3,opt,name=response_get,json=responseGet,proto3,oneof" json:"response_get,omitempty"`
+func (m *PutRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutRequest.DiscardUnknown(m)
 }
 
-func (*ResponseOp_ResponsePut) isResponseOp_Response()    {}
-func (*ResponseOp_ResponseDelete) isResponseOp_Response() {}
-func (*ResponseOp_ResponseGet) isResponseOp_Response()    {}
+var xxx_messageInfo_PutRequest proto.InternalMessageInfo
 
-func (m *ResponseOp) GetResponse() isResponseOp_Response {
+func (m *PutRequest) GetKey() []byte {
 	if m != nil {
-		return m.Response
+		return m.Key
 	}
 	return nil
 }
 
-func (m *ResponseOp) GetResponsePut() *PutResponse {
-	if x, ok := m.GetResponse().(*ResponseOp_ResponsePut); ok {
-		return x.ResponsePut
+func (m *PutRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
 	}
 	return nil
 }
 
-func (m *ResponseOp) GetResponseDelete() *DeleteResponse {
-	if x, ok := m.GetResponse().(*ResponseOp_ResponseDelete); ok {
-		return x.ResponseDelete
+func (m *PutRequest) GetExpiresAt() uint64 {
+	if m != nil {
+		return m.ExpiresAt
 	}
-	return nil
+	return 0
 }
 
-func (m *ResponseOp) GetResponseGet() *GetResponse {
-	if x, ok := m.GetResponse().(*ResponseOp_ResponseGet); ok {
-		return x.ResponseGet
+func (m *PutRequest) GetPsversion() uint64 {
+	if m != nil {
+		return m.Psversion
 	}
-	return nil
+	return 0
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*ResponseOp) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*ResponseOp_ResponsePut)(nil),
-		(*ResponseOp_ResponseDelete)(nil),
-		(*ResponseOp_ResponseGet)(nil),
+func (m *PutRequest) GetPartid() uint64 {
+	if m != nil {
+		return m.Partid
 	}
+	return 0
 }
 
-type BatchRequest struct {
-	Req []*RequestOp `protobuf:"bytes,1,rep,name=req,proto3" json:"req,omitempty"`
+type PutResponse struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (m *BatchRequest) Reset()         { *m = BatchRequest{} }
-func (m *BatchRequest) String() string { return proto.CompactTextString(m) }
-func (*BatchRequest) ProtoMessage()    {}
-func (*BatchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{31}
+func (m *PutResponse) Reset()         { *m = PutResponse{} }
+func (m *PutResponse) String() string { return proto.CompactTextString(m) }
+func (*PutResponse) ProtoMessage()    {}
+func (*PutResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{36}
 }
-func (m *BatchRequest) XXX_Unmarshal(b []byte) error {
+func (m *PutResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *PutResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BatchRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_PutResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1846,41 +2145,43 @@ func (m *BatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *BatchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BatchRequest.Merge(m, src)
+func (m *PutResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutResponse.Merge(m, src)
 }
-func (m *BatchRequest) XXX_Size() int {
+func (m *PutResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *BatchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_BatchRequest.DiscardUnknown(m)
+func (m *PutResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BatchRequest proto.InternalMessageInfo
+var xxx_messageInfo_PutResponse proto.InternalMessageInfo
 
-func (m *BatchRequest) GetReq() []*RequestOp {
+func (m *PutResponse) GetKey() []byte {
 	if m != nil {
-		return m.Req
+		return m.Key
 	}
 	return nil
 }
 
-type BatchResponse struct {
-	Res []*RequestOp `protobuf:"bytes,1,rep,name=res,proto3" json:"res,omitempty"`
+type DeleteRequest struct {
+	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Psversion uint64 `protobuf:"varint,2,opt,name=psversion,proto3" json:"psversion,omitempty"`
+	Partid    uint64 `protobuf:"varint,5,opt,name=partid,proto3" json:"partid,omitempty"`
 }
 
-func (m *BatchResponse) Reset()         { *m = BatchResponse{} }
-func (m *BatchResponse) String() string { return proto.CompactTextString(m) }
-func (*BatchResponse) ProtoMessage()    {}
-func (*BatchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{32}
+func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()    {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{37}
 }
-func (m *BatchResponse) XXX_Unmarshal(b []byte) error {
+func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *BatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_BatchResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1890,46 +2191,56 @@ func (m *BatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *BatchResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BatchResponse.Merge(m, src)
+func (m *DeleteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteRequest.Merge(m, src)
 }
-func (m *BatchResponse) XXX_Size() int {
+func (m *DeleteRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *BatchResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_BatchResponse.DiscardUnknown(m)
+func (m *DeleteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BatchResponse proto.InternalMessageInfo
+var xxx_messageInfo_DeleteRequest proto.InternalMessageInfo
 
-func (m *BatchResponse) GetRes() []*RequestOp {
+func (m *DeleteRequest) GetKey() []byte {
 	if m != nil {
-		return m.Res
+		return m.Key
 	}
 	return nil
 }
 
-//return message KeyValue?
-type RangeRequest struct {
-	Prefix    []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	Start     []byte `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
-	Limit     uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Partid    uint64 `protobuf:"varint,4,opt,name=partid,proto3" json:"partid,omitempty"`
-	Psversion uint64 `protobuf:"varint,5,opt,name=psversion,proto3" json:"psversion,omitempty"`
+func (m *DeleteRequest) GetPsversion() uint64 {
+	if m != nil {
+		return m.Psversion
+	}
+	return 0
 }
 
-func (m *RangeRequest) Reset()         { *m = RangeRequest{} }
-func (m *RangeRequest) String() string { return proto.CompactTextString(m) }
-func (*RangeRequest) ProtoMessage()    {}
-func (*RangeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{33}
+func (m *DeleteRequest) GetPartid() uint64 {
+	if m != nil {
+		return m.Partid
+	}
+	return 0
 }
-func (m *RangeRequest) XXX_Unmarshal(b []byte) error {
+
+type DeleteResponse struct {
+	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Psversion uint64 `protobuf:"varint,2,opt,name=psversion,proto3" json:"psversion,omitempty"`
+}
+
+func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
+func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteResponse) ProtoMessage()    {}
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{38}
+}
+func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DeleteResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RangeRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DeleteResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1939,70 +2250,109 @@ func (m *RangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *RangeRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RangeRequest.Merge(m, src)
+func (m *DeleteResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteResponse.Merge(m, src)
 }
-func (m *RangeRequest) XXX_Size() int {
+func (m *DeleteResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *RangeRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RangeRequest.DiscardUnknown(m)
+func (m *DeleteResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RangeRequest proto.InternalMessageInfo
+var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
 
-func (m *RangeRequest) GetPrefix() []byte {
+func (m *DeleteResponse) GetKey() []byte {
 	if m != nil {
-		return m.Prefix
+		return m.Key
 	}
 	return nil
 }
 
-func (m *RangeRequest) GetStart() []byte {
+func (m *DeleteResponse) GetPsversion() uint64 {
 	if m != nil {
-		return m.Start
+		return m.Psversion
 	}
-	return nil
+	return 0
 }
 
-func (m *RangeRequest) GetLimit() uint32 {
+type GetRequest struct {
+	Key       []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Psversion uint64 `protobuf:"varint,2,opt,name=psversion,proto3" json:"psversion,omitempty"`
+	Partid    uint64 `protobuf:"varint,5,opt,name=partid,proto3" json:"partid,omitempty"`
+}
+
+func (m *GetRequest) Reset()         { *m = GetRequest{} }
+func (m *GetRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRequest) ProtoMessage()    {}
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{39}
+}
+func (m *GetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRequest.Merge(m, src)
+}
+func (m *GetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRequest proto.InternalMessageInfo
+
+func (m *GetRequest) GetKey() []byte {
 	if m != nil {
-		return m.Limit
+		return m.Key
 	}
-	return 0
+	return nil
 }
 
-func (m *RangeRequest) GetPartid() uint64 {
+func (m *GetRequest) GetPsversion() uint64 {
 	if m != nil {
-		return m.Partid
+		return m.Psversion
 	}
 	return 0
 }
 
-func (m *RangeRequest) GetPsversion() uint64 {
+func (m *GetRequest) GetPartid() uint64 {
 	if m != nil {
-		return m.Psversion
+		return m.Partid
 	}
 	return 0
 }
 
-type RangeResponse struct {
-	Truncated uint32   `protobuf:"varint,1,opt,name=truncated,proto3" json:"truncated,omitempty"`
-	Keys      [][]byte `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+type GetResponse struct {
+	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (m *RangeResponse) Reset()         { *m = RangeResponse{} }
-func (m *RangeResponse) String() string { return proto.CompactTextString(m) }
-func (*RangeResponse) ProtoMessage()    {}
-func (*RangeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3e3c719c85d382a4, []int{34}
+func (m *GetResponse) Reset()         { *m = GetResponse{} }
+func (m *GetResponse) String() string { return proto.CompactTextString(m) }
+func (*GetResponse) ProtoMessage()    {}
+func (*GetResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{40}
 }
-func (m *RangeResponse) XXX_Unmarshal(b []byte) error {
+func (m *GetResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *RangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_RangeResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -2012,1324 +2362,1282 @@ func (m *RangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *RangeResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RangeResponse.Merge(m, src)
+func (m *GetResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetResponse.Merge(m, src)
 }
-func (m *RangeResponse) XXX_Size() int {
+func (m *GetResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *RangeResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RangeResponse.DiscardUnknown(m)
+func (m *GetResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RangeResponse proto.InternalMessageInfo
+var xxx_messageInfo_GetResponse proto.InternalMessageInfo
 
-func (m *RangeResponse) GetTruncated() uint32 {
+func (m *GetResponse) GetKey() []byte {
 	if m != nil {
-		return m.Truncated
+		return m.Key
 	}
-	return 0
+	return nil
 }
 
-func (m *RangeResponse) GetKeys() [][]byte {
+func (m *GetResponse) GetValue() []byte {
 	if m != nil {
-		return m.Keys
+		return m.Value
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("pspb.RawBlockType", RawBlockType_name, RawBlockType_value)
-	proto.RegisterType((*MixedLog)(nil), "pspb.MixedLog")
-	proto.RegisterType((*Range)(nil), "pspb.Range")
-	proto.RegisterType((*Location)(nil), "pspb.Location")
-	proto.RegisterType((*BlobStreams)(nil), "pspb.BlobStreams")
-	proto.RegisterType((*TableLocations)(nil), "pspb.TableLocations")
-	proto.RegisterType((*PartitionMeta)(nil), "pspb.PartitionMeta")
-	proto.RegisterType((*PSDetail)(nil), "pspb.PSDetail")
-	proto.RegisterType((*RegionInfo)(nil), "pspb.RegionInfo")
-	proto.RegisterType((*RawBlockMeta)(nil), "pspb.RawBlockMeta")
-	proto.RegisterType((*BlockOffset)(nil), "pspb.BlockOffset")
-	proto.RegisterType((*TableIndex)(nil), "pspb.TableIndex")
-	proto.RegisterType((*GetPartitionMetaRequest)(nil), "pspb.GetPartitionMetaRequest")
-	proto.RegisterType((*GetPartitionMetaResponse)(nil), "pspb.GetPartitionMetaResponse")
-	proto.RegisterType((*SetRowStreamTablesRequest)(nil), "pspb.SetRowStreamTablesRequest")
-	proto.RegisterType((*SetRowStreamTablesResponse)(nil), "pspb.SetRowStreamTablesResponse")
-	proto.RegisterType((*GetRegionsRequest)(nil), "pspb.GetRegionsRequest")
-	proto.RegisterType((*GetRegionsResponse)(nil), "pspb.GetRegionsResponse")
-	proto.RegisterType((*RegisterPSRequest)(nil), "pspb.RegisterPSRequest")
-	proto.RegisterType((*RegisterPSResponse)(nil), "pspb.RegisterPSResponse")
-	proto.RegisterType((*GetPSInfoRequest)(nil), "pspb.GetPSInfoRequest")
-	proto.RegisterType((*GetPSInfoResponse)(nil), "pspb.GetPSInfoResponse")
-	proto.RegisterType((*BootstrapRequest)(nil), "pspb.BootstrapRequest")
-	proto.RegisterType((*BootstrapResponse)(nil), "pspb.BootstrapResponse")
-	proto.RegisterType((*PutRequest)(nil), "pspb.PutRequest")
-	proto.RegisterType((*PutResponse)(nil), "pspb.PutResponse")
-	proto.RegisterType((*DeleteRequest)(nil), "pspb.DeleteRequest")
-	proto.RegisterType((*DeleteResponse)(nil), "pspb.DeleteResponse")
-	proto.RegisterType((*GetRequest)(nil), "pspb.GetRequest")
-	proto.RegisterType((*GetResponse)(nil), "pspb.GetResponse")
-	proto.RegisterType((*RequestOp)(nil), "pspb.RequestOp")
-	proto.RegisterType((*ResponseOp)(nil), "pspb.ResponseOp")
-	proto.RegisterType((*BatchRequest)(nil), "pspb.BatchRequest")
-	proto.RegisterType((*BatchResponse)(nil), "pspb.BatchResponse")
-	proto.RegisterType((*RangeRequest)(nil), "pspb.RangeRequest")
-	proto.RegisterType((*RangeResponse)(nil), "pspb.RangeResponse")
+type RequestOp struct {
+	// Types that are valid to be assigned to Request:
+	//	*RequestOp_RequestPut
+	//	*RequestOp_RequestDelete
+	//	*RequestOp_RequestGet
+	Request isRequestOp_Request `protobuf_oneof:"request"`
 }
 
-func init() { proto.RegisterFile("pspb.proto", fileDescriptor_3e3c719c85d382a4) }
-
-var fileDescriptor_3e3c719c85d382a4 = []byte{
-	// 1426 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xdf, 0xb5, 0xd7, 0x89, 0xfd, 0x1c, 0xbb, 0xce, 0x34, 0x6a, 0x8c, 0x29, 0x6e, 0x3a, 0xaa,
-	0xda, 0xa8, 0x85, 0x48, 0xb8, 0x14, 0x21, 0xfe, 0x14, 0xea, 0xa6, 0xa4, 0x51, 0x5b, 0x12, 0x8d,
-	0x4b, 0x11, 0x17, 0xd0, 0xda, 0x3b, 0x31, 0xab, 0xd8, 0xbb, 0xdb, 0xdd, 0x71, 0x9a, 0x70, 0x47,
-	0xea, 0x91, 0xef, 0xc0, 0xf7, 0xe0, 0x0c, 0x27, 0x7a, 0xe4, 0x84, 0x50, 0xf3, 0x45, 0xd0, 0xfc,
-	0xdb, 0x9d, 0xcd, 0xda, 0x6d, 0x0e, 0xdc, 0xf6, 0xfd, 0x7f, 0x6f, 0xe6, 0xfd, 0xde, 0x9b, 0x05,
-	0x88, 0x92, 0x68, 0xb8, 0x15, 0xc5, 0x21, 0x0b, 0x91, 0xc3, 0xbf, 0x3b, 0x55, 0x4d, 0xe3, 0x6b,
-	0x50, 0x7d, 0xe2, 0x1f, 0x53, 0xef, 0x71, 0x38, 0x46, 0x6d, 0x58, 0x0e, 0x0f, 0x0e, 0x12, 0xca,
-	0x92, 0xb6, 0xbd, 0x51, 0xde, 0x6c, 0x10, 0x4d, 0xe2, 0xcf, 0xa0, 0x42, 0xdc, 0x60, 0x4c, 0x51,
-	0x07, 0xaa, 0x09, 0x73, 0x63, 0xf6, 0x88, 0x9e, 0xb4, 0xed, 0x0d, 0x7b, 0x73, 0x85, 0xa4, 0x34,
-	0xba, 0x04, 0x4b, 0x34, 0xf0, 0xb8, 0xa4, 0x24, 0x24, 0x8a, 0xc2, 0x77, 0xa1, 0xfa, 0x38, 0x1c,
-	0xb9, 0xcc, 0x0f, 0x03, 0x6e, 0x4f, 0x8f, 0x19, 0x0d, 0xd8, 0xee, 0xb6, 0xb0, 0x77, 0x48, 0x4a,
-	0x73, 0x7b, 0x19, 0x4f, 0xd8, 0x37, 0x88, 0xa2, 0xf0, 0x55, 0xa8, 0xf7, 0x27, 0xe1, 0x70, 0xc0,
-	0x62, 0xea, 0x4e, 0x13, 0x84, 0xc0, 0x19, 0x4e, 0xc2, 0xa1, 0x48, 0xd1, 0x21, 0xe2, 0x1b, 0x7f,
-	0x04, 0xcd, 0xa7, 0xee, 0x70, 0x42, 0x75, 0x9c, 0x04, 0x61, 0x70, 0x26, 0xe1, 0x48, 0x16, 0x52,
-	0xef, 0x35, 0xb7, 0xc4, 0x11, 0x68, 0x31, 0x11, 0x32, 0xfc, 0x4b, 0x09, 0x1a, 0xfb, 0x6e, 0xcc,
-	0x7c, 0xce, 0x7b, 0x42, 0x99, 0x8b, 0x6e, 0x40, 0x85, 0xfb, 0x4b, 0x44, 0x6e, 0xf5, 0xde, 0xaa,
-	0x34, 0x33, 0xa2, 0x13, 0x29, 0x47, 0x97, 0xa1, 0x36, 0x09, 0xc7, 0x92, 0x29, 0xd2, 0x75, 0x48,
-	0xc6, 0xe0, 0xd2, 0x38, 0x7c, 0xa1, 0xa4, 0x65, 0x29, 0x4d, 0x19, 0x68, 0x53, 0xa5, 0xe6, 0x88,
-	0x18, 0x6b, 0x32, 0x46, 0x3e, 0x7d, 0x99, 0x20, 0x3f, 0x91, 0xc8, 0x8d, 0x69, 0xc0, 0xda, 0x15,
-	0xe1, 0x44, 0x51, 0xfc, 0xa2, 0x3c, 0x3f, 0x19, 0xb9, 0xb1, 0xd7, 0x5e, 0x12, 0x47, 0xad, 0x49,
-	0xf4, 0x2e, 0x94, 0xe2, 0x71, 0x7b, 0x59, 0x78, 0xae, 0x4b, 0xcf, 0xe2, 0xe2, 0x48, 0x29, 0x1e,
-	0x73, 0x77, 0xbc, 0xdc, 0xdd, 0xed, 0x76, 0x55, 0xba, 0x93, 0x14, 0xfe, 0x04, 0xaa, 0xfb, 0x83,
-	0x6d, 0xca, 0x5c, 0x7f, 0xc2, 0x4f, 0x77, 0x7f, 0x90, 0x5e, 0x8e, 0xf8, 0xe6, 0xe1, 0x5c, 0xcf,
-	0x8b, 0x69, 0x92, 0x88, 0x52, 0x6b, 0x44, 0x93, 0xd8, 0x07, 0x20, 0x74, 0xec, 0x87, 0xc1, 0x6e,
-	0x70, 0x10, 0xaa, 0xe0, 0xf6, 0xdb, 0x82, 0x97, 0xcc, 0xe0, 0x69, 0xc0, 0xb2, 0x11, 0x10, 0x81,
-	0xc3, 0x23, 0x88, 0x13, 0xaa, 0x11, 0xf1, 0x8d, 0xff, 0xb1, 0x61, 0x85, 0xb8, 0x2f, 0xfa, 0x93,
-	0x70, 0x74, 0x28, 0xee, 0xea, 0x3a, 0x38, 0xec, 0x24, 0xa2, 0x22, 0x5e, 0xb3, 0x87, 0x74, 0x3c,
-	0xa9, 0xf1, 0xf4, 0x24, 0xa2, 0x44, 0xc8, 0xd1, 0x75, 0x68, 0xde, 0x0f, 0xa7, 0x11, 0xcf, 0x97,
-	0x7a, 0x03, 0xff, 0x67, 0xaa, 0xda, 0xeb, 0x0c, 0x17, 0xdd, 0x84, 0xd6, 0xb7, 0xc1, 0x19, 0xcd,
-	0xb2, 0xd0, 0x2c, 0xf0, 0x51, 0x17, 0xe0, 0x28, 0x7a, 0xa0, 0x1b, 0xd9, 0x11, 0xa9, 0x1b, 0x1c,
-	0xde, 0xe6, 0x47, 0xd1, 0x9e, 0x6c, 0xe6, 0x8a, 0xf0, 0x91, 0xd2, 0xfc, 0x20, 0x12, 0xfa, 0xfc,
-	0x9b, 0xd9, 0x54, 0xdc, 0x9d, 0x43, 0x14, 0x85, 0x07, 0xa2, 0xcd, 0x47, 0x87, 0x4a, 0xad, 0x05,
-	0xe5, 0xc3, 0x14, 0x64, 0xfc, 0x33, 0x87, 0x9d, 0xd2, 0x42, 0xec, 0x94, 0x73, 0xd8, 0xf9, 0xcd,
-	0x06, 0x10, 0xad, 0xb5, 0x1b, 0x78, 0xf4, 0x18, 0xdd, 0xca, 0x23, 0xdc, 0xec, 0x70, 0x1d, 0x38,
-	0x05, 0x3d, 0xda, 0x80, 0xfa, 0x70, 0x12, 0x86, 0xd3, 0xaf, 0xfd, 0x09, 0xa3, 0xb1, 0x02, 0xb5,
-	0xc9, 0x42, 0xd7, 0xa0, 0x41, 0x13, 0xe6, 0x4f, 0x5d, 0x66, 0x9c, 0x97, 0x43, 0xf2, 0x4c, 0xee,
-	0x27, 0x98, 0x4d, 0xf7, 0x0e, 0x44, 0x10, 0xd9, 0xf6, 0x0d, 0x62, 0xb2, 0xf0, 0x07, 0xb0, 0xbe,
-	0x43, 0x59, 0x0e, 0x8a, 0x84, 0x3e, 0x9f, 0xd1, 0x84, 0xcd, 0xeb, 0x47, 0xec, 0x42, 0xbb, 0xa8,
-	0x9e, 0x44, 0x61, 0x90, 0x50, 0x74, 0x19, 0x9c, 0x51, 0xe8, 0xe9, 0xae, 0xa8, 0x6e, 0x45, 0xc3,
-	0xad, 0xfb, 0xa1, 0x47, 0x89, 0xe0, 0xa2, 0x1b, 0xe0, 0x4c, 0x29, 0x73, 0xdb, 0x25, 0x51, 0xfc,
-	0x45, 0x59, 0x7c, 0xde, 0x91, 0x50, 0xc0, 0x63, 0x78, 0x67, 0x40, 0x19, 0xd1, 0x98, 0x15, 0x47,
-	0x98, 0xe8, 0x9c, 0x36, 0xa0, 0x1e, 0x69, 0x9b, 0x34, 0x35, 0x93, 0x95, 0x42, 0xbc, 0xf4, 0x36,
-	0x88, 0xe3, 0x4f, 0xa1, 0x33, 0x2f, 0xd0, 0x79, 0xaa, 0xc1, 0x17, 0x61, 0x75, 0x87, 0x32, 0x09,
-	0x40, 0x9d, 0x1c, 0xfe, 0x01, 0x90, 0xc9, 0x3c, 0xd7, 0xb1, 0xdc, 0x84, 0xe5, 0x58, 0x1a, 0xa8,
-	0x93, 0x69, 0x29, 0x34, 0xa5, 0xd8, 0x26, 0x5a, 0x01, 0xdf, 0x80, 0x55, 0xce, 0x4e, 0x18, 0x8d,
-	0xf7, 0x07, 0xc6, 0x2d, 0x09, 0xc0, 0xda, 0x06, 0x60, 0xfb, 0x80, 0x4c, 0xc5, 0x73, 0x25, 0xd2,
-	0x84, 0x92, 0xef, 0xa9, 0xe6, 0x2e, 0xf9, 0x1e, 0x46, 0xd0, 0xe2, 0x37, 0x3d, 0x10, 0x29, 0xa8,
-	0x02, 0xbf, 0x10, 0x55, 0x6b, 0x9e, 0x72, 0xbb, 0x09, 0xcb, 0x09, 0x8d, 0x8f, 0x68, 0x7c, 0x66,
-	0xe2, 0xeb, 0xb9, 0x46, 0xb4, 0x18, 0x3f, 0x83, 0x56, 0x3f, 0x0c, 0x59, 0xc2, 0x62, 0x37, 0xd2,
-	0xe9, 0xaf, 0x41, 0x65, 0x12, 0x8e, 0xd3, 0xab, 0x94, 0x04, 0xe7, 0xc6, 0xe1, 0x8b, 0x14, 0x6c,
-	0x92, 0x30, 0x66, 0x72, 0xd9, 0x9c, 0xc9, 0xf8, 0x16, 0xac, 0x1a, 0x7e, 0x55, 0x5a, 0x52, 0x39,
-	0x5b, 0x76, 0x8a, 0xc2, 0x2f, 0x6d, 0x80, 0xfd, 0x19, 0xd3, 0xf1, 0x8b, 0x58, 0x5f, 0x83, 0xca,
-	0x91, 0x3b, 0x99, 0x51, 0x85, 0x3a, 0x49, 0xf0, 0xbd, 0xf2, 0xe0, 0x38, 0xf2, 0x63, 0x9a, 0xdc,
-	0xd3, 0xe1, 0x33, 0x06, 0x97, 0x46, 0x09, 0xaf, 0xd1, 0x0f, 0x03, 0x35, 0x93, 0x32, 0x86, 0x4e,
-	0xc5, 0xf7, 0x8c, 0x5d, 0xc2, 0x7c, 0x0f, 0x5f, 0x81, 0xba, 0xc8, 0x44, 0x65, 0x5c, 0x48, 0x05,
-	0x7f, 0x07, 0x8d, 0x6d, 0x3a, 0xa1, 0x8c, 0x2e, 0xce, 0x36, 0x17, 0xb9, 0x74, 0xde, 0xc8, 0x5f,
-	0x41, 0x53, 0x3b, 0x5e, 0x14, 0xfc, 0xcd, 0x9e, 0xf1, 0x53, 0x00, 0xd1, 0xeb, 0xff, 0x6f, 0x5e,
-	0x77, 0xa0, 0x2e, 0xbc, 0x2e, 0x4c, 0x6a, 0xee, 0xe5, 0xe0, 0xdf, 0x6d, 0xa8, 0xa9, 0x54, 0xf6,
-	0x22, 0x74, 0x1b, 0xea, 0xb1, 0x24, 0x7e, 0x8c, 0x66, 0x4c, 0x2d, 0x45, 0x05, 0xab, 0xec, 0xe6,
-	0x1f, 0x5a, 0x04, 0x94, 0xda, 0xfe, 0x8c, 0xa1, 0xcf, 0xa1, 0xa9, 0x8d, 0x3c, 0x71, 0x32, 0x6a,
-	0x80, 0xa8, 0x41, 0x95, 0xbb, 0x86, 0x87, 0x16, 0x69, 0x28, 0x65, 0xc9, 0x37, 0x43, 0x8e, 0xd5,
-	0x22, 0x48, 0x43, 0x66, 0xc7, 0x64, 0x84, 0xdc, 0xa1, 0xac, 0x5f, 0xe3, 0xd0, 0x97, 0xc0, 0xfa,
-	0xd3, 0xe6, 0xdb, 0x5c, 0x56, 0xbd, 0x17, 0xa1, 0x8f, 0x61, 0x25, 0x56, 0x94, 0x51, 0xc2, 0xaa,
-	0x51, 0x82, 0x14, 0x3e, 0xb4, 0x48, 0x5d, 0x2b, 0xf2, 0x22, 0xbe, 0x84, 0x0b, 0xa9, 0x5d, 0xae,
-	0x8a, 0xb5, 0x7c, 0x15, 0xa9, 0x75, 0x53, 0xab, 0xab, 0x3a, 0xcc, 0xc0, 0x59, 0x21, 0xab, 0x46,
-	0x21, 0xc5, 0xc0, 0xbc, 0x14, 0x80, 0xaa, 0x26, 0xf1, 0x87, 0xb0, 0xd2, 0x77, 0xd9, 0xe8, 0x27,
-	0xdd, 0x1b, 0x57, 0xa1, 0x1c, 0xd3, 0xe7, 0x6a, 0x36, 0x5c, 0xd0, 0xd3, 0x4d, 0x5d, 0x16, 0xe1,
-	0x32, 0xdc, 0x83, 0x86, 0x32, 0x51, 0x17, 0x2f, 0x6c, 0x92, 0x37, 0xd8, 0x24, 0x1c, 0xc7, 0x2b,
-	0xf2, 0x89, 0xa3, 0xe2, 0xf0, 0x9e, 0x8a, 0xe9, 0x81, 0x7f, 0xac, 0xfa, 0x45, 0x51, 0xbc, 0x65,
-	0xc4, 0x3b, 0x59, 0xb7, 0x8c, 0x20, 0xc4, 0xdc, 0xf1, 0xa7, 0xbe, 0x5e, 0xda, 0x92, 0x30, 0xfa,
-	0xd2, 0x31, 0xfb, 0x32, 0xdf, 0xcd, 0x95, 0xb3, 0x58, 0xb8, 0x07, 0x0d, 0x95, 0x49, 0x3a, 0x69,
-	0x6b, 0x2c, 0x9e, 0x05, 0x23, 0xbe, 0x87, 0x45, 0x36, 0x0d, 0x92, 0x31, 0xf8, 0xc4, 0x3e, 0xa4,
-	0x27, 0x72, 0xde, 0xaf, 0x10, 0xf1, 0x7d, 0x13, 0x67, 0x2f, 0x2c, 0xfe, 0x7e, 0x42, 0x55, 0x70,
-	0x3c, 0x97, 0xb9, 0x2d, 0x8b, 0x7f, 0xf1, 0xb5, 0xd8, 0xb2, 0x7b, 0x7f, 0x95, 0x61, 0x3d, 0x5b,
-	0x98, 0x6e, 0xe0, 0x8e, 0x69, 0x3c, 0xa0, 0xf1, 0x91, 0x3f, 0xa2, 0xe8, 0x7b, 0x40, 0xc5, 0x5d,
-	0x86, 0xae, 0xc8, 0x93, 0x5b, 0xb8, 0x4e, 0x3b, 0x1b, 0x8b, 0x15, 0xd4, 0x6d, 0x5a, 0xe8, 0x9e,
-	0x7c, 0x68, 0xca, 0x65, 0x82, 0xd6, 0xb3, 0xf5, 0x94, 0xdb, 0x43, 0x9d, 0x76, 0x51, 0x60, 0xba,
-	0xc8, 0x16, 0xa3, 0x76, 0x51, 0xd8, 0x9f, 0xda, 0x45, 0x71, 0x87, 0x62, 0x0b, 0x0d, 0xe4, 0x3a,
-	0xca, 0xfd, 0x32, 0xbc, 0x97, 0xea, 0xcf, 0x7b, 0xbf, 0x74, 0xba, 0x8b, 0xc4, 0xa9, 0xd3, 0xbb,
-	0x50, 0x4b, 0xf7, 0x19, 0xba, 0x94, 0xa9, 0x9b, 0x4b, 0xaf, 0xb3, 0x5e, 0xe0, 0x9b, 0xf6, 0xe9,
-	0xe2, 0xd1, 0xf6, 0x67, 0x37, 0x9c, 0xb6, 0x2f, 0x6c, 0x28, 0x6c, 0xf5, 0x5e, 0x96, 0xa0, 0x9e,
-	0xe6, 0xf6, 0xe8, 0x19, 0xea, 0x41, 0x45, 0xe0, 0x00, 0xa9, 0x27, 0xb5, 0x89, 0xa3, 0xce, 0xc5,
-	0x1c, 0x2f, 0xcd, 0xe1, 0x7d, 0x28, 0x73, 0xe8, 0x17, 0xe6, 0x5b, 0xa7, 0x38, 0x2e, 0xa4, 0xf6,
-	0x0e, 0x4d, 0xb5, 0xb3, 0xd1, 0xd4, 0x29, 0x62, 0x1c, 0x5b, 0xe8, 0x0e, 0x2c, 0xa9, 0xc1, 0x30,
-	0x6f, 0x0c, 0x76, 0xe6, 0x4e, 0x15, 0x6c, 0xf1, 0x32, 0xe4, 0x2f, 0x2b, 0x32, 0xff, 0x44, 0xf2,
-	0x65, 0xe4, 0x00, 0x83, 0xad, 0x7e, 0xfb, 0x8f, 0xd7, 0x5d, 0xfb, 0xd5, 0xeb, 0xae, 0xfd, 0xef,
-	0xeb, 0xae, 0xfd, 0xeb, 0x69, 0xd7, 0x7a, 0x75, 0xda, 0xb5, 0xfe, 0x3e, 0xed, 0x5a, 0xc3, 0x25,
-	0xf1, 0xb7, 0x7c, 0xfb, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x1c, 0x9d, 0xde, 0x16, 0x4b, 0x0f,
-	0x00, 0x00,
+func (m *RequestOp) Reset()         { *m = RequestOp{} }
+func (m *RequestOp) String() string { return proto.CompactTextString(m) }
+func (*RequestOp) ProtoMessage()    {}
+func (*RequestOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{41}
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// PartitionManagerServiceClient is the client API for PartitionManagerService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PartitionManagerServiceClient interface {
-	SetRowStreamTables(ctx context.Context, in *SetRowStreamTablesRequest, opts ...grpc.CallOption) (*SetRowStreamTablesResponse, error)
-	RegisterPS(ctx context.Context, in *RegisterPSRequest, opts ...grpc.CallOption) (*RegisterPSResponse, error)
-	GetRegions(ctx context.Context, in *GetRegionsRequest, opts ...grpc.CallOption) (*GetRegionsResponse, error)
-	GetPartitionMeta(ctx context.Context, in *GetPartitionMetaRequest, opts ...grpc.CallOption) (*GetPartitionMetaResponse, error)
-	GetPSInfo(ctx context.Context, in *GetPSInfoRequest, opts ...grpc.CallOption) (*GetPSInfoResponse, error)
-	Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error)
+func (m *RequestOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-type partitionManagerServiceClient struct {
-	cc *grpc.ClientConn
+func (m *RequestOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestOp.Merge(m, src)
+}
+func (m *RequestOp) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestOp.DiscardUnknown(m)
 }
 
-func NewPartitionManagerServiceClient(cc *grpc.ClientConn) PartitionManagerServiceClient {
-	return &partitionManagerServiceClient{cc}
+var xxx_messageInfo_RequestOp proto.InternalMessageInfo
+
+type isRequestOp_Request interface {
+	isRequestOp_Request()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func (c *partitionManagerServiceClient) SetRowStreamTables(ctx context.Context, in *SetRowStreamTablesRequest, opts ...grpc.CallOption) (*SetRowStreamTablesResponse, error) {
-	out := new(SetRowStreamTablesResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/SetRowStreamTables", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type RequestOp_RequestPut struct {
+	RequestPut *PutRequest `protobuf:"bytes,1,opt,name=request_put,json=requestPut,proto3,oneof" json:"request_put,omitempty"`
+}
+type RequestOp_RequestDelete struct {
+	RequestDelete *DeleteRequest `protobuf:"bytes,2,opt,name=request_delete,json=requestDelete,proto3,oneof" json:"request_delete,omitempty"`
+}
+type RequestOp_RequestGet struct {
+	RequestGet *GetRequest `protobuf:"bytes,3,opt,name=request_get,json=requestGet,proto3,oneof" json:"request_get,omitempty"`
 }
 
-func (c *partitionManagerServiceClient) RegisterPS(ctx context.Context, in *RegisterPSRequest, opts ...grpc.CallOption) (*RegisterPSResponse, error) {
-	out := new(RegisterPSResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/RegisterPS", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (*RequestOp_RequestPut) isRequestOp_Request()    {}
+func (*RequestOp_RequestDelete) isRequestOp_Request() {}
+func (*RequestOp_RequestGet) isRequestOp_Request()    {}
+
+func (m *RequestOp) GetRequest() isRequestOp_Request {
+	if m != nil {
+		return m.Request
 	}
-	return out, nil
+	return nil
 }
 
-func (c *partitionManagerServiceClient) GetRegions(ctx context.Context, in *GetRegionsRequest, opts ...grpc.CallOption) (*GetRegionsResponse, error) {
-	out := new(GetRegionsResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/GetRegions", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RequestOp) GetRequestPut() *PutRequest {
+	if x, ok := m.GetRequest().(*RequestOp_RequestPut); ok {
+		return x.RequestPut
 	}
-	return out, nil
+	return nil
 }
 
-func (c *partitionManagerServiceClient) GetPartitionMeta(ctx context.Context, in *GetPartitionMetaRequest, opts ...grpc.CallOption) (*GetPartitionMetaResponse, error) {
-	out := new(GetPartitionMetaResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/GetPartitionMeta", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RequestOp) GetRequestDelete() *DeleteRequest {
+	if x, ok := m.GetRequest().(*RequestOp_RequestDelete); ok {
+		return x.RequestDelete
 	}
-	return out, nil
+	return nil
 }
 
-func (c *partitionManagerServiceClient) GetPSInfo(ctx context.Context, in *GetPSInfoRequest, opts ...grpc.CallOption) (*GetPSInfoResponse, error) {
-	out := new(GetPSInfoResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/GetPSInfo", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RequestOp) GetRequestGet() *GetRequest {
+	if x, ok := m.GetRequest().(*RequestOp_RequestGet); ok {
+		return x.RequestGet
 	}
-	return out, nil
+	return nil
 }
 
-func (c *partitionManagerServiceClient) Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error) {
-	out := new(BootstrapResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/Bootstrap", in, out, opts...)
-	if err != nil {
-		return nil, err
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RequestOp) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RequestOp_RequestPut)(nil),
+		(*RequestOp_RequestDelete)(nil),
+		(*RequestOp_RequestGet)(nil),
 	}
-	return out, nil
 }
 
-// PartitionManagerServiceServer is the server API for PartitionManagerService service.
-type PartitionManagerServiceServer interface {
-	SetRowStreamTables(context.Context, *SetRowStreamTablesRequest) (*SetRowStreamTablesResponse, error)
-	RegisterPS(context.Context, *RegisterPSRequest) (*RegisterPSResponse, error)
-	GetRegions(context.Context, *GetRegionsRequest) (*GetRegionsResponse, error)
-	GetPartitionMeta(context.Context, *GetPartitionMetaRequest) (*GetPartitionMetaResponse, error)
-	GetPSInfo(context.Context, *GetPSInfoRequest) (*GetPSInfoResponse, error)
-	Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error)
+type ResponseOp struct {
+	// Types that are valid to be assigned to Response:
+	//	*ResponseOp_ResponsePut
+	//	*ResponseOp_ResponseDelete
+	//	*ResponseOp_ResponseGet
+	Response isResponseOp_Response `protobuf_oneof:"response"`
 }
 
-// UnimplementedPartitionManagerServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedPartitionManagerServiceServer struct {
+func (m *ResponseOp) Reset()         { *m = ResponseOp{} }
+func (m *ResponseOp) String() string { return proto.CompactTextString(m) }
+func (*ResponseOp) ProtoMessage()    {}
+func (*ResponseOp) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{42}
 }
-
-func (*UnimplementedPartitionManagerServiceServer) SetRowStreamTables(ctx context.Context, req *SetRowStreamTablesRequest) (*SetRowStreamTablesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetRowStreamTables not implemented")
+func (m *ResponseOp) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedPartitionManagerServiceServer) RegisterPS(ctx context.Context, req *RegisterPSRequest) (*RegisterPSResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RegisterPS not implemented")
+func (m *ResponseOp) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseOp.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedPartitionManagerServiceServer) GetRegions(ctx context.Context, req *GetRegionsRequest) (*GetRegionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRegions not implemented")
+func (m *ResponseOp) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseOp.Merge(m, src)
 }
-func (*UnimplementedPartitionManagerServiceServer) GetPartitionMeta(ctx context.Context, req *GetPartitionMetaRequest) (*GetPartitionMetaResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPartitionMeta not implemented")
+func (m *ResponseOp) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedPartitionManagerServiceServer) GetPSInfo(ctx context.Context, req *GetPSInfoRequest) (*GetPSInfoResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPSInfo not implemented")
+func (m *ResponseOp) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseOp.DiscardUnknown(m)
 }
-func (*UnimplementedPartitionManagerServiceServer) Bootstrap(ctx context.Context, req *BootstrapRequest) (*BootstrapResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Bootstrap not implemented")
+
+var xxx_messageInfo_ResponseOp proto.InternalMessageInfo
+
+type isResponseOp_Response interface {
+	isResponseOp_Response()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func RegisterPartitionManagerServiceServer(s *grpc.Server, srv PartitionManagerServiceServer) {
-	s.RegisterService(&_PartitionManagerService_serviceDesc, srv)
+type ResponseOp_ResponsePut struct {
+	ResponsePut *PutResponse `protobuf:"bytes,1,opt,name=response_put,json=responsePut,proto3,oneof" json:"response_put,omitempty"`
+}
+type ResponseOp_ResponseDelete struct {
+	ResponseDelete *DeleteResponse `protobuf:"bytes,2,opt,name=response_delete,json=responseDelete,proto3,oneof" json:"response_delete,omitempty"`
+}
+type ResponseOp_ResponseGet struct {
+	ResponseGet *GetResponse `protobuf:"bytes,3,opt,name=response_get,json=responseGet,proto3,oneof" json:"response_get,omitempty"`
 }
 
-func _PartitionManagerService_SetRowStreamTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetRowStreamTablesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionManagerServiceServer).SetRowStreamTables(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionManagerService/SetRowStreamTables",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionManagerServiceServer).SetRowStreamTables(ctx, req.(*SetRowStreamTablesRequest))
+func (*ResponseOp_ResponsePut) isResponseOp_Response()    {}
+func (*ResponseOp_ResponseDelete) isResponseOp_Response() {}
+func (*ResponseOp_ResponseGet) isResponseOp_Response()    {}
+
+func (m *ResponseOp) GetResponse() isResponseOp_Response {
+	if m != nil {
+		return m.Response
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PartitionManagerService_RegisterPS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterPSRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionManagerServiceServer).RegisterPS(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionManagerService/RegisterPS",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionManagerServiceServer).RegisterPS(ctx, req.(*RegisterPSRequest))
+func (m *ResponseOp) GetResponsePut() *PutResponse {
+	if x, ok := m.GetResponse().(*ResponseOp_ResponsePut); ok {
+		return x.ResponsePut
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PartitionManagerService_GetRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRegionsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionManagerServiceServer).GetRegions(ctx, in)
+func (m *ResponseOp) GetResponseDelete() *DeleteResponse {
+	if x, ok := m.GetResponse().(*ResponseOp_ResponseDelete); ok {
+		return x.ResponseDelete
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionManagerService/GetRegions",
+	return nil
+}
+
+func (m *ResponseOp) GetResponseGet() *GetResponse {
+	if x, ok := m.GetResponse().(*ResponseOp_ResponseGet); ok {
+		return x.ResponseGet
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionManagerServiceServer).GetRegions(ctx, req.(*GetRegionsRequest))
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ResponseOp) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ResponseOp_ResponsePut)(nil),
+		(*ResponseOp_ResponseDelete)(nil),
+		(*ResponseOp_ResponseGet)(nil),
 	}
-	return interceptor(ctx, in, info, handler)
 }
 
-func _PartitionManagerService_GetPartitionMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPartitionMetaRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionManagerServiceServer).GetPartitionMeta(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionManagerService/GetPartitionMeta",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionManagerServiceServer).GetPartitionMeta(ctx, req.(*GetPartitionMetaRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type BatchRequest struct {
+	Req []*RequestOp `protobuf:"bytes,1,rep,name=req,proto3" json:"req,omitempty"`
 }
 
-func _PartitionManagerService_GetPSInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetPSInfoRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionManagerServiceServer).GetPSInfo(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionManagerService/GetPSInfo",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionManagerServiceServer).GetPSInfo(ctx, req.(*GetPSInfoRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *BatchRequest) Reset()         { *m = BatchRequest{} }
+func (m *BatchRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchRequest) ProtoMessage()    {}
+func (*BatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{43}
 }
-
-func _PartitionManagerService_Bootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BootstrapRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionManagerServiceServer).Bootstrap(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionManagerService/Bootstrap",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionManagerServiceServer).Bootstrap(ctx, req.(*BootstrapRequest))
+func (m *BatchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
 }
-
-var _PartitionManagerService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pspb.PartitionManagerService",
-	HandlerType: (*PartitionManagerServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "SetRowStreamTables",
-			Handler:    _PartitionManagerService_SetRowStreamTables_Handler,
-		},
-		{
-			MethodName: "RegisterPS",
-			Handler:    _PartitionManagerService_RegisterPS_Handler,
-		},
-		{
-			MethodName: "GetRegions",
-			Handler:    _PartitionManagerService_GetRegions_Handler,
-		},
-		{
-			MethodName: "GetPartitionMeta",
-			Handler:    _PartitionManagerService_GetPartitionMeta_Handler,
-		},
-		{
-			MethodName: "GetPSInfo",
-			Handler:    _PartitionManagerService_GetPSInfo_Handler,
-		},
-		{
-			MethodName: "Bootstrap",
-			Handler:    _PartitionManagerService_Bootstrap_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pspb.proto",
+func (m *BatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchRequest.Merge(m, src)
 }
-
-// PartitionKVClient is the client API for PartitionKV service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PartitionKVClient interface {
-	//
-	//option (google.api.http) = {
-	//post: "/v3/kv/txn"
-	//body: "*"
-	//};
-	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
-	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
-	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
-	Range(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (*RangeResponse, error)
+func (m *BatchRequest) XXX_Size() int {
+	return m.Size()
 }
-
-type partitionKVClient struct {
-	cc *grpc.ClientConn
+func (m *BatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchRequest.DiscardUnknown(m)
 }
 
-func NewPartitionKVClient(cc *grpc.ClientConn) PartitionKVClient {
-	return &partitionKVClient{cc}
-}
+var xxx_messageInfo_BatchRequest proto.InternalMessageInfo
 
-func (c *partitionKVClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
-	out := new(BatchResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Batch", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchRequest) GetReq() []*RequestOp {
+	if m != nil {
+		return m.Req
 	}
-	return out, nil
+	return nil
 }
 
-func (c *partitionKVClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
-	out := new(PutResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Put", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type BatchResponse struct {
+	Res []*RequestOp `protobuf:"bytes,1,rep,name=res,proto3" json:"res,omitempty"`
 }
 
-func (c *partitionKVClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
-	out := new(GetResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Get", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *BatchResponse) Reset()         { *m = BatchResponse{} }
+func (m *BatchResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchResponse) ProtoMessage()    {}
+func (*BatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{44}
 }
-
-func (c *partitionKVClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Delete", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *BatchResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *BatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchResponse.Merge(m, src)
+}
+func (m *BatchResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchResponse.DiscardUnknown(m)
 }
 
-func (c *partitionKVClient) Range(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (*RangeResponse, error) {
-	out := new(RangeResponse)
-	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Range", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_BatchResponse proto.InternalMessageInfo
+
+func (m *BatchResponse) GetRes() []*RequestOp {
+	if m != nil {
+		return m.Res
 	}
-	return out, nil
+	return nil
 }
 
-// PartitionKVServer is the server API for PartitionKV service.
-type PartitionKVServer interface {
-	//
-	//option (google.api.http) = {
-	//post: "/v3/kv/txn"
-	//body: "*"
-	//};
-	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
-	Put(context.Context, *PutRequest) (*PutResponse, error)
-	Get(context.Context, *GetRequest) (*GetResponse, error)
-	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
-	Range(context.Context, *RangeRequest) (*RangeResponse, error)
+//return message KeyValue?
+type RangeRequest struct {
+	Prefix    []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Start     []byte `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Limit     uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Partid    uint64 `protobuf:"varint,4,opt,name=partid,proto3" json:"partid,omitempty"`
+	Psversion uint64 `protobuf:"varint,5,opt,name=psversion,proto3" json:"psversion,omitempty"`
+	//return values of keys
+	WithValues bool `protobuf:"varint,6,opt,name=withValues,proto3" json:"withValues,omitempty"`
 }
 
-// UnimplementedPartitionKVServer can be embedded to have forward compatible implementations.
-type UnimplementedPartitionKVServer struct {
+func (m *RangeRequest) Reset()         { *m = RangeRequest{} }
+func (m *RangeRequest) String() string { return proto.CompactTextString(m) }
+func (*RangeRequest) ProtoMessage()    {}
+func (*RangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{45}
 }
-
-func (*UnimplementedPartitionKVServer) Batch(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Batch not implemented")
+func (m *RangeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedPartitionKVServer) Put(ctx context.Context, req *PutRequest) (*PutResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
+func (m *RangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RangeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedPartitionKVServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+func (m *RangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeRequest.Merge(m, src)
 }
-func (*UnimplementedPartitionKVServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+func (m *RangeRequest) XXX_Size() int {
+	return m.Size()
 }
-func (*UnimplementedPartitionKVServer) Range(ctx context.Context, req *RangeRequest) (*RangeResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Range not implemented")
+func (m *RangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeRequest.DiscardUnknown(m)
 }
 
-func RegisterPartitionKVServer(s *grpc.Server, srv PartitionKVServer) {
-	s.RegisterService(&_PartitionKV_serviceDesc, srv)
-}
+var xxx_messageInfo_RangeRequest proto.InternalMessageInfo
 
-func _PartitionKV_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BatchRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionKVServer).Batch(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionKV/Batch",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionKVServer).Batch(ctx, req.(*BatchRequest))
+func (m *RangeRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PartitionKV_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PutRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionKVServer).Put(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionKV/Put",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionKVServer).Put(ctx, req.(*PutRequest))
+func (m *RangeRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _PartitionKV_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionKVServer).Get(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionKV/Get",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionKVServer).Get(ctx, req.(*GetRequest))
+func (m *RangeRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _PartitionKV_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionKVServer).Delete(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionKV/Delete",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionKVServer).Delete(ctx, req.(*DeleteRequest))
+func (m *RangeRequest) GetPartid() uint64 {
+	if m != nil {
+		return m.Partid
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _PartitionKV_Range_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RangeRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PartitionKVServer).Range(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pspb.PartitionKV/Range",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PartitionKVServer).Range(ctx, req.(*RangeRequest))
+func (m *RangeRequest) GetPsversion() uint64 {
+	if m != nil {
+		return m.Psversion
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _PartitionKV_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pspb.PartitionKV",
-	HandlerType: (*PartitionKVServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Batch",
-			Handler:    _PartitionKV_Batch_Handler,
-		},
-		{
-			MethodName: "Put",
-			Handler:    _PartitionKV_Put_Handler,
-		},
-		{
-			MethodName: "Get",
-			Handler:    _PartitionKV_Get_Handler,
-		},
-		{
-			MethodName: "Delete",
-			Handler:    _PartitionKV_Delete_Handler,
-		},
-		{
-			MethodName: "Range",
-			Handler:    _PartitionKV_Range_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pspb.proto",
+	return 0
 }
 
-func (m *MixedLog) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RangeRequest) GetWithValues() bool {
+	if m != nil {
+		return m.WithValues
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *MixedLog) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RangeResponse struct {
+	Truncated uint32   `protobuf:"varint,1,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Keys      [][]byte `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	//values[i] is the value of keys[i] if withValues is set
+	Values [][]byte `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
 }
 
-func (m *MixedLog) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Offsets) > 0 {
-		dAtA2 := make([]byte, len(m.Offsets)*10)
-		var j1 int
-		for _, num := range m.Offsets {
-			for num >= 1<<7 {
-				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j1++
-			}
-			dAtA2[j1] = uint8(num)
-			j1++
+func (m *RangeResponse) Reset()         { *m = RangeResponse{} }
+func (m *RangeResponse) String() string { return proto.CompactTextString(m) }
+func (*RangeResponse) ProtoMessage()    {}
+func (*RangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e3c719c85d382a4, []int{46}
+}
+func (m *RangeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RangeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i -= j1
-		copy(dAtA[i:], dAtA2[:j1])
-		i = encodeVarintPspb(dAtA, i, uint64(j1))
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-
-func (m *Range) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *RangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeResponse.Merge(m, src)
 }
-
-func (m *Range) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RangeResponse) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *Range) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.EndKey) > 0 {
-		i -= len(m.EndKey)
-		copy(dAtA[i:], m.EndKey)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.EndKey)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.StartKey) > 0 {
-		i -= len(m.StartKey)
-		copy(dAtA[i:], m.StartKey)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.StartKey)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *RangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeResponse.DiscardUnknown(m)
 }
 
-func (m *Location) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RangeResponse proto.InternalMessageInfo
+
+func (m *RangeResponse) GetTruncated() uint32 {
+	if m != nil {
+		return m.Truncated
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Location) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RangeResponse) GetKeys() [][]byte {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
 }
 
-func (m *Location) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Offset != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Offset))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.ExtentID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.ExtentID))
-		i--
-		dAtA[i] = 0x8
+func (m *RangeResponse) GetValues() [][]byte {
+	if m != nil {
+		return m.Values
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *BlobStreams) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func init() {
+	proto.RegisterEnum("pspb.CompressionType", CompressionType_name, CompressionType_value)
+	proto.RegisterEnum("pspb.RawBlockType", RawBlockType_name, RawBlockType_value)
+	proto.RegisterType((*MixedLog)(nil), "pspb.MixedLog")
+	proto.RegisterType((*Range)(nil), "pspb.Range")
+	proto.RegisterType((*Location)(nil), "pspb.Location")
+	proto.RegisterType((*BlobStreams)(nil), "pspb.BlobStreams")
+	proto.RegisterType((*DiscardStats)(nil), "pspb.DiscardStats")
+	proto.RegisterMapType((map[uint64]int64)(nil), "pspb.DiscardStats.DiscardEntry")
+	proto.RegisterType((*TableLocations)(nil), "pspb.TableLocations")
+	proto.RegisterType((*DataKey)(nil), "pspb.DataKey")
+	proto.RegisterType((*DataKeys)(nil), "pspb.DataKeys")
+	proto.RegisterType((*PartitionOptions)(nil), "pspb.PartitionOptions")
+	proto.RegisterType((*PartitionMeta)(nil), "pspb.PartitionMeta")
+	proto.RegisterType((*PSDetail)(nil), "pspb.PSDetail")
+	proto.RegisterType((*RegionInfo)(nil), "pspb.RegionInfo")
+	proto.RegisterType((*RawBlockMeta)(nil), "pspb.RawBlockMeta")
+	proto.RegisterType((*BlockOffset)(nil), "pspb.BlockOffset")
+	proto.RegisterType((*TableIndex)(nil), "pspb.TableIndex")
+	proto.RegisterType((*GetPartitionMetaRequest)(nil), "pspb.GetPartitionMetaRequest")
+	proto.RegisterType((*GetPartitionMetaResponse)(nil), "pspb.GetPartitionMetaResponse")
+	proto.RegisterType((*SetRowStreamTablesRequest)(nil), "pspb.SetRowStreamTablesRequest")
+	proto.RegisterType((*SetRowStreamTablesResponse)(nil), "pspb.SetRowStreamTablesResponse")
+	proto.RegisterType((*SetDataKeysRequest)(nil), "pspb.SetDataKeysRequest")
+	proto.RegisterType((*SetDataKeysResponse)(nil), "pspb.SetDataKeysResponse")
+	proto.RegisterType((*SetDiscardStatsRequest)(nil), "pspb.SetDiscardStatsRequest")
+	proto.RegisterType((*SetDiscardStatsResponse)(nil), "pspb.SetDiscardStatsResponse")
+	proto.RegisterType((*SetBlobStreamsRequest)(nil), "pspb.SetBlobStreamsRequest")
+	proto.RegisterType((*SetBlobStreamsResponse)(nil), "pspb.SetBlobStreamsResponse")
+	proto.RegisterType((*SetPartitionOptionsRequest)(nil), "pspb.SetPartitionOptionsRequest")
+	proto.RegisterType((*SetPartitionOptionsResponse)(nil), "pspb.SetPartitionOptionsResponse")
+	proto.RegisterType((*GetRegionsRequest)(nil), "pspb.GetRegionsRequest")
+	proto.RegisterType((*GetRegionsResponse)(nil), "pspb.GetRegionsResponse")
+	proto.RegisterType((*RegisterPSRequest)(nil), "pspb.RegisterPSRequest")
+	proto.RegisterType((*RegisterPSResponse)(nil), "pspb.RegisterPSResponse")
+	proto.RegisterType((*GetPSInfoRequest)(nil), "pspb.GetPSInfoRequest")
+	proto.RegisterType((*GetPSInfoResponse)(nil), "pspb.GetPSInfoResponse")
+	proto.RegisterType((*BootstrapRequest)(nil), "pspb.BootstrapRequest")
+	proto.RegisterType((*BootstrapResponse)(nil), "pspb.BootstrapResponse")
+	proto.RegisterType((*PutRequest)(nil), "pspb.PutRequest")
+	proto.RegisterType((*PutResponse)(nil), "pspb.PutResponse")
+	proto.RegisterType((*DeleteRequest)(nil), "pspb.DeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "pspb.DeleteResponse")
+	proto.RegisterType((*GetRequest)(nil), "pspb.GetRequest")
+	proto.RegisterType((*GetResponse)(nil), "pspb.GetResponse")
+	proto.RegisterType((*RequestOp)(nil), "pspb.RequestOp")
+	proto.RegisterType((*ResponseOp)(nil), "pspb.ResponseOp")
+	proto.RegisterType((*BatchRequest)(nil), "pspb.BatchRequest")
+	proto.RegisterType((*BatchResponse)(nil), "pspb.BatchResponse")
+	proto.RegisterType((*RangeRequest)(nil), "pspb.RangeRequest")
+	proto.RegisterType((*RangeResponse)(nil), "pspb.RangeResponse")
+}
+
+func init() { proto.RegisterFile("pspb.proto", fileDescriptor_3e3c719c85d382a4) }
+
+var fileDescriptor_3e3c719c85d382a4 = []byte{
+	// 2061 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x6f, 0x1b, 0xc7,
+	0x15, 0xe7, 0x2e, 0x97, 0x14, 0xf9, 0xf8, 0x61, 0x6a, 0xec, 0x48, 0x0c, 0xed, 0x28, 0xf2, 0x20,
+	0x88, 0x05, 0xa5, 0x31, 0x50, 0xb9, 0x49, 0x5c, 0xa7, 0x4d, 0x6b, 0x49, 0xae, 0x2c, 0xd8, 0x8e,
+	0x84, 0xa1, 0x9b, 0x22, 0x45, 0xd1, 0x62, 0xc5, 0x1d, 0x51, 0x5b, 0x2d, 0x77, 0xd7, 0xbb, 0xa3,
+	0x0f, 0xf6, 0x5e, 0x20, 0xc7, 0x1e, 0xfa, 0x27, 0xf4, 0xd0, 0x6b, 0xff, 0x81, 0x9e, 0xdb, 0x5b,
+	0x80, 0xa2, 0x40, 0x8f, 0x85, 0xfd, 0x8f, 0x14, 0xf3, 0xb5, 0x3b, 0xcb, 0x25, 0x1d, 0x16, 0xc8,
+	0x6d, 0xdf, 0xc7, 0xbc, 0xf7, 0x7b, 0x33, 0x6f, 0xde, 0x7b, 0xb3, 0x00, 0x71, 0x1a, 0x9f, 0xdc,
+	0x8f, 0x93, 0x88, 0x45, 0xc8, 0xe1, 0xdf, 0x83, 0x86, 0xa6, 0xf1, 0x1f, 0x2d, 0x68, 0xbc, 0xf0,
+	0xaf, 0xa9, 0xf7, 0x3c, 0x1a, 0xa3, 0x3e, 0xac, 0x44, 0xa7, 0xa7, 0x29, 0x65, 0x69, 0xdf, 0xda,
+	0xac, 0x6e, 0x75, 0x88, 0x26, 0xd1, 0x67, 0xd0, 0x1a, 0x45, 0x93, 0x38, 0xa1, 0x69, 0xea, 0x47,
+	0x61, 0xdf, 0xde, 0xb4, 0xb6, 0xba, 0x3b, 0xef, 0xdc, 0x17, 0x86, 0xf7, 0x72, 0xc1, 0xcb, 0x69,
+	0x4c, 0x89, 0xa9, 0x89, 0x3e, 0x84, 0xae, 0x26, 0xa9, 0x37, 0xf4, 0xff, 0x40, 0xfb, 0xd5, 0x4d,
+	0x6b, 0xab, 0x43, 0x66, 0xb8, 0xf8, 0x73, 0xa8, 0x11, 0x37, 0x1c, 0x53, 0x34, 0x80, 0x46, 0xca,
+	0xdc, 0x84, 0x3d, 0xa3, 0xd3, 0xbe, 0xb5, 0x69, 0x6d, 0xb5, 0x49, 0x46, 0xa3, 0x35, 0xa8, 0xd3,
+	0xd0, 0xe3, 0x12, 0x5b, 0x48, 0x14, 0x85, 0xbf, 0x80, 0xc6, 0xf3, 0x68, 0xe4, 0x32, 0xee, 0x70,
+	0x00, 0x0d, 0x7a, 0xcd, 0x68, 0xc8, 0x0e, 0xf7, 0xc5, 0x7a, 0x87, 0x64, 0x34, 0x5f, 0x2f, 0x03,
+	0x12, 0xeb, 0x3b, 0x44, 0x51, 0xf8, 0x2e, 0xb4, 0x76, 0x83, 0xe8, 0x64, 0xc8, 0x12, 0xea, 0x4e,
+	0x52, 0x84, 0xc0, 0x39, 0x09, 0xa2, 0x13, 0xb1, 0x07, 0x0e, 0x11, 0xdf, 0x7c, 0x9f, 0xda, 0xfb,
+	0x7e, 0x3a, 0x72, 0x13, 0x6f, 0xc8, 0x5c, 0x96, 0xa2, 0x1f, 0xc3, 0x8a, 0x27, 0x69, 0xa1, 0xd7,
+	0xda, 0x79, 0x5f, 0xee, 0x86, 0xa9, 0xa4, 0x89, 0x27, 0x21, 0x4b, 0xa6, 0x44, 0xeb, 0x0f, 0x1e,
+	0x41, 0xdb, 0x14, 0xa0, 0x1e, 0x54, 0xcf, 0x55, 0xb4, 0x0e, 0xe1, 0x9f, 0xe8, 0x16, 0xd4, 0x2e,
+	0xdd, 0xe0, 0x82, 0x0a, 0x9c, 0x55, 0x22, 0x89, 0x47, 0xf6, 0x43, 0x0b, 0xff, 0x08, 0xba, 0x2f,
+	0xdd, 0x93, 0x80, 0xea, 0x78, 0x53, 0x84, 0xc1, 0x09, 0xa2, 0x51, 0xaa, 0x50, 0x74, 0x25, 0x0a,
+	0x2d, 0x26, 0x42, 0x86, 0xa7, 0xb0, 0xb2, 0xef, 0x32, 0xf7, 0x99, 0x34, 0x7d, 0x4e, 0xa7, 0xd9,
+	0xe6, 0x48, 0x02, 0x6d, 0x42, 0x6b, 0xe2, 0xa6, 0x8c, 0x26, 0xcf, 0x84, 0x4c, 0x6e, 0x8f, 0xc9,
+	0xe2, 0xb9, 0x71, 0x95, 0xb8, 0x71, 0x4c, 0x3d, 0x71, 0x82, 0x6d, 0xa2, 0x49, 0x74, 0x07, 0x9a,
+	0xa3, 0x84, 0xba, 0x8c, 0x7a, 0x8f, 0x59, 0xdf, 0x11, 0x80, 0x73, 0x06, 0xfe, 0x18, 0x1a, 0xca,
+	0x75, 0x8a, 0xee, 0x82, 0x73, 0x4e, 0xa7, 0x1a, 0x6a, 0x47, 0x6d, 0x98, 0x94, 0x12, 0x21, 0xc2,
+	0x7f, 0xb1, 0xa1, 0x77, 0xec, 0x26, 0xcc, 0xe7, 0xe8, 0x8f, 0x62, 0x1d, 0x62, 0x7b, 0x42, 0x27,
+	0x8c, 0xc7, 0x2d, 0x52, 0x48, 0x42, 0x2f, 0xf0, 0xd0, 0x16, 0xdc, 0xb8, 0x4a, 0x7c, 0x46, 0xf7,
+	0xce, 0xf6, 0xdc, 0xd8, 0x1d, 0xf9, 0x6c, 0xaa, 0xa2, 0x98, 0x65, 0xa3, 0x0f, 0xa0, 0x33, 0x71,
+	0xaf, 0x09, 0x8d, 0x03, 0x77, 0x9a, 0x65, 0xa4, 0x43, 0x8a, 0x4c, 0xae, 0x75, 0x1a, 0x5c, 0xa4,
+	0x67, 0x87, 0x21, 0xa3, 0xc9, 0xa5, 0x1b, 0xa8, 0xc8, 0x8a, 0x4c, 0x9e, 0xde, 0x02, 0xc2, 0x6e,
+	0x10, 0x8d, 0xce, 0x85, 0xb1, 0x9a, 0x4c, 0xef, 0x22, 0x97, 0xeb, 0x89, 0x33, 0x7c, 0x79, 0x96,
+	0xd0, 0xf4, 0x2c, 0x0a, 0xbc, 0x7e, 0x5d, 0xea, 0x15, 0xb9, 0x5c, 0x6f, 0xc2, 0x6f, 0x63, 0x6e,
+	0x6f, 0x45, 0xea, 0x15, 0xb9, 0xf8, 0xdf, 0x36, 0x74, 0xb2, 0x6d, 0x7a, 0x41, 0x99, 0x8b, 0xee,
+	0x41, 0x8d, 0x27, 0x6a, 0x2a, 0x36, 0xa7, 0xb5, 0xb3, 0x2a, 0x37, 0xd7, 0x48, 0x6b, 0x22, 0xe5,
+	0xfc, 0xb8, 0x82, 0x68, 0x2c, 0x99, 0x62, 0x8b, 0x1c, 0x92, 0x33, 0xb8, 0x34, 0x89, 0xae, 0x94,
+	0x54, 0x6e, 0x4c, 0xce, 0x40, 0x5b, 0x2a, 0xd7, 0x1c, 0xe1, 0xe3, 0x96, 0xf4, 0x51, 0xcc, 0x47,
+	0x99, 0x71, 0xfc, 0xaa, 0xc5, 0x6e, 0x42, 0x43, 0x26, 0x36, 0xc4, 0x21, 0x8a, 0xe2, 0x69, 0xa4,
+	0xaf, 0x4d, 0x5d, 0xa6, 0x91, 0x22, 0xd1, 0x6d, 0xb0, 0x93, 0xb1, 0x08, 0xb7, 0xb5, 0xd3, 0x92,
+	0x96, 0x45, 0x45, 0x20, 0x76, 0x32, 0xe6, 0xe6, 0x78, 0xb8, 0x87, 0xfb, 0xfd, 0x86, 0x34, 0x27,
+	0x29, 0x9e, 0xfc, 0x22, 0xa3, 0x9a, 0x9b, 0x56, 0x9e, 0xfc, 0x3a, 0xdf, 0x64, 0x4a, 0xa1, 0x6d,
+	0x70, 0xa2, 0x98, 0xa5, 0x7d, 0x10, 0x3a, 0x6b, 0x52, 0x67, 0x36, 0xc7, 0x88, 0xd0, 0xc1, 0x0f,
+	0xa1, 0x71, 0x3c, 0xdc, 0xa7, 0xcc, 0xf5, 0x03, 0x5e, 0x06, 0x8e, 0x87, 0xd9, 0x45, 0x11, 0xdf,
+	0x1c, 0xbe, 0xeb, 0x79, 0x09, 0x4d, 0x53, 0xb1, 0x75, 0x4d, 0xa2, 0x49, 0xec, 0x03, 0x10, 0x3a,
+	0xf6, 0xa3, 0xf0, 0x30, 0x3c, 0x8d, 0x54, 0x30, 0xd6, 0x77, 0x05, 0x63, 0x17, 0x82, 0xd1, 0x0e,
+	0xab, 0x86, 0x43, 0x04, 0x0e, 0xf7, 0x20, 0x76, 0xbc, 0x49, 0xc4, 0x37, 0xfe, 0x97, 0x0d, 0x6d,
+	0xe2, 0x5e, 0x89, 0x6c, 0x10, 0x67, 0xff, 0x21, 0x38, 0x6c, 0x1a, 0xcb, 0x7b, 0xd1, 0xdd, 0x41,
+	0xda, 0x9f, 0xd4, 0x10, 0x35, 0x59, 0xc8, 0x79, 0x76, 0xed, 0x15, 0x8b, 0xb1, 0xbc, 0x22, 0x33,
+	0x5c, 0xb4, 0x0d, 0xbd, 0x5f, 0x86, 0x7b, 0xf3, 0xca, 0x76, 0x89, 0x8f, 0x36, 0x00, 0x2e, 0xe3,
+	0x27, 0xba, 0xe2, 0x3a, 0x02, 0xba, 0xc1, 0xe1, 0xf5, 0xf8, 0x32, 0x3e, 0x92, 0x55, 0x57, 0xde,
+	0x8d, 0x8c, 0xe6, 0x1b, 0x91, 0xd2, 0x57, 0x5f, 0x5e, 0x4c, 0x44, 0x2e, 0x38, 0x44, 0x51, 0xb3,
+	0xdd, 0x66, 0x65, 0xe9, 0x6e, 0x93, 0x15, 0xb7, 0x86, 0x59, 0xdc, 0x3e, 0x80, 0x0e, 0x0d, 0x47,
+	0xc9, 0x34, 0x66, 0x2a, 0x96, 0xa6, 0xc0, 0x51, 0x64, 0xe2, 0xa1, 0x68, 0x02, 0xa3, 0x73, 0x85,
+	0xcd, 0x28, 0xca, 0x6d, 0x59, 0x94, 0xcd, 0xce, 0x62, 0x2f, 0xec, 0x2c, 0xd5, 0x42, 0x67, 0xf9,
+	0x9b, 0x0d, 0x20, 0xee, 0xc7, 0x61, 0xe8, 0xd1, 0x6b, 0xf4, 0x51, 0xb1, 0xc1, 0x9a, 0xd7, 0x54,
+	0x3b, 0xce, 0x7b, 0xee, 0x26, 0xb4, 0x4e, 0x82, 0x28, 0x9a, 0xfc, 0xc2, 0x0f, 0x18, 0x4d, 0x54,
+	0xcb, 0x33, 0x59, 0x22, 0xb0, 0x94, 0xf9, 0x13, 0x97, 0x19, 0x87, 0xe4, 0x90, 0x22, 0x93, 0xdb,
+	0x09, 0x2f, 0x26, 0x47, 0xa7, 0xc2, 0x89, 0xbc, 0xbb, 0x1d, 0x62, 0xb2, 0xd0, 0x36, 0x34, 0x7c,
+	0x8e, 0xef, 0x79, 0x34, 0xea, 0xd7, 0xcc, 0x9b, 0x94, 0xb5, 0x91, 0x4c, 0xce, 0x75, 0x05, 0x04,
+	0xae, 0x5b, 0x9f, 0xaf, 0xab, 0xe5, 0xa2, 0x97, 0x4f, 0xdc, 0x20, 0xa0, 0x29, 0xeb, 0xaf, 0xa8,
+	0x5e, 0xae, 0x68, 0x7e, 0x93, 0x4e, 0xfc, 0xf1, 0x98, 0x8b, 0x1a, 0xb2, 0x10, 0x28, 0x12, 0x7f,
+	0x0c, 0xeb, 0x07, 0x94, 0x15, 0xaa, 0x1b, 0xa1, 0xaf, 0x2e, 0xf8, 0xa2, 0x39, 0x57, 0x12, 0xbb,
+	0xd0, 0x2f, 0xab, 0xa7, 0x71, 0x14, 0xa6, 0x14, 0xdd, 0x01, 0x67, 0x14, 0x79, 0xfa, 0x62, 0x34,
+	0xee, 0x8b, 0xfc, 0xf1, 0x28, 0x11, 0x5c, 0x74, 0x0f, 0x9c, 0x09, 0x65, 0x6e, 0xdf, 0x16, 0x47,
+	0x71, 0x73, 0xa6, 0x30, 0x08, 0x43, 0x42, 0x01, 0x8f, 0xe1, 0xdd, 0x21, 0x65, 0x44, 0x97, 0x41,
+	0x71, 0xa0, 0xa9, 0xc6, 0xb4, 0x09, 0xad, 0x58, 0xaf, 0xc9, 0xa0, 0x99, 0xac, 0xac, 0x6a, 0xda,
+	0xdf, 0x55, 0x35, 0xf1, 0x23, 0x18, 0xcc, 0x73, 0xb4, 0x4c, 0x34, 0xf8, 0xd7, 0x80, 0x86, 0x94,
+	0x65, 0xb5, 0x6f, 0x69, 0x74, 0xba, 0x84, 0xda, 0x8b, 0x4b, 0x28, 0x7e, 0x00, 0x37, 0x0b, 0xb6,
+	0x97, 0x02, 0xe4, 0xc1, 0x1a, 0x5f, 0x64, 0xcc, 0x43, 0xff, 0xcf, 0x96, 0xd5, 0x52, 0xbe, 0x42,
+	0xa1, 0x42, 0xe5, 0xd9, 0x8a, 0x48, 0x05, 0xfc, 0x19, 0xac, 0x97, 0xbc, 0x2c, 0x05, 0xef, 0x04,
+	0xde, 0x19, 0x52, 0x66, 0x36, 0xc8, 0xa5, 0xd1, 0x65, 0xbd, 0xd6, 0x7e, 0x7b, 0xaf, 0xc5, 0x9f,
+	0x8a, 0x2d, 0x28, 0xf8, 0x58, 0x0a, 0xdb, 0xef, 0x45, 0x1e, 0x94, 0x7a, 0xd4, 0xd2, 0x00, 0x75,
+	0xcb, 0xb3, 0x97, 0x68, 0x79, 0x9f, 0xc3, 0xed, 0xb9, 0xbe, 0x96, 0x02, 0x7a, 0x13, 0x56, 0x0f,
+	0x28, 0x93, 0x8d, 0x4f, 0xe3, 0xc3, 0xbf, 0x05, 0x64, 0x32, 0x97, 0xba, 0x8b, 0xdb, 0xb0, 0x92,
+	0xc8, 0x05, 0xea, 0x3a, 0xf6, 0x54, 0x17, 0xcb, 0x7a, 0x2a, 0xd1, 0x0a, 0xf8, 0x1e, 0xac, 0x72,
+	0x76, 0xca, 0x68, 0x72, 0x3c, 0x34, 0x4a, 0x83, 0x68, 0x94, 0x96, 0xd1, 0x28, 0x77, 0x01, 0x99,
+	0x8a, 0x4b, 0x01, 0xe9, 0x82, 0xed, 0x7b, 0xaa, 0xbe, 0xdb, 0xbe, 0x87, 0x11, 0xf4, 0x78, 0x79,
+	0x19, 0x0a, 0x08, 0x2a, 0xc0, 0x9f, 0xc2, 0xaa, 0xc1, 0x53, 0x66, 0xb7, 0x60, 0x25, 0xa5, 0xc9,
+	0x25, 0x4d, 0x66, 0x46, 0x71, 0x3d, 0x4f, 0x10, 0x2d, 0xc6, 0x21, 0xf4, 0x76, 0xa3, 0x88, 0xa5,
+	0x2c, 0x71, 0x63, 0x0d, 0xff, 0x16, 0xd4, 0x82, 0x68, 0x9c, 0x8f, 0xe5, 0x82, 0xe0, 0xdc, 0x24,
+	0xba, 0xca, 0xfa, 0x8d, 0x24, 0x8c, 0xd9, 0xaa, 0x5a, 0x98, 0xad, 0xd6, 0xa0, 0xce, 0xd3, 0x2e,
+	0x6b, 0xc3, 0x8a, 0xc2, 0x1f, 0xc1, 0xaa, 0xe1, 0x4f, 0xc1, 0x95, 0x46, 0xf2, 0x57, 0x92, 0xa2,
+	0xf0, 0x37, 0x16, 0xc0, 0xf1, 0x05, 0xd3, 0xb8, 0xca, 0x6d, 0xb0, 0xf0, 0x36, 0x69, 0xab, 0xb7,
+	0x09, 0x9f, 0x1b, 0x9f, 0x5c, 0xc7, 0x7e, 0x42, 0xd3, 0xc7, 0x1a, 0x56, 0xce, 0xe0, 0xd2, 0x38,
+	0xe5, 0xb1, 0xf3, 0x76, 0x2e, 0xc1, 0xe5, 0x0c, 0x0d, 0xc5, 0xf7, 0x8c, 0x59, 0x91, 0xf9, 0x1e,
+	0x7e, 0x1f, 0x5a, 0x02, 0x89, 0x42, 0x5c, 0x82, 0x82, 0x7f, 0x05, 0x9d, 0x7d, 0x1a, 0x50, 0x46,
+	0x17, 0xa3, 0x2d, 0x78, 0xb6, 0x97, 0xf5, 0xfc, 0x73, 0xe8, 0x6a, 0xc3, 0x8b, 0x9c, 0xbf, 0xdd,
+	0x32, 0x7e, 0x09, 0x20, 0xee, 0xc0, 0xf7, 0x8b, 0xeb, 0x13, 0x68, 0x09, 0xab, 0x0b, 0x41, 0xcd,
+	0x3d, 0x1c, 0xfc, 0x77, 0x0b, 0x9a, 0x0a, 0xca, 0x51, 0x8c, 0x1e, 0x40, 0x2b, 0x91, 0xc4, 0xef,
+	0xe2, 0x0b, 0xa6, 0x86, 0x54, 0x75, 0xdd, 0xf2, 0x93, 0x7f, 0x5a, 0x21, 0xa0, 0xd4, 0x8e, 0x2f,
+	0x18, 0xfa, 0x09, 0x74, 0xf5, 0x22, 0x4f, 0xec, 0x8c, 0xaa, 0x2d, 0xaa, 0x6b, 0x16, 0x8e, 0xe1,
+	0x69, 0x85, 0x74, 0x94, 0xb2, 0xe4, 0x9b, 0x2e, 0xc7, 0x6a, 0x46, 0xca, 0x5c, 0x1e, 0xd0, 0x39,
+	0x2e, 0x0f, 0x28, 0xdb, 0x6d, 0xc2, 0x8a, 0xa2, 0xf0, 0x3f, 0x2d, 0x00, 0x1d, 0xf5, 0x51, 0x8c,
+	0x3e, 0x85, 0x76, 0xa2, 0x28, 0x23, 0x84, 0x55, 0x23, 0x04, 0x29, 0x7c, 0x5a, 0x21, 0x2d, 0xad,
+	0xc8, 0x83, 0xf8, 0x19, 0xdc, 0xc8, 0xd6, 0x15, 0xa2, 0xb8, 0x55, 0x8c, 0x22, 0x5b, 0xdd, 0xd5,
+	0xea, 0x2a, 0x0e, 0xd3, 0x71, 0x1e, 0xc8, 0xaa, 0x11, 0x48, 0xd9, 0x31, 0x0f, 0x05, 0xa0, 0xa1,
+	0x49, 0xfc, 0x43, 0x68, 0xef, 0xba, 0x6c, 0x74, 0xa6, 0x73, 0xe3, 0x2e, 0x54, 0x13, 0xfa, 0x4a,
+	0xd5, 0x8c, 0x1b, 0xba, 0xea, 0xa9, 0xc3, 0x22, 0x5c, 0x86, 0x77, 0xa0, 0xa3, 0x96, 0xa8, 0x83,
+	0x17, 0x6b, 0xd2, 0xb7, 0xac, 0x49, 0xf1, 0x5f, 0x2d, 0x68, 0xcb, 0x27, 0x87, 0xf2, 0xc3, 0x73,
+	0x2a, 0xa1, 0xa7, 0xfe, 0xb5, 0xca, 0x17, 0x45, 0xf1, 0x94, 0x11, 0x3f, 0x58, 0x74, 0xca, 0x08,
+	0x82, 0x73, 0x03, 0x7f, 0xe2, 0xeb, 0x79, 0x56, 0x12, 0x46, 0x5e, 0x3a, 0x66, 0x5e, 0x16, 0xb3,
+	0xb9, 0x36, 0x9b, 0xcd, 0x1b, 0x00, 0x57, 0x3e, 0x3b, 0xfb, 0x8a, 0xe7, 0x62, 0x2a, 0x86, 0xc6,
+	0x06, 0x31, 0x38, 0xf8, 0x6b, 0xe8, 0x28, 0xa4, 0x59, 0x85, 0x6e, 0xb2, 0xe4, 0x22, 0x1c, 0xf1,
+	0x11, 0x56, 0xa0, 0xed, 0x90, 0x9c, 0xc1, 0x2b, 0xbd, 0x1a, 0x58, 0xaa, 0x5b, 0x6d, 0xf5, 0xc6,
+	0x5b, 0x83, 0xfa, 0xa5, 0x34, 0x5f, 0x15, 0x5c, 0x45, 0x6d, 0xdf, 0x83, 0x1b, 0x33, 0x0f, 0x06,
+	0xd4, 0x00, 0xe7, 0xcb, 0x28, 0xa4, 0xbd, 0x0a, 0x02, 0xa8, 0x0f, 0x43, 0x37, 0x8e, 0xa7, 0x3d,
+	0x6b, 0xfb, 0x61, 0xfe, 0xa4, 0xd2, 0x5a, 0x9e, 0xcb, 0xdc, 0x5e, 0x85, 0x7f, 0xf1, 0x21, 0xb0,
+	0x67, 0xa1, 0x26, 0xd4, 0xc4, 0x18, 0xdc, 0xb3, 0xf9, 0xa7, 0x98, 0x72, 0x7b, 0xd5, 0x9d, 0x3f,
+	0xd7, 0x61, 0x3d, 0x1f, 0x1a, 0xdd, 0xd0, 0x1d, 0xd3, 0x64, 0x48, 0x93, 0x4b, 0x7f, 0x44, 0xd1,
+	0xd7, 0x80, 0xca, 0xf3, 0x1c, 0x52, 0x7f, 0x8a, 0x16, 0x8e, 0x94, 0x83, 0xcd, 0xc5, 0x0a, 0x2a,
+	0x89, 0x2a, 0x68, 0x1f, 0x5a, 0xc6, 0x48, 0x86, 0xfa, 0xd9, 0x92, 0x99, 0x09, 0x70, 0xf0, 0xee,
+	0x1c, 0x49, 0x66, 0xe5, 0x18, 0x6e, 0xcc, 0x4c, 0x4f, 0xe8, 0x4e, 0xae, 0x5f, 0x1e, 0xdd, 0x06,
+	0xef, 0x2d, 0x90, 0x66, 0x16, 0x5f, 0x40, 0xb7, 0x38, 0xf2, 0xa0, 0xdb, 0xd9, 0x92, 0xf2, 0xb0,
+	0x35, 0xb8, 0x33, 0x5f, 0x98, 0x99, 0xfb, 0x8d, 0x98, 0x3c, 0x4b, 0x7f, 0x84, 0xf2, 0x1d, 0x5a,
+	0x30, 0x24, 0x0d, 0xee, 0xbe, 0x45, 0x23, 0xb3, 0xfe, 0x18, 0x20, 0x1f, 0x10, 0xd0, 0x7a, 0x3e,
+	0x72, 0x14, 0x66, 0x8b, 0x41, 0xbf, 0x2c, 0x30, 0x4d, 0xe4, 0xc3, 0x8e, 0x36, 0x51, 0x9a, 0x89,
+	0x06, 0xfd, 0xb2, 0x20, 0x33, 0x31, 0x94, 0x23, 0x46, 0xe1, 0x77, 0xce, 0x7b, 0x99, 0xfe, 0xbc,
+	0x87, 0xd0, 0x60, 0x63, 0x91, 0x38, 0x33, 0xfa, 0x05, 0x34, 0xb3, 0x19, 0x05, 0xad, 0xe5, 0xea,
+	0xe6, 0x20, 0x33, 0x58, 0x2f, 0xf1, 0xcd, 0xf5, 0xd9, 0xd0, 0xa0, 0xd7, 0xcf, 0x4e, 0x2d, 0x83,
+	0xf5, 0x12, 0x5f, 0xaf, 0xdf, 0xf9, 0xc6, 0x86, 0x56, 0x86, 0xed, 0xd9, 0x57, 0x68, 0x07, 0x6a,
+	0xa2, 0x86, 0x21, 0x35, 0xcb, 0x9b, 0x35, 0x70, 0x70, 0xb3, 0xc0, 0xcb, 0x30, 0xfc, 0x00, 0xaa,
+	0xbc, 0x6c, 0x97, 0x7a, 0xd3, 0xa0, 0x5c, 0xea, 0xa5, 0xf6, 0x01, 0xcd, 0xb4, 0x0f, 0xe8, 0xac,
+	0xb6, 0x51, 0x9f, 0x71, 0x05, 0x7d, 0x02, 0x75, 0x55, 0xd4, 0xe7, 0xb5, 0xb0, 0xc1, 0xdc, 0x8e,
+	0x80, 0x2b, 0x3c, 0x0c, 0xf9, 0x9f, 0x1a, 0x99, 0x7f, 0x75, 0x8a, 0x61, 0x14, 0x8a, 0x19, 0xae,
+	0xec, 0xf6, 0xff, 0xf1, 0x7a, 0xc3, 0xfa, 0xf6, 0xf5, 0x86, 0xf5, 0xdf, 0xd7, 0x1b, 0xd6, 0x9f,
+	0xde, 0x6c, 0x54, 0xbe, 0x7d, 0xb3, 0x51, 0xf9, 0xcf, 0x9b, 0x8d, 0xca, 0x49, 0x5d, 0xfc, 0x84,
+	0x7f, 0xf0, 0xbf, 0x01, 0x00, 0xa5, 0x0a, 0x12, 0x2f, 0xa2, 0x17, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PartitionManagerServiceClient is the client API for PartitionManagerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PartitionManagerServiceClient interface {
+	SetRowStreamTables(ctx context.Context, in *SetRowStreamTablesRequest, opts ...grpc.CallOption) (*SetRowStreamTablesResponse, error)
+	SetDataKeys(ctx context.Context, in *SetDataKeysRequest, opts ...grpc.CallOption) (*SetDataKeysResponse, error)
+	SetDiscardStats(ctx context.Context, in *SetDiscardStatsRequest, opts ...grpc.CallOption) (*SetDiscardStatsResponse, error)
+	SetBlobStreams(ctx context.Context, in *SetBlobStreamsRequest, opts ...grpc.CallOption) (*SetBlobStreamsResponse, error)
+	SetPartitionOptions(ctx context.Context, in *SetPartitionOptionsRequest, opts ...grpc.CallOption) (*SetPartitionOptionsResponse, error)
+	RegisterPS(ctx context.Context, in *RegisterPSRequest, opts ...grpc.CallOption) (*RegisterPSResponse, error)
+	GetRegions(ctx context.Context, in *GetRegionsRequest, opts ...grpc.CallOption) (*GetRegionsResponse, error)
+	GetPartitionMeta(ctx context.Context, in *GetPartitionMetaRequest, opts ...grpc.CallOption) (*GetPartitionMetaResponse, error)
+	GetPSInfo(ctx context.Context, in *GetPSInfoRequest, opts ...grpc.CallOption) (*GetPSInfoResponse, error)
+	Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error)
+}
+
+type partitionManagerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPartitionManagerServiceClient(cc *grpc.ClientConn) PartitionManagerServiceClient {
+	return &partitionManagerServiceClient{cc}
+}
+
+func (c *partitionManagerServiceClient) SetRowStreamTables(ctx context.Context, in *SetRowStreamTablesRequest, opts ...grpc.CallOption) (*SetRowStreamTablesResponse, error) {
+	out := new(SetRowStreamTablesResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/SetRowStreamTables", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *BlobStreams) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionManagerServiceClient) SetDataKeys(ctx context.Context, in *SetDataKeysRequest, opts ...grpc.CallOption) (*SetDataKeysResponse, error) {
+	out := new(SetDataKeysResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/SetDataKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *BlobStreams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Blob) > 0 {
-		dAtA4 := make([]byte, len(m.Blob)*10)
-		var j3 int
-		for _, num := range m.Blob {
-			for num >= 1<<7 {
-				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j3++
-			}
-			dAtA4[j3] = uint8(num)
-			j3++
-		}
-		i -= j3
-		copy(dAtA[i:], dAtA4[:j3])
-		i = encodeVarintPspb(dAtA, i, uint64(j3))
-		i--
-		dAtA[i] = 0xa
+func (c *partitionManagerServiceClient) SetDiscardStats(ctx context.Context, in *SetDiscardStatsRequest, opts ...grpc.CallOption) (*SetDiscardStatsResponse, error) {
+	out := new(SetDiscardStatsResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/SetDiscardStats", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *TableLocations) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionManagerServiceClient) SetBlobStreams(ctx context.Context, in *SetBlobStreamsRequest, opts ...grpc.CallOption) (*SetBlobStreamsResponse, error) {
+	out := new(SetBlobStreamsResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/SetBlobStreams", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *TableLocations) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionManagerServiceClient) SetPartitionOptions(ctx context.Context, in *SetPartitionOptionsRequest, opts ...grpc.CallOption) (*SetPartitionOptionsResponse, error) {
+	out := new(SetPartitionOptionsResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/SetPartitionOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *TableLocations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Locs) > 0 {
-		for iNdEx := len(m.Locs) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Locs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPspb(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (c *partitionManagerServiceClient) RegisterPS(ctx context.Context, in *RegisterPSRequest, opts ...grpc.CallOption) (*RegisterPSResponse, error) {
+	out := new(RegisterPSResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/RegisterPS", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *PartitionMeta) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionManagerServiceClient) GetRegions(ctx context.Context, in *GetRegionsRequest, opts ...grpc.CallOption) (*GetRegionsResponse, error) {
+	out := new(GetRegionsResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/GetRegions", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *PartitionMeta) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionManagerServiceClient) GetPartitionMeta(ctx context.Context, in *GetPartitionMetaRequest, opts ...grpc.CallOption) (*GetPartitionMetaResponse, error) {
+	out := new(GetPartitionMetaResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/GetPartitionMeta", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *PartitionMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.PartID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.PartID))
-		i--
-		dAtA[i] = 0x40
-	}
-	if m.Rg != nil {
-		{
-			size, err := m.Rg.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPspb(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
-	}
-	if len(m.Discard) > 0 {
-		i -= len(m.Discard)
-		copy(dAtA[i:], m.Discard)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Discard)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if m.Parent != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Parent))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Locs != nil {
-		{
-			size, err := m.Locs.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPspb(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.RowStream != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.RowStream))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.LogStream != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.LogStream))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.Blobs != nil {
-		{
-			size, err := m.Blobs.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPspb(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (c *partitionManagerServiceClient) GetPSInfo(ctx context.Context, in *GetPSInfoRequest, opts ...grpc.CallOption) (*GetPSInfoResponse, error) {
+	out := new(GetPSInfoResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/GetPSInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *PSDetail) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionManagerServiceClient) Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error) {
+	out := new(BootstrapResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionManagerService/Bootstrap", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *PSDetail) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
+// PartitionManagerServiceServer is the server API for PartitionManagerService service.
+type PartitionManagerServiceServer interface {
+	SetRowStreamTables(context.Context, *SetRowStreamTablesRequest) (*SetRowStreamTablesResponse, error)
+	SetDataKeys(context.Context, *SetDataKeysRequest) (*SetDataKeysResponse, error)
+	SetDiscardStats(context.Context, *SetDiscardStatsRequest) (*SetDiscardStatsResponse, error)
+	SetBlobStreams(context.Context, *SetBlobStreamsRequest) (*SetBlobStreamsResponse, error)
+	SetPartitionOptions(context.Context, *SetPartitionOptionsRequest) (*SetPartitionOptionsResponse, error)
+	RegisterPS(context.Context, *RegisterPSRequest) (*RegisterPSResponse, error)
+	GetRegions(context.Context, *GetRegionsRequest) (*GetRegionsResponse, error)
+	GetPartitionMeta(context.Context, *GetPartitionMetaRequest) (*GetPartitionMetaResponse, error)
+	GetPSInfo(context.Context, *GetPSInfoRequest) (*GetPSInfoResponse, error)
+	Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error)
+}
 
-func (m *PSDetail) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Address) > 0 {
-		i -= len(m.Address)
-		copy(dAtA[i:], m.Address)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Address)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.PSID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.PSID))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+// UnimplementedPartitionManagerServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedPartitionManagerServiceServer struct {
 }
 
-func (m *RegionInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (*UnimplementedPartitionManagerServiceServer) SetRowStreamTables(ctx context.Context, req *SetRowStreamTablesRequest) (*SetRowStreamTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRowStreamTables not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) SetDataKeys(ctx context.Context, req *SetDataKeysRequest) (*SetDataKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDataKeys not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) SetDiscardStats(ctx context.Context, req *SetDiscardStatsRequest) (*SetDiscardStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDiscardStats not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) SetBlobStreams(ctx context.Context, req *SetBlobStreamsRequest) (*SetBlobStreamsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBlobStreams not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) SetPartitionOptions(ctx context.Context, req *SetPartitionOptionsRequest) (*SetPartitionOptionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPartitionOptions not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) RegisterPS(ctx context.Context, req *RegisterPSRequest) (*RegisterPSResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterPS not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) GetRegions(ctx context.Context, req *GetRegionsRequest) (*GetRegionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRegions not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) GetPartitionMeta(ctx context.Context, req *GetPartitionMetaRequest) (*GetPartitionMetaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPartitionMeta not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) GetPSInfo(ctx context.Context, req *GetPSInfoRequest) (*GetPSInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPSInfo not implemented")
+}
+func (*UnimplementedPartitionManagerServiceServer) Bootstrap(ctx context.Context, req *BootstrapRequest) (*BootstrapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Bootstrap not implemented")
 }
 
-func (m *RegionInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func RegisterPartitionManagerServiceServer(s *grpc.Server, srv PartitionManagerServiceServer) {
+	s.RegisterService(&_PartitionManagerService_serviceDesc, srv)
 }
 
-func (m *RegionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Addr) > 0 {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0x22
+func _PartitionManagerService_SetRowStreamTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRowStreamTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.PSID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.PSID))
-		i--
-		dAtA[i] = 0x18
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).SetRowStreamTables(ctx, in)
 	}
-	if m.PartID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.PartID))
-		i--
-		dAtA[i] = 0x10
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/SetRowStreamTables",
 	}
-	if m.Rg != nil {
-		{
-			size, err := m.Rg.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPspb(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).SetRowStreamTables(ctx, req.(*SetRowStreamTablesRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *RawBlockMeta) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _PartitionManagerService_SetDataKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDataKeysRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *RawBlockMeta) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *RawBlockMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.SeqNum != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.SeqNum))
-		i--
-		dAtA[i] = 0x30
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).SetDataKeys(ctx, in)
 	}
-	if m.VpOffset != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.VpOffset))
-		i--
-		dAtA[i] = 0x28
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/SetDataKeys",
 	}
-	if m.VpExtentID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.VpExtentID))
-		i--
-		dAtA[i] = 0x20
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).SetDataKeys(ctx, req.(*SetDataKeysRequest))
 	}
-	if m.UnCompressedSize != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.UnCompressedSize))
-		i--
-		dAtA[i] = 0x18
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartitionManagerService_SetDiscardStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDiscardStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.CompressedSize != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.CompressedSize))
-		i--
-		dAtA[i] = 0x10
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).SetDiscardStats(ctx, in)
 	}
-	if m.Type != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x8
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/SetDiscardStats",
 	}
-	return len(dAtA) - i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).SetDiscardStats(ctx, req.(*SetDiscardStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *BlockOffset) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _PartitionManagerService_SetBlobStreams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBlobStreamsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *BlockOffset) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).SetBlobStreams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/SetBlobStreams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).SetBlobStreams(ctx, req.(*SetBlobStreamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *BlockOffset) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Offset != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Offset))
-		i--
-		dAtA[i] = 0x18
+func _PartitionManagerService_SetPartitionOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPartitionOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.ExtentID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.ExtentID))
-		i--
-		dAtA[i] = 0x10
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).SetPartitionOptions(ctx, in)
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/SetPartitionOptions",
 	}
-	return len(dAtA) - i, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).SetPartitionOptions(ctx, req.(*SetPartitionOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *TableIndex) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _PartitionManagerService_RegisterPS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterPSRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).RegisterPS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/RegisterPS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).RegisterPS(ctx, req.(*RegisterPSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *TableIndex) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _PartitionManagerService_GetRegions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRegionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).GetRegions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/GetRegions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).GetRegions(ctx, req.(*GetRegionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *TableIndex) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.NumOfBlocks != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.NumOfBlocks))
-		i--
-		dAtA[i] = 0x20
+func _PartitionManagerService_GetPartitionMeta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartitionMetaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.EstimatedSize != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.EstimatedSize))
-		i--
-		dAtA[i] = 0x18
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).GetPartitionMeta(ctx, in)
 	}
-	if len(m.BloomFilter) > 0 {
-		i -= len(m.BloomFilter)
-		copy(dAtA[i:], m.BloomFilter)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.BloomFilter)))
-		i--
-		dAtA[i] = 0x12
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/GetPartitionMeta",
 	}
-	if len(m.Offsets) > 0 {
-		for iNdEx := len(m.Offsets) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Offsets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPspb(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).GetPartitionMeta(ctx, req.(*GetPartitionMetaRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetPartitionMetaRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _PartitionManagerService_GetPSInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPSInfoRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).GetPSInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/GetPSInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).GetPSInfo(ctx, req.(*GetPSInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetPartitionMetaRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _PartitionManagerService_Bootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootstrapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartitionManagerServiceServer).Bootstrap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionManagerService/Bootstrap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionManagerServiceServer).Bootstrap(ctx, req.(*BootstrapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetPartitionMetaRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.PSID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.PSID))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+var _PartitionManagerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pspb.PartitionManagerService",
+	HandlerType: (*PartitionManagerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetRowStreamTables",
+			Handler:    _PartitionManagerService_SetRowStreamTables_Handler,
+		},
+		{
+			MethodName: "SetDataKeys",
+			Handler:    _PartitionManagerService_SetDataKeys_Handler,
+		},
+		{
+			MethodName: "SetDiscardStats",
+			Handler:    _PartitionManagerService_SetDiscardStats_Handler,
+		},
+		{
+			MethodName: "SetBlobStreams",
+			Handler:    _PartitionManagerService_SetBlobStreams_Handler,
+		},
+		{
+			MethodName: "SetPartitionOptions",
+			Handler:    _PartitionManagerService_SetPartitionOptions_Handler,
+		},
+		{
+			MethodName: "RegisterPS",
+			Handler:    _PartitionManagerService_RegisterPS_Handler,
+		},
+		{
+			MethodName: "GetRegions",
+			Handler:    _PartitionManagerService_GetRegions_Handler,
+		},
+		{
+			MethodName: "GetPartitionMeta",
+			Handler:    _PartitionManagerService_GetPartitionMeta_Handler,
+		},
+		{
+			MethodName: "GetPSInfo",
+			Handler:    _PartitionManagerService_GetPSInfo_Handler,
+		},
+		{
+			MethodName: "Bootstrap",
+			Handler:    _PartitionManagerService_Bootstrap_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pspb.proto",
 }
 
-func (m *GetPartitionMetaResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+// PartitionKVClient is the client API for PartitionKV service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PartitionKVClient interface {
+	//
+	//option (google.api.http) = {
+	//post: "/v3/kv/txn"
+	//body: "*"
+	//};
+	Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
+	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Range(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (*RangeResponse, error)
 }
 
-func (m *GetPartitionMetaResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type partitionKVClient struct {
+	cc *grpc.ClientConn
 }
 
-func (m *GetPartitionMetaResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Meta) > 0 {
-		for iNdEx := len(m.Meta) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Meta[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPspb(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if m.Code != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+func NewPartitionKVClient(cc *grpc.ClientConn) PartitionKVClient {
+	return &partitionKVClient{cc}
 }
 
-func (m *SetRowStreamTablesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionKVClient) Batch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
+	out := new(BatchResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Batch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *SetRowStreamTablesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionKVClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error) {
+	out := new(PutResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Put", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *SetRowStreamTablesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Locs != nil {
-		{
-			size, err := m.Locs.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPspb(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.PartitionID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.PartitionID))
-		i--
-		dAtA[i] = 0x8
+func (c *partitionKVClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *SetRowStreamTablesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionKVClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Delete", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *SetRowStreamTablesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *partitionKVClient) Range(ctx context.Context, in *RangeRequest, opts ...grpc.CallOption) (*RangeResponse, error) {
+	out := new(RangeResponse)
+	err := c.cc.Invoke(ctx, "/pspb.PartitionKV/Range", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *SetRowStreamTablesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Code != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
-		i--
-		dAtA[i] = 0x8
+// PartitionKVServer is the server API for PartitionKV service.
+type PartitionKVServer interface {
+	//
+	//option (google.api.http) = {
+	//post: "/v3/kv/txn"
+	//body: "*"
+	//};
+	Batch(context.Context, *BatchRequest) (*BatchResponse, error)
+	Put(context.Context, *PutRequest) (*PutResponse, error)
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Range(context.Context, *RangeRequest) (*RangeResponse, error)
+}
+
+// UnimplementedPartitionKVServer can be embedded to have forward compatible implementations.
+type UnimplementedPartitionKVServer struct {
+}
+
+func (*UnimplementedPartitionKVServer) Batch(ctx context.Context, req *BatchRequest) (*BatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Batch not implemented")
+}
+func (*UnimplementedPartitionKVServer) Put(ctx context.Context, req *PutRequest) (*PutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
+}
+func (*UnimplementedPartitionKVServer) Get(ctx context.Context, req *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedPartitionKVServer) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedPartitionKVServer) Range(ctx context.Context, req *RangeRequest) (*RangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Range not implemented")
+}
+
+func RegisterPartitionKVServer(s *grpc.Server, srv PartitionKVServer) {
+	s.RegisterService(&_PartitionKV_serviceDesc, srv)
+}
+
+func _PartitionKV_Batch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	if interceptor == nil {
+		return srv.(PartitionKVServer).Batch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionKV/Batch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionKVServer).Batch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetRegionsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func _PartitionKV_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	if interceptor == nil {
+		return srv.(PartitionKVServer).Put(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionKV/Put",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionKVServer).Put(ctx, req.(*PutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetRegionsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func _PartitionKV_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartitionKVServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionKV/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionKVServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetRegionsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func _PartitionKV_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartitionKVServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionKV/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionKVServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *GetRegionsResponse) Marshal() (dAtA []byte, err error) {
+func _PartitionKV_Range_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartitionKVServer).Range(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pspb.PartitionKV/Range",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartitionKVServer).Range(ctx, req.(*RangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PartitionKV_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pspb.PartitionKV",
+	HandlerType: (*PartitionKVServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Batch",
+			Handler:    _PartitionKV_Batch_Handler,
+		},
+		{
+			MethodName: "Put",
+			Handler:    _PartitionKV_Put_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _PartitionKV_Get_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _PartitionKV_Delete_Handler,
+		},
+		{
+			MethodName: "Range",
+			Handler:    _PartitionKV_Range_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pspb.proto",
+}
+
+func (m *MixedLog) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3339,39 +3647,48 @@ func (m *GetRegionsResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetRegionsResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *MixedLog) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetRegionsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MixedLog) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Regions) > 0 {
-		for iNdEx := len(m.Regions) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Regions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPspb(dAtA, i, uint64(size))
+	if m.CompressedSize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.CompressedSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Compression != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Compression))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Offsets) > 0 {
+		dAtA2 := make([]byte, len(m.Offsets)*10)
+		var j1 int
+		for _, num := range m.Offsets {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
 			}
-			i--
-			dAtA[i] = 0x12
+			dAtA2[j1] = uint8(num)
+			j1++
 		}
-	}
-	if m.Code != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintPspb(dAtA, i, uint64(j1))
 		i--
-		dAtA[i] = 0x8
+		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RegisterPSRequest) Marshal() (dAtA []byte, err error) {
+func (m *Range) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3381,27 +3698,34 @@ func (m *RegisterPSRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RegisterPSRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *Range) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RegisterPSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Range) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Addr) > 0 {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Addr)))
+	if len(m.EndKey) > 0 {
+		i -= len(m.EndKey)
+		copy(dAtA[i:], m.EndKey)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.EndKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.StartKey) > 0 {
+		i -= len(m.StartKey)
+		copy(dAtA[i:], m.StartKey)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.StartKey)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RegisterPSResponse) Marshal() (dAtA []byte, err error) {
+func (m *Location) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3411,30 +3735,30 @@ func (m *RegisterPSResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RegisterPSResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *Location) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RegisterPSResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Location) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Id != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Id))
+	if m.Offset != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Offset))
 		i--
 		dAtA[i] = 0x10
 	}
-	if m.Code != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
+	if m.ExtentID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.ExtentID))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetPSInfoRequest) Marshal() (dAtA []byte, err error) {
+func (m *BlobStreams) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3444,43 +3768,99 @@ func (m *GetPSInfoRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetPSInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *BlobStreams) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetPSInfoRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BlobStreams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	return len(dAtA) - i, nil
-}
-
-func (m *GetPSInfoResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if len(m.Blob) > 0 {
+		dAtA4 := make([]byte, len(m.Blob)*10)
+		var j3 int
+		for _, num := range m.Blob {
+			for num >= 1<<7 {
+				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j3++
+			}
+			dAtA4[j3] = uint8(num)
+			j3++
+		}
+		i -= j3
+		copy(dAtA[i:], dAtA4[:j3])
+		i = encodeVarintPspb(dAtA, i, uint64(j3))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiscardStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
 	if err != nil {
 		return nil, err
 	}
 	return dAtA[:n], nil
 }
 
-func (m *GetPSInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *DiscardStats) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetPSInfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DiscardStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Servers) > 0 {
-		for iNdEx := len(m.Servers) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Discard) > 0 {
+		for k := range m.Discard {
+			v := m.Discard[k]
+			baseI := i
+			i = encodeVarintPspb(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i = encodeVarintPspb(dAtA, i, uint64(k))
+			i--
+			dAtA[i] = 0x8
+			i = encodeVarintPspb(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TableLocations) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TableLocations) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TableLocations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Locs) > 0 {
+		for iNdEx := len(m.Locs) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Servers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Locs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -3494,7 +3874,7 @@ func (m *GetPSInfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *BootstrapRequest) Marshal() (dAtA []byte, err error) {
+func (m *DataKey) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3504,35 +3884,42 @@ func (m *BootstrapRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BootstrapRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *DataKey) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BootstrapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DataKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Parent != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Parent))
+	if m.CreatedAt != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.CreatedAt))
 		i--
-		dAtA[i] = 0x18
+		dAtA[i] = 0x20
 	}
-	if m.RowID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.RowID))
+	if len(m.Wrapped) > 0 {
+		i -= len(m.Wrapped)
+		copy(dAtA[i:], m.Wrapped)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Wrapped)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.MasterKeyID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.MasterKeyID))
 		i--
 		dAtA[i] = 0x10
 	}
-	if m.LogID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.LogID))
+	if m.KeyID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.KeyID))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *BootstrapResponse) Marshal() (dAtA []byte, err error) {
+func (m *DataKeys) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3542,25 +3929,34 @@ func (m *BootstrapResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BootstrapResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *DataKeys) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DataKeys) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.PartID != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.PartID))
-		i--
-		dAtA[i] = 0x8
+	if len(m.Keys) > 0 {
+		for iNdEx := len(m.Keys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Keys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPspb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PutRequest) Marshal() (dAtA []byte, err error) {
+func (m *PartitionOptions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3570,49 +3966,55 @@ func (m *PutRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PutRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *PartitionOptions) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PutRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PartitionOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Partid != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Partid))
+	if m.MixedBlockSize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.MixedBlockSize))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ValueThreshold != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.ValueThreshold))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.TableBlockSize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.TableBlockSize))
 		i--
 		dAtA[i] = 0x28
 	}
-	if m.Psversion != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+	if m.FlushInterval != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.FlushInterval))
 		i--
 		dAtA[i] = 0x20
 	}
-	if m.ExpiresAt != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.ExpiresAt))
+	if m.MaxReplaySize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.MaxReplaySize))
 		i--
 		dAtA[i] = 0x18
 	}
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Value)))
+	if m.WriteChCapacity != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.WriteChCapacity))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x10
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+	if m.MemtableSize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.MemtableSize))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *PutResponse) Marshal() (dAtA []byte, err error) {
+func (m *PartitionMeta) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3622,27 +4024,107 @@ func (m *PutResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PutResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *PartitionMeta) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *PutResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PartitionMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+	if m.Opts != nil {
+		{
+			size, err := m.Opts.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Keys != nil {
+		{
+			size, err := m.Keys.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.PartID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PartID))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Rg != nil {
+		{
+			size, err := m.Rg.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Discard) > 0 {
+		i -= len(m.Discard)
+		copy(dAtA[i:], m.Discard)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Discard)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Parent != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Parent))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Locs != nil {
+		{
+			size, err := m.Locs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.RowStream != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.RowStream))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.LogStream != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.LogStream))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Blobs != nil {
+		{
+			size, err := m.Blobs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteRequest) Marshal() (dAtA []byte, err error) {
+func (m *PSDetail) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3652,37 +4134,32 @@ func (m *DeleteRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *PSDetail) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PSDetail) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Partid != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Partid))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Psversion != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Address)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x12
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+	if m.PSID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PSID))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DeleteResponse) Marshal() (dAtA []byte, err error) {
+func (m *RegionInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3692,32 +4169,49 @@ func (m *DeleteResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *RegionInfo) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DeleteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RegionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Psversion != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+	if len(m.Addr) > 0 {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Addr)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x22
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+	if m.PSID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PSID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.PartID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PartID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Rg != nil {
+		{
+			size, err := m.Rg.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetRequest) Marshal() (dAtA []byte, err error) {
+func (m *RawBlockMeta) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3727,37 +4221,65 @@ func (m *GetRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *RawBlockMeta) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *RawBlockMeta) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Partid != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Partid))
+	if m.EncryptedSize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.EncryptedSize))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.KeyID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.KeyID))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Compression != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Compression))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.SeqNum != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.SeqNum))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.VpOffset != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.VpOffset))
 		i--
 		dAtA[i] = 0x28
 	}
-	if m.Psversion != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+	if m.VpExtentID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.VpExtentID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.UnCompressedSize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.UnCompressedSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.CompressedSize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.CompressedSize))
 		i--
 		dAtA[i] = 0x10
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+	if m.Type != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Type))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *GetResponse) Marshal() (dAtA []byte, err error) {
+func (m *BlockOffset) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3767,22 +4289,25 @@ func (m *GetResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *BlockOffset) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *BlockOffset) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Value)))
+	if m.Offset != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Offset))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x18
+	}
+	if m.ExtentID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.ExtentID))
+		i--
+		dAtA[i] = 0x10
 	}
 	if len(m.Key) > 0 {
 		i -= len(m.Key)
@@ -3794,7 +4319,7 @@ func (m *GetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *RequestOp) Marshal() (dAtA []byte, err error) {
+func (m *TableIndex) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3804,38 +4329,33 @@ func (m *RequestOp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RequestOp) MarshalTo(dAtA []byte) (int, error) {
+func (m *TableIndex) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RequestOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *TableIndex) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Request != nil {
-		{
-			size := m.Request.Size()
-			i -= size
-			if _, err := m.Request.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
+	if len(m.Biggest) > 0 {
+		i -= len(m.Biggest)
+		copy(dAtA[i:], m.Biggest)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Biggest)))
+		i--
+		dAtA[i] = 0x42
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *RequestOp_RequestPut) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *RequestOp_RequestPut) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.RequestPut != nil {
+	if len(m.Smallest) > 0 {
+		i -= len(m.Smallest)
+		copy(dAtA[i:], m.Smallest)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Smallest)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.BloomLoc != nil {
 		{
-			size, err := m.RequestPut.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.BloomLoc.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3843,20 +4363,11 @@ func (m *RequestOp_RequestPut) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintPspb(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x32
 	}
-	return len(dAtA) - i, nil
-}
-func (m *RequestOp_RequestDelete) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *RequestOp_RequestDelete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.RequestDelete != nil {
+	if m.IndexLoc != nil {
 		{
-			size, err := m.RequestDelete.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.IndexLoc.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3864,32 +4375,71 @@ func (m *RequestOp_RequestDelete) MarshalToSizedBuffer(dAtA []byte) (int, error)
 			i = encodeVarintPspb(dAtA, i, uint64(size))
 		}
 		i--
+		dAtA[i] = 0x2a
+	}
+	if m.NumOfBlocks != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.NumOfBlocks))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.EstimatedSize != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.EstimatedSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.BloomFilter) > 0 {
+		i -= len(m.BloomFilter)
+		copy(dAtA[i:], m.BloomFilter)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.BloomFilter)))
+		i--
 		dAtA[i] = 0x12
 	}
+	if len(m.Offsets) > 0 {
+		for iNdEx := len(m.Offsets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Offsets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPspb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
 	return len(dAtA) - i, nil
 }
-func (m *RequestOp_RequestGet) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *GetPartitionMetaRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetPartitionMetaRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RequestOp_RequestGet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetPartitionMetaRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.RequestGet != nil {
-		{
-			size, err := m.RequestGet.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPspb(dAtA, i, uint64(size))
-		}
+	_ = i
+	var l int
+	_ = l
+	if m.PSID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PSID))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
-func (m *ResponseOp) Marshal() (dAtA []byte, err error) {
+
+func (m *GetPartitionMetaResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3899,38 +4449,61 @@ func (m *ResponseOp) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ResponseOp) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetPartitionMetaResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResponseOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GetPartitionMetaResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Response != nil {
-		{
-			size := m.Response.Size()
-			i -= size
-			if _, err := m.Response.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
+	if len(m.Meta) > 0 {
+		for iNdEx := len(m.Meta) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Meta[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPspb(dAtA, i, uint64(size))
 			}
+			i--
+			dAtA[i] = 0x12
 		}
 	}
+	if m.Code != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
-func (m *ResponseOp_ResponsePut) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetRowStreamTablesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetRowStreamTablesRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResponseOp_ResponsePut) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetRowStreamTablesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.ResponsePut != nil {
+	_ = i
+	var l int
+	_ = l
+	if m.Locs != nil {
 		{
-			size, err := m.ResponsePut.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Locs.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3938,41 +4511,67 @@ func (m *ResponseOp_ResponsePut) MarshalToSizedBuffer(dAtA []byte) (int, error)
 			i = encodeVarintPspb(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x12
+	}
+	if m.PartitionID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PartitionID))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
-func (m *ResponseOp_ResponseDelete) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *SetRowStreamTablesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetRowStreamTablesResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResponseOp_ResponseDelete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetRowStreamTablesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.ResponseDelete != nil {
-		{
-			size, err := m.ResponseDelete.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPspb(dAtA, i, uint64(size))
-		}
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
-func (m *ResponseOp_ResponseGet) MarshalTo(dAtA []byte) (int, error) {
+
+func (m *SetDataKeysRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetDataKeysRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ResponseOp_ResponseGet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetDataKeysRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
-	if m.ResponseGet != nil {
+	_ = i
+	var l int
+	_ = l
+	if m.Keys != nil {
 		{
-			size, err := m.ResponseGet.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Keys.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3980,11 +4579,17 @@ func (m *ResponseOp_ResponseGet) MarshalToSizedBuffer(dAtA []byte) (int, error)
 			i = encodeVarintPspb(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x12
+	}
+	if m.PartitionID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PartitionID))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
-func (m *BatchRequest) Marshal() (dAtA []byte, err error) {
+
+func (m *SetDataKeysResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3994,34 +4599,25 @@ func (m *BatchRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BatchRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetDataKeysResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetDataKeysResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Req) > 0 {
-		for iNdEx := len(m.Req) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Req[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPspb(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if m.Code != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *BatchResponse) Marshal() (dAtA []byte, err error) {
+func (m *SetDiscardStatsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4031,34 +4627,37 @@ func (m *BatchResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *BatchResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetDiscardStatsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BatchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetDiscardStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Res) > 0 {
-		for iNdEx := len(m.Res) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Res[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintPspb(dAtA, i, uint64(size))
+	if m.Stats != nil {
+		{
+			size, err := m.Stats.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			i--
-			dAtA[i] = 0xa
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.PartitionID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PartitionID))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RangeRequest) Marshal() (dAtA []byte, err error) {
+func (m *SetDiscardStatsResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4068,49 +4667,25 @@ func (m *RangeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RangeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetDiscardStatsResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RangeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetDiscardStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Psversion != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
-		i--
-		dAtA[i] = 0x28
-	}
-	if m.Partid != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Partid))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Limit != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Limit))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Start) > 0 {
-		i -= len(m.Start)
-		copy(dAtA[i:], m.Start)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Start)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Prefix) > 0 {
-		i -= len(m.Prefix)
-		copy(dAtA[i:], m.Prefix)
-		i = encodeVarintPspb(dAtA, i, uint64(len(m.Prefix)))
+	if m.Code != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *RangeResponse) Marshal() (dAtA []byte, err error) {
+func (m *SetBlobStreamsRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -4120,828 +4695,3680 @@ func (m *RangeResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RangeResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetBlobStreamsRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RangeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SetBlobStreamsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for iNdEx := len(m.Keys) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Keys[iNdEx])
-			copy(dAtA[i:], m.Keys[iNdEx])
-			i = encodeVarintPspb(dAtA, i, uint64(len(m.Keys[iNdEx])))
-			i--
-			dAtA[i] = 0x12
+	if m.Blobs != nil {
+		{
+			size, err := m.Blobs.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Truncated != 0 {
-		i = encodeVarintPspb(dAtA, i, uint64(m.Truncated))
+	if m.PartitionID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PartitionID))
 		i--
 		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintPspb(dAtA []byte, offset int, v uint64) int {
-	offset -= sovPspb(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *SetBlobStreamsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *MixedLog) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *SetBlobStreamsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetBlobStreamsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Offsets) > 0 {
-		l = 0
-		for _, e := range m.Offsets {
-			l += sovPspb(uint64(e))
-		}
-		n += 1 + sovPspb(uint64(l)) + l
+	if m.Code != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Range) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SetPartitionOptionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SetPartitionOptionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetPartitionOptionsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.StartKey)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.Opts != nil {
+		{
+			size, err := m.Opts.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	l = len(m.EndKey)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.PartitionID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.PartitionID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Location) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SetPartitionOptionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *SetPartitionOptionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetPartitionOptionsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ExtentID != 0 {
-		n += 1 + sovPspb(uint64(m.ExtentID))
+	if m.Code != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
 	}
-	if m.Offset != 0 {
-		n += 1 + sovPspb(uint64(m.Offset))
+	return len(dAtA) - i, nil
+}
+
+func (m *GetRegionsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *BlobStreams) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Blob) > 0 {
-		l = 0
-		for _, e := range m.Blob {
-			l += sovPspb(uint64(e))
-		}
-		n += 1 + sovPspb(uint64(l)) + l
-	}
-	return n
+func (m *GetRegionsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TableLocations) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetRegionsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Locs) > 0 {
-		for _, e := range m.Locs {
-			l = e.Size()
-			n += 1 + l + sovPspb(uint64(l))
-		}
-	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PartitionMeta) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Blobs != nil {
-		l = m.Blobs.Size()
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	if m.LogStream != 0 {
-		n += 1 + sovPspb(uint64(m.LogStream))
-	}
-	if m.RowStream != 0 {
-		n += 1 + sovPspb(uint64(m.RowStream))
-	}
-	if m.Locs != nil {
-		l = m.Locs.Size()
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	if m.Parent != 0 {
-		n += 1 + sovPspb(uint64(m.Parent))
-	}
-	l = len(m.Discard)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	if m.Rg != nil {
-		l = m.Rg.Size()
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	if m.PartID != 0 {
-		n += 1 + sovPspb(uint64(m.PartID))
+func (m *GetRegionsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *PSDetail) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.PSID != 0 {
-		n += 1 + sovPspb(uint64(m.PSID))
-	}
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	return n
+func (m *GetRegionsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RegionInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetRegionsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Rg != nil {
-		l = m.Rg.Size()
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	if m.PartID != 0 {
-		n += 1 + sovPspb(uint64(m.PartID))
-	}
-	if m.PSID != 0 {
-		n += 1 + sovPspb(uint64(m.PSID))
+	if len(m.Regions) > 0 {
+		for iNdEx := len(m.Regions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Regions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPspb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	l = len(m.Addr)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.Code != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *RawBlockMeta) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovPspb(uint64(m.Type))
-	}
-	if m.CompressedSize != 0 {
-		n += 1 + sovPspb(uint64(m.CompressedSize))
-	}
-	if m.UnCompressedSize != 0 {
-		n += 1 + sovPspb(uint64(m.UnCompressedSize))
-	}
-	if m.VpExtentID != 0 {
-		n += 1 + sovPspb(uint64(m.VpExtentID))
-	}
-	if m.VpOffset != 0 {
-		n += 1 + sovPspb(uint64(m.VpOffset))
-	}
-	if m.SeqNum != 0 {
-		n += 1 + sovPspb(uint64(m.SeqNum))
+func (m *RegisterPSRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *BlockOffset) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	if m.ExtentID != 0 {
-		n += 1 + sovPspb(uint64(m.ExtentID))
-	}
-	if m.Offset != 0 {
-		n += 1 + sovPspb(uint64(m.Offset))
-	}
-	return n
+func (m *RegisterPSRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TableIndex) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *RegisterPSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Offsets) > 0 {
-		for _, e := range m.Offsets {
-			l = e.Size()
-			n += 1 + l + sovPspb(uint64(l))
-		}
-	}
-	l = len(m.BloomFilter)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	if m.EstimatedSize != 0 {
-		n += 1 + sovPspb(uint64(m.EstimatedSize))
-	}
-	if m.NumOfBlocks != 0 {
-		n += 1 + sovPspb(uint64(m.NumOfBlocks))
+	if len(m.Addr) > 0 {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetPartitionMetaRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.PSID != 0 {
-		n += 1 + sovPspb(uint64(m.PSID))
+func (m *RegisterPSResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetPartitionMetaResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *RegisterPSResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RegisterPSResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Code != 0 {
-		n += 1 + sovPspb(uint64(m.Code))
+	if m.Id != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x10
 	}
-	if len(m.Meta) > 0 {
-		for _, e := range m.Meta {
-			l = e.Size()
-			n += 1 + l + sovPspb(uint64(l))
-		}
+	if m.Code != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *SetRowStreamTablesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.PartitionID != 0 {
-		n += 1 + sovPspb(uint64(m.PartitionID))
-	}
-	if m.Locs != nil {
-		l = m.Locs.Size()
-		n += 1 + l + sovPspb(uint64(l))
+func (m *GetPSInfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *SetRowStreamTablesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Code != 0 {
-		n += 1 + sovPspb(uint64(m.Code))
-	}
-	return n
+func (m *GetPSInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetRegionsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetPSInfoRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetRegionsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Code != 0 {
-		n += 1 + sovPspb(uint64(m.Code))
-	}
-	if len(m.Regions) > 0 {
-		for _, e := range m.Regions {
-			l = e.Size()
-			n += 1 + l + sovPspb(uint64(l))
-		}
+func (m *GetPSInfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *RegisterPSRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Addr)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	return n
+func (m *GetPSInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RegisterPSResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *GetPSInfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Code != 0 {
-		n += 1 + sovPspb(uint64(m.Code))
-	}
-	if m.Id != 0 {
-		n += 1 + sovPspb(uint64(m.Id))
+	if len(m.Servers) > 0 {
+		for iNdEx := len(m.Servers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Servers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPspb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetPSInfoRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BootstrapRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetPSInfoResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Servers) > 0 {
-		for _, e := range m.Servers {
-			l = e.Size()
-			n += 1 + l + sovPspb(uint64(l))
-		}
-	}
-	return n
+func (m *BootstrapRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *BootstrapRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *BootstrapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.LogID != 0 {
-		n += 1 + sovPspb(uint64(m.LogID))
+	if m.BlobID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.BlobID))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Parent != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Parent))
+		i--
+		dAtA[i] = 0x18
 	}
 	if m.RowID != 0 {
-		n += 1 + sovPspb(uint64(m.RowID))
+		i = encodeVarintPspb(dAtA, i, uint64(m.RowID))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.Parent != 0 {
-		n += 1 + sovPspb(uint64(m.Parent))
+	if m.LogID != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.LogID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BootstrapResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BootstrapResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BootstrapResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.PartID != 0 {
-		n += 1 + sovPspb(uint64(m.PartID))
+		i = encodeVarintPspb(dAtA, i, uint64(m.PartID))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PutRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PutRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *PutRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PutRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.Partid != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Partid))
+		i--
+		dAtA[i] = 0x28
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.Psversion != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+		i--
+		dAtA[i] = 0x20
 	}
 	if m.ExpiresAt != 0 {
-		n += 1 + sovPspb(uint64(m.ExpiresAt))
+		i = encodeVarintPspb(dAtA, i, uint64(m.ExpiresAt))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.Psversion != 0 {
-		n += 1 + sovPspb(uint64(m.Psversion))
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Partid != 0 {
-		n += 1 + sovPspb(uint64(m.Partid))
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *PutResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PutResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	return dAtA[:n], nil
+}
+
+func (m *PutResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PutResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DeleteRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.Partid != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Partid))
+		i--
+		dAtA[i] = 0x28
 	}
 	if m.Psversion != 0 {
-		n += 1 + sovPspb(uint64(m.Psversion))
+		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.Partid != 0 {
-		n += 1 + sovPspb(uint64(m.Partid))
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DeleteResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DeleteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
-	}
 	if m.Psversion != 0 {
-		n += 1 + sovPspb(uint64(m.Psversion))
+		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+		i--
+		dAtA[i] = 0x10
 	}
-	return n
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *GetRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.Partid != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Partid))
+		i--
+		dAtA[i] = 0x28
 	}
 	if m.Psversion != 0 {
-		n += 1 + sovPspb(uint64(m.Psversion))
+		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.Partid != 0 {
-		n += 1 + sovPspb(uint64(m.Partid))
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *GetResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *GetResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GetResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *RequestOp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RequestOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.Request != nil {
-		n += m.Request.Size()
+		{
+			size := m.Request.Size()
+			i -= size
+			if _, err := m.Request.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *RequestOp_RequestPut) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
+func (m *RequestOp_RequestPut) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestOp_RequestPut) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	if m.RequestPut != nil {
-		l = m.RequestPut.Size()
-		n += 1 + l + sovPspb(uint64(l))
+		{
+			size, err := m.RequestPut.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *RequestOp_RequestDelete) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.RequestDelete != nil {
-		l = m.RequestDelete.Size()
-		n += 1 + l + sovPspb(uint64(l))
-	}
-	return n
+func (m *RequestOp_RequestDelete) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (m *RequestOp_RequestGet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
+
+func (m *RequestOp_RequestDelete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.RequestDelete != nil {
+		{
+			size, err := m.RequestDelete.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *RequestOp_RequestGet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestOp_RequestGet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	if m.RequestGet != nil {
-		l = m.RequestGet.Size()
-		n += 1 + l + sovPspb(uint64(l))
+		{
+			size, err := m.RequestGet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *ResponseOp) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ResponseOp) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseOp) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseOp) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if m.Response != nil {
-		n += m.Response.Size()
+		{
+			size := m.Response.Size()
+			i -= size
+			if _, err := m.Response.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *ResponseOp_ResponsePut) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
+func (m *ResponseOp_ResponsePut) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseOp_ResponsePut) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	if m.ResponsePut != nil {
-		l = m.ResponsePut.Size()
-		n += 1 + l + sovPspb(uint64(l))
+		{
+			size, err := m.ResponsePut.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *ResponseOp_ResponseDelete) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
+func (m *ResponseOp_ResponseDelete) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseOp_ResponseDelete) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	if m.ResponseDelete != nil {
-		l = m.ResponseDelete.Size()
-		n += 1 + l + sovPspb(uint64(l))
+		{
+			size, err := m.ResponseDelete.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *ResponseOp_ResponseGet) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
+func (m *ResponseOp_ResponseGet) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseOp_ResponseGet) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
 	if m.ResponseGet != nil {
-		l = m.ResponseGet.Size()
-		n += 1 + l + sovPspb(uint64(l))
+		{
+			size, err := m.ResponseGet.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPspb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
 	}
-	return n
+	return len(dAtA) - i, nil
 }
-func (m *BatchRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if len(m.Req) > 0 {
-		for _, e := range m.Req {
-			l = e.Size()
-			n += 1 + l + sovPspb(uint64(l))
+		for iNdEx := len(m.Req) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Req[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPspb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *BatchResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
 	if len(m.Res) > 0 {
-		for _, e := range m.Res {
-			l = e.Size()
-			n += 1 + l + sovPspb(uint64(l))
+		for iNdEx := len(m.Res) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Res[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPspb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
 		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *RangeRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *RangeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *RangeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RangeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Prefix)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.WithValues {
+		i--
+		if m.WithValues {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
 	}
-	l = len(m.Start)
-	if l > 0 {
-		n += 1 + l + sovPspb(uint64(l))
+	if m.Psversion != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Psversion))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Partid != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Partid))
+		i--
+		dAtA[i] = 0x20
 	}
 	if m.Limit != 0 {
-		n += 1 + sovPspb(uint64(m.Limit))
+		i = encodeVarintPspb(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
 	}
-	if m.Partid != 0 {
-		n += 1 + sovPspb(uint64(m.Partid))
+	if len(m.Start) > 0 {
+		i -= len(m.Start)
+		copy(dAtA[i:], m.Start)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Start)))
+		i--
+		dAtA[i] = 0x12
 	}
-	if m.Psversion != 0 {
-		n += 1 + sovPspb(uint64(m.Psversion))
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintPspb(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RangeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RangeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RangeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Values[iNdEx])
+			copy(dAtA[i:], m.Values[iNdEx])
+			i = encodeVarintPspb(dAtA, i, uint64(len(m.Values[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Keys) > 0 {
+		for iNdEx := len(m.Keys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Keys[iNdEx])
+			copy(dAtA[i:], m.Keys[iNdEx])
+			i = encodeVarintPspb(dAtA, i, uint64(len(m.Keys[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Truncated != 0 {
+		i = encodeVarintPspb(dAtA, i, uint64(m.Truncated))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintPspb(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPspb(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *MixedLog) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Offsets) > 0 {
+		l = 0
+		for _, e := range m.Offsets {
+			l += sovPspb(uint64(e))
+		}
+		n += 1 + sovPspb(uint64(l)) + l
+	}
+	if m.Compression != 0 {
+		n += 1 + sovPspb(uint64(m.Compression))
+	}
+	if m.CompressedSize != 0 {
+		n += 1 + sovPspb(uint64(m.CompressedSize))
 	}
 	return n
 }
 
-func (m *RangeResponse) Size() (n int) {
+func (m *Range) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Truncated != 0 {
-		n += 1 + sovPspb(uint64(m.Truncated))
+	l = len(m.StartKey)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	l = len(m.EndKey)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *Location) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ExtentID != 0 {
+		n += 1 + sovPspb(uint64(m.ExtentID))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovPspb(uint64(m.Offset))
+	}
+	return n
+}
+
+func (m *BlobStreams) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Blob) > 0 {
+		l = 0
+		for _, e := range m.Blob {
+			l += sovPspb(uint64(e))
+		}
+		n += 1 + sovPspb(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *DiscardStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Discard) > 0 {
+		for k, v := range m.Discard {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + sovPspb(uint64(k)) + 1 + sovPspb(uint64(v))
+			n += mapEntrySize + 1 + sovPspb(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *TableLocations) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Locs) > 0 {
+		for _, e := range m.Locs {
+			l = e.Size()
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DataKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.KeyID != 0 {
+		n += 1 + sovPspb(uint64(m.KeyID))
+	}
+	if m.MasterKeyID != 0 {
+		n += 1 + sovPspb(uint64(m.MasterKeyID))
+	}
+	l = len(m.Wrapped)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.CreatedAt != 0 {
+		n += 1 + sovPspb(uint64(m.CreatedAt))
+	}
+	return n
+}
+
+func (m *DataKeys) Size() (n int) {
+	if m == nil {
+		return 0
 	}
+	var l int
+	_ = l
 	if len(m.Keys) > 0 {
-		for _, b := range m.Keys {
-			l = len(b)
+		for _, e := range m.Keys {
+			l = e.Size()
 			n += 1 + l + sovPspb(uint64(l))
 		}
 	}
 	return n
 }
 
-func sovPspb(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *PartitionOptions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemtableSize != 0 {
+		n += 1 + sovPspb(uint64(m.MemtableSize))
+	}
+	if m.WriteChCapacity != 0 {
+		n += 1 + sovPspb(uint64(m.WriteChCapacity))
+	}
+	if m.MaxReplaySize != 0 {
+		n += 1 + sovPspb(uint64(m.MaxReplaySize))
+	}
+	if m.FlushInterval != 0 {
+		n += 1 + sovPspb(uint64(m.FlushInterval))
+	}
+	if m.TableBlockSize != 0 {
+		n += 1 + sovPspb(uint64(m.TableBlockSize))
+	}
+	if m.ValueThreshold != 0 {
+		n += 1 + sovPspb(uint64(m.ValueThreshold))
+	}
+	if m.MixedBlockSize != 0 {
+		n += 1 + sovPspb(uint64(m.MixedBlockSize))
+	}
+	return n
 }
-func sozPspb(x uint64) (n int) {
-	return sovPspb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *PartitionMeta) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Blobs != nil {
+		l = m.Blobs.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.LogStream != 0 {
+		n += 1 + sovPspb(uint64(m.LogStream))
+	}
+	if m.RowStream != 0 {
+		n += 1 + sovPspb(uint64(m.RowStream))
+	}
+	if m.Locs != nil {
+		l = m.Locs.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.Parent != 0 {
+		n += 1 + sovPspb(uint64(m.Parent))
+	}
+	l = len(m.Discard)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.Rg != nil {
+		l = m.Rg.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.PartID != 0 {
+		n += 1 + sovPspb(uint64(m.PartID))
+	}
+	if m.Keys != nil {
+		l = m.Keys.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.Opts != nil {
+		l = m.Opts.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
 }
-func (m *MixedLog) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPspb
+
+func (m *PSDetail) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PSID != 0 {
+		n += 1 + sovPspb(uint64(m.PSID))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *RegionInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Rg != nil {
+		l = m.Rg.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.PartID != 0 {
+		n += 1 + sovPspb(uint64(m.PartID))
+	}
+	if m.PSID != 0 {
+		n += 1 + sovPspb(uint64(m.PSID))
+	}
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *RawBlockMeta) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovPspb(uint64(m.Type))
+	}
+	if m.CompressedSize != 0 {
+		n += 1 + sovPspb(uint64(m.CompressedSize))
+	}
+	if m.UnCompressedSize != 0 {
+		n += 1 + sovPspb(uint64(m.UnCompressedSize))
+	}
+	if m.VpExtentID != 0 {
+		n += 1 + sovPspb(uint64(m.VpExtentID))
+	}
+	if m.VpOffset != 0 {
+		n += 1 + sovPspb(uint64(m.VpOffset))
+	}
+	if m.SeqNum != 0 {
+		n += 1 + sovPspb(uint64(m.SeqNum))
+	}
+	if m.Compression != 0 {
+		n += 1 + sovPspb(uint64(m.Compression))
+	}
+	if m.KeyID != 0 {
+		n += 1 + sovPspb(uint64(m.KeyID))
+	}
+	if m.EncryptedSize != 0 {
+		n += 1 + sovPspb(uint64(m.EncryptedSize))
+	}
+	return n
+}
+
+func (m *BlockOffset) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.ExtentID != 0 {
+		n += 1 + sovPspb(uint64(m.ExtentID))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovPspb(uint64(m.Offset))
+	}
+	return n
+}
+
+func (m *TableIndex) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Offsets) > 0 {
+		for _, e := range m.Offsets {
+			l = e.Size()
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	l = len(m.BloomFilter)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.EstimatedSize != 0 {
+		n += 1 + sovPspb(uint64(m.EstimatedSize))
+	}
+	if m.NumOfBlocks != 0 {
+		n += 1 + sovPspb(uint64(m.NumOfBlocks))
+	}
+	if m.IndexLoc != nil {
+		l = m.IndexLoc.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.BloomLoc != nil {
+		l = m.BloomLoc.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	l = len(m.Smallest)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	l = len(m.Biggest)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *GetPartitionMetaRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PSID != 0 {
+		n += 1 + sovPspb(uint64(m.PSID))
+	}
+	return n
+}
+
+func (m *GetPartitionMetaResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPspb(uint64(m.Code))
+	}
+	if len(m.Meta) > 0 {
+		for _, e := range m.Meta {
+			l = e.Size()
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetRowStreamTablesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PartitionID != 0 {
+		n += 1 + sovPspb(uint64(m.PartitionID))
+	}
+	if m.Locs != nil {
+		l = m.Locs.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *SetRowStreamTablesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPspb(uint64(m.Code))
+	}
+	return n
+}
+
+func (m *SetDataKeysRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PartitionID != 0 {
+		n += 1 + sovPspb(uint64(m.PartitionID))
+	}
+	if m.Keys != nil {
+		l = m.Keys.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *SetDataKeysResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPspb(uint64(m.Code))
+	}
+	return n
+}
+
+func (m *SetDiscardStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PartitionID != 0 {
+		n += 1 + sovPspb(uint64(m.PartitionID))
+	}
+	if m.Stats != nil {
+		l = m.Stats.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *SetDiscardStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPspb(uint64(m.Code))
+	}
+	return n
+}
+
+func (m *SetBlobStreamsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PartitionID != 0 {
+		n += 1 + sovPspb(uint64(m.PartitionID))
+	}
+	if m.Blobs != nil {
+		l = m.Blobs.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *SetBlobStreamsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPspb(uint64(m.Code))
+	}
+	return n
+}
+
+func (m *SetPartitionOptionsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PartitionID != 0 {
+		n += 1 + sovPspb(uint64(m.PartitionID))
+	}
+	if m.Opts != nil {
+		l = m.Opts.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *SetPartitionOptionsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPspb(uint64(m.Code))
+	}
+	return n
+}
+
+func (m *GetRegionsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetRegionsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPspb(uint64(m.Code))
+	}
+	if len(m.Regions) > 0 {
+		for _, e := range m.Regions {
+			l = e.Size()
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RegisterPSRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Addr)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *RegisterPSResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovPspb(uint64(m.Code))
+	}
+	if m.Id != 0 {
+		n += 1 + sovPspb(uint64(m.Id))
+	}
+	return n
+}
+
+func (m *GetPSInfoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetPSInfoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Servers) > 0 {
+		for _, e := range m.Servers {
+			l = e.Size()
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BootstrapRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogID != 0 {
+		n += 1 + sovPspb(uint64(m.LogID))
+	}
+	if m.RowID != 0 {
+		n += 1 + sovPspb(uint64(m.RowID))
+	}
+	if m.Parent != 0 {
+		n += 1 + sovPspb(uint64(m.Parent))
+	}
+	if m.BlobID != 0 {
+		n += 1 + sovPspb(uint64(m.BlobID))
+	}
+	return n
+}
+
+func (m *BootstrapResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PartID != 0 {
+		n += 1 + sovPspb(uint64(m.PartID))
+	}
+	return n
+}
+
+func (m *PutRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.ExpiresAt != 0 {
+		n += 1 + sovPspb(uint64(m.ExpiresAt))
+	}
+	if m.Psversion != 0 {
+		n += 1 + sovPspb(uint64(m.Psversion))
+	}
+	if m.Partid != 0 {
+		n += 1 + sovPspb(uint64(m.Partid))
+	}
+	return n
+}
+
+func (m *PutResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *DeleteRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.Psversion != 0 {
+		n += 1 + sovPspb(uint64(m.Psversion))
+	}
+	if m.Partid != 0 {
+		n += 1 + sovPspb(uint64(m.Partid))
+	}
+	return n
+}
+
+func (m *DeleteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.Psversion != 0 {
+		n += 1 + sovPspb(uint64(m.Psversion))
+	}
+	return n
+}
+
+func (m *GetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.Psversion != 0 {
+		n += 1 + sovPspb(uint64(m.Psversion))
+	}
+	if m.Partid != 0 {
+		n += 1 + sovPspb(uint64(m.Partid))
+	}
+	return n
+}
+
+func (m *GetResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+
+func (m *RequestOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Request != nil {
+		n += m.Request.Size()
+	}
+	return n
+}
+
+func (m *RequestOp_RequestPut) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestPut != nil {
+		l = m.RequestPut.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+func (m *RequestOp_RequestDelete) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestDelete != nil {
+		l = m.RequestDelete.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+func (m *RequestOp_RequestGet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequestGet != nil {
+		l = m.RequestGet.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+func (m *ResponseOp) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Response != nil {
+		n += m.Response.Size()
+	}
+	return n
+}
+
+func (m *ResponseOp_ResponsePut) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResponsePut != nil {
+		l = m.ResponsePut.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+func (m *ResponseOp_ResponseDelete) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResponseDelete != nil {
+		l = m.ResponseDelete.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+func (m *ResponseOp_ResponseGet) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ResponseGet != nil {
+		l = m.ResponseGet.Size()
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	return n
+}
+func (m *BatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Req) > 0 {
+		for _, e := range m.Req {
+			l = e.Size()
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BatchResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Res) > 0 {
+		for _, e := range m.Res {
+			l = e.Size()
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RangeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	l = len(m.Start)
+	if l > 0 {
+		n += 1 + l + sovPspb(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovPspb(uint64(m.Limit))
+	}
+	if m.Partid != 0 {
+		n += 1 + sovPspb(uint64(m.Partid))
+	}
+	if m.Psversion != 0 {
+		n += 1 + sovPspb(uint64(m.Psversion))
+	}
+	if m.WithValues {
+		n += 2
+	}
+	return n
+}
+
+func (m *RangeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Truncated != 0 {
+		n += 1 + sovPspb(uint64(m.Truncated))
+	}
+	if len(m.Keys) > 0 {
+		for _, b := range m.Keys {
+			l = len(b)
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	if len(m.Values) > 0 {
+		for _, b := range m.Values {
+			l = len(b)
+			n += 1 + l + sovPspb(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovPspb(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozPspb(x uint64) (n int) {
+	return sovPspb(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *MixedLog) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MixedLog: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MixedLog: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPspb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Offsets = append(m.Offsets, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPspb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPspb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPspb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Offsets) == 0 {
+					m.Offsets = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPspb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Offsets = append(m.Offsets, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offsets", wireType)
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			m.Compression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compression |= CompressionType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedSize", wireType)
+			}
+			m.CompressedSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompressedSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Range) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Range: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Range: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.StartKey == nil {
+				m.StartKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EndKey == nil {
+				m.EndKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Location) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Location: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Location: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
+			}
+			m.ExtentID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExtentID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlobStreams) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlobStreams: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlobStreams: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPspb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Blob = append(m.Blob, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPspb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPspb
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthPspb
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Blob) == 0 {
+					m.Blob = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPspb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Blob = append(m.Blob, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiscardStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiscardStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiscardStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Discard", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Discard == nil {
+				m.Discard = make(map[uint64]int64)
+			}
+			var mapkey uint64
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPspb
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPspb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPspb
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPspb(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthPspb
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Discard[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TableLocations) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TableLocations: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TableLocations: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Locs = append(m.Locs, &Location{})
+			if err := m.Locs[len(m.Locs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DataKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DataKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DataKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyID", wireType)
+			}
+			m.KeyID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.KeyID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MasterKeyID", wireType)
+			}
+			m.MasterKeyID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MasterKeyID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Wrapped", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Wrapped = append(m.Wrapped[:0], dAtA[iNdEx:postIndex]...)
+			if m.Wrapped == nil {
+				m.Wrapped = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DataKeys) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DataKeys: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DataKeys: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keys = append(m.Keys, &DataKey{})
+			if err := m.Keys[len(m.Keys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PartitionOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PartitionOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PartitionOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemtableSize", wireType)
+			}
+			m.MemtableSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemtableSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WriteChCapacity", wireType)
+			}
+			m.WriteChCapacity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WriteChCapacity |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxReplaySize", wireType)
+			}
+			m.MaxReplaySize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxReplaySize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FlushInterval", wireType)
+			}
+			m.FlushInterval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FlushInterval |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableBlockSize", wireType)
+			}
+			m.TableBlockSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableBlockSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueThreshold", wireType)
+			}
+			m.ValueThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValueThreshold |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MixedBlockSize", wireType)
+			}
+			m.MixedBlockSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MixedBlockSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PartitionMeta: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PartitionMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blobs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Blobs == nil {
+				m.Blobs = &BlobStreams{}
+			}
+			if err := m.Blobs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogStream", wireType)
+			}
+			m.LogStream = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LogStream |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RowStream", wireType)
+			}
+			m.RowStream = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RowStream |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Locs == nil {
+				m.Locs = &TableLocations{}
+			}
+			if err := m.Locs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+			}
+			m.Parent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Parent |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Discard", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Discard = append(m.Discard[:0], dAtA[iNdEx:postIndex]...)
+			if m.Discard == nil {
+				m.Discard = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Rg == nil {
+				m.Rg = &Range{}
+			}
+			if err := m.Rg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartID", wireType)
+			}
+			m.PartID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PartID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Keys == nil {
+				m.Keys = &DataKeys{}
+			}
+			if err := m.Keys.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Opts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Opts == nil {
+				m.Opts = &PartitionOptions{}
+			}
+			if err := m.Opts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PSDetail) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PSDetail: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PSDetail: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PSID", wireType)
+			}
+			m.PSID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PSID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RegionInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegionInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegionInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rg", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Rg == nil {
+				m.Rg = &Range{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: MixedLog: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MixedLog: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType == 0 {
-				var v uint32
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPspb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint32(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if err := m.Rg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartID", wireType)
+			}
+			m.PartID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
 				}
-				m.Offsets = append(m.Offsets, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPspb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPspb
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PartID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPspb
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PSID", wireType)
+			}
+			m.PSID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PSID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				elementCount = count
-				if elementCount != 0 && len(m.Offsets) == 0 {
-					m.Offsets = make([]uint32, 0, elementCount)
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
 				}
-				for iNdEx < postIndex {
-					var v uint32
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPspb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Offsets = append(m.Offsets, v)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offsets", wireType)
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Addr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPspb(dAtA[iNdEx:])
@@ -4966,7 +8393,7 @@ func (m *MixedLog) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Range) Unmarshal(dAtA []byte) error {
+func (m *RawBlockMeta) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4989,17 +8416,17 @@ func (m *Range) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Range: wiretype end group for non-group")
+			return fmt.Errorf("proto: RawBlockMeta: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Range: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RawBlockMeta: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var byteLen int
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5009,31 +8436,92 @@ func (m *Range) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.Type |= RawBlockType(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthPspb
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedSize", wireType)
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPspb
+			m.CompressedSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompressedSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnCompressedSize", wireType)
 			}
-			m.StartKey = append(m.StartKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.StartKey == nil {
-				m.StartKey = []byte{}
+			m.UnCompressedSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnCompressedSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VpExtentID", wireType)
+			}
+			m.VpExtentID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VpExtentID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VpOffset", wireType)
+			}
+			m.VpOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.VpOffset |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeqNum", wireType)
 			}
-			var byteLen int
+			m.SeqNum = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5043,84 +8531,35 @@ func (m *Range) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.SeqNum |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthPspb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPspb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.EndKey = append(m.EndKey[:0], dAtA[iNdEx:postIndex]...)
-			if m.EndKey == nil {
-				m.EndKey = []byte{}
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPspb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthPspb
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthPspb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Location) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPspb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Compression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compression |= CompressionType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Location: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Location: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyID", wireType)
 			}
-			m.ExtentID = 0
+			m.KeyID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5130,16 +8569,16 @@ func (m *Location) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExtentID |= uint64(b&0x7F) << shift
+				m.KeyID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 9:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedSize", wireType)
 			}
-			m.Offset = 0
+			m.EncryptedSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5149,7 +8588,7 @@ func (m *Location) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Offset |= uint32(b&0x7F) << shift
+				m.EncryptedSize |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5178,7 +8617,7 @@ func (m *Location) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlobStreams) Unmarshal(dAtA []byte) error {
+func (m *BlockOffset) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5201,146 +8640,70 @@ func (m *BlobStreams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlobStreams: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockOffset: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlobStreams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockOffset: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPspb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Blob = append(m.Blob, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowPspb
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthPspb
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthPspb
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Blob) == 0 {
-					m.Blob = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowPspb
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Blob = append(m.Blob, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Blob", wireType)
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPspb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPspb
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthPspb
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *TableLocations) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPspb
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ExtentID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExtentID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: TableLocations: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TableLocations: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locs", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
 			}
-			var msglen int
+			m.Offset = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5350,26 +8713,11 @@ func (m *TableLocations) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Offset |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPspb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPspb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Locs = append(m.Locs, &Location{})
-			if err := m.Locs[len(m.Locs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPspb(dAtA[iNdEx:])
@@ -5394,7 +8742,7 @@ func (m *TableLocations) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
+func (m *TableIndex) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5417,15 +8765,15 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PartitionMeta: wiretype end group for non-group")
+			return fmt.Errorf("proto: TableIndex: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PartitionMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TableIndex: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Blobs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Offsets", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5452,18 +8800,16 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Blobs == nil {
-				m.Blobs = &BlobStreams{}
-			}
-			if err := m.Blobs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Offsets = append(m.Offsets, &BlockOffset{})
+			if err := m.Offsets[len(m.Offsets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogStream", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BloomFilter", wireType)
 			}
-			m.LogStream = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5473,16 +8819,31 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LogStream |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BloomFilter = append(m.BloomFilter[:0], dAtA[iNdEx:postIndex]...)
+			if m.BloomFilter == nil {
+				m.BloomFilter = []byte{}
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RowStream", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EstimatedSize", wireType)
 			}
-			m.RowStream = 0
+			m.EstimatedSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5492,14 +8853,33 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RowStream |= uint64(b&0x7F) << shift
+				m.EstimatedSize |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumOfBlocks", wireType)
+			}
+			m.NumOfBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NumOfBlocks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexLoc", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5526,18 +8906,18 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Locs == nil {
-				m.Locs = &TableLocations{}
+			if m.IndexLoc == nil {
+				m.IndexLoc = &Location{}
 			}
-			if err := m.Locs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.IndexLoc.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parent", wireType)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BloomLoc", wireType)
 			}
-			m.Parent = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5547,14 +8927,31 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Parent |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BloomLoc == nil {
+				m.BloomLoc = &Location{}
+			}
+			if err := m.BloomLoc.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Discard", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Smallest", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5581,16 +8978,16 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Discard = append(m.Discard[:0], dAtA[iNdEx:postIndex]...)
-			if m.Discard == nil {
-				m.Discard = []byte{}
+			m.Smallest = append(m.Smallest[:0], dAtA[iNdEx:postIndex]...)
+			if m.Smallest == nil {
+				m.Smallest = []byte{}
 			}
 			iNdEx = postIndex
-		case 7:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Biggest", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5600,47 +8997,26 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthPspb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPspb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Rg == nil {
-				m.Rg = &Range{}
-			}
-			if err := m.Rg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Biggest = append(m.Biggest[:0], dAtA[iNdEx:postIndex]...)
+			if m.Biggest == nil {
+				m.Biggest = []byte{}
 			}
 			iNdEx = postIndex
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PartID", wireType)
-			}
-			m.PartID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.PartID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPspb(dAtA[iNdEx:])
@@ -5665,7 +9041,7 @@ func (m *PartitionMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PSDetail) Unmarshal(dAtA []byte) error {
+func (m *GetPartitionMetaRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5688,10 +9064,10 @@ func (m *PSDetail) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PSDetail: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetPartitionMetaRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PSDetail: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetPartitionMetaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -5713,38 +9089,6 @@ func (m *PSDetail) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPspb
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPspb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Address = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPspb(dAtA[iNdEx:])
@@ -5769,7 +9113,7 @@ func (m *PSDetail) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RegionInfo) Unmarshal(dAtA []byte) error {
+func (m *GetPartitionMetaResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5792,72 +9136,17 @@ func (m *RegionInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RegionInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetPartitionMetaResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RegionInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetPartitionMetaResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Rg", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthPspb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPspb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Rg == nil {
-				m.Rg = &Range{}
-			}
-			if err := m.Rg.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PartID", wireType)
-			}
-			m.PartID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.PartID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PSID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.PSID = 0
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5867,16 +9156,16 @@ func (m *RegionInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PSID |= uint64(b&0x7F) << shift
+				m.Code |= pb.Code(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5886,23 +9175,25 @@ func (m *RegionInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPspb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPspb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addr = string(dAtA[iNdEx:postIndex])
+			m.Meta = append(m.Meta, &PartitionMeta{})
+			if err := m.Meta[len(m.Meta)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5928,7 +9219,7 @@ func (m *RegionInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RawBlockMeta) Unmarshal(dAtA []byte) error {
+func (m *SetRowStreamTablesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5951,17 +9242,17 @@ func (m *RawBlockMeta) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RawBlockMeta: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetRowStreamTablesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RawBlockMeta: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetRowStreamTablesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionID", wireType)
 			}
-			m.Type = 0
+			m.PartitionID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5971,16 +9262,16 @@ func (m *RawBlockMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= RawBlockType(b&0x7F) << shift
+				m.PartitionID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CompressedSize", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locs", wireType)
 			}
-			m.CompressedSize = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -5990,73 +9281,86 @@ func (m *RawBlockMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CompressedSize |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnCompressedSize", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
 			}
-			m.UnCompressedSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.UnCompressedSize |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VpExtentID", wireType)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			m.VpExtentID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.VpExtentID |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if m.Locs == nil {
+				m.Locs = &TableLocations{}
 			}
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VpOffset", wireType)
+			if err := m.Locs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.VpOffset = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.VpOffset |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-		case 6:
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetRowStreamTablesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetRowStreamTablesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetRowStreamTablesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SeqNum", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.SeqNum = 0
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -6066,7 +9370,7 @@ func (m *RawBlockMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SeqNum |= uint64(b&0x7F) << shift
+				m.Code |= pb.Code(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6095,7 +9399,7 @@ func (m *RawBlockMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BlockOffset) Unmarshal(dAtA []byte) error {
+func (m *SetDataKeysRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6118,17 +9422,17 @@ func (m *BlockOffset) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BlockOffset: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetDataKeysRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BlockOffset: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetDataKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionID", wireType)
 			}
-			var byteLen int
+			m.PartitionID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -6138,31 +9442,16 @@ func (m *BlockOffset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.PartitionID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthPspb
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPspb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExtentID", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-			m.ExtentID = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -6172,16 +9461,86 @@ func (m *BlockOffset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ExtentID |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			if msglen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Keys == nil {
+				m.Keys = &DataKeys{}
+			}
+			if err := m.Keys.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetDataKeysResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetDataKeysResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetDataKeysResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.Offset = 0
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -6191,7 +9550,7 @@ func (m *BlockOffset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Offset |= uint32(b&0x7F) << shift
+				m.Code |= pb.Code(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6220,7 +9579,7 @@ func (m *BlockOffset) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TableIndex) Unmarshal(dAtA []byte) error {
+func (m *SetDiscardStatsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6243,17 +9602,17 @@ func (m *TableIndex) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TableIndex: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetDiscardStatsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TableIndex: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetDiscardStatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offsets", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionID", wireType)
 			}
-			var msglen int
+			m.PartitionID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -6263,31 +9622,16 @@ func (m *TableIndex) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.PartitionID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPspb
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthPspb
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Offsets = append(m.Offsets, &BlockOffset{})
-			if err := m.Offsets[len(m.Offsets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BloomFilter", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -6297,64 +9641,28 @@ func (m *TableIndex) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPspb
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPspb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BloomFilter = append(m.BloomFilter[:0], dAtA[iNdEx:postIndex]...)
-			if m.BloomFilter == nil {
-				m.BloomFilter = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EstimatedSize", wireType)
-			}
-			m.EstimatedSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.EstimatedSize |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NumOfBlocks", wireType)
+			if m.Stats == nil {
+				m.Stats = &DiscardStats{}
 			}
-			m.NumOfBlocks = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPspb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.NumOfBlocks |= uint32(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.Stats.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPspb(dAtA[iNdEx:])
@@ -6379,7 +9687,7 @@ func (m *TableIndex) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetPartitionMetaRequest) Unmarshal(dAtA []byte) error {
+func (m *SetDiscardStatsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6402,17 +9710,17 @@ func (m *GetPartitionMetaRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetPartitionMetaRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetDiscardStatsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetPartitionMetaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetDiscardStatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PSID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
 			}
-			m.PSID = 0
+			m.Code = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -6422,7 +9730,7 @@ func (m *GetPartitionMetaRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PSID |= uint64(b&0x7F) << shift
+				m.Code |= pb.Code(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6451,7 +9759,7 @@ func (m *GetPartitionMetaRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetPartitionMetaResponse) Unmarshal(dAtA []byte) error {
+func (m *SetBlobStreamsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6474,17 +9782,17 @@ func (m *GetPartitionMetaResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetPartitionMetaResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetBlobStreamsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetPartitionMetaResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetBlobStreamsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionID", wireType)
 			}
-			m.Code = 0
+			m.PartitionID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPspb
@@ -6494,14 +9802,14 @@ func (m *GetPartitionMetaResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= pb.Code(b&0x7F) << shift
+				m.PartitionID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blobs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6528,8 +9836,10 @@ func (m *GetPartitionMetaResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Meta = append(m.Meta, &PartitionMeta{})
-			if err := m.Meta[len(m.Meta)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Blobs == nil {
+				m.Blobs = &BlobStreams{}
+			}
+			if err := m.Blobs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -6557,7 +9867,7 @@ func (m *GetPartitionMetaResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetRowStreamTablesRequest) Unmarshal(dAtA []byte) error {
+func (m *SetBlobStreamsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6580,10 +9890,82 @@ func (m *SetRowStreamTablesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetRowStreamTablesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetBlobStreamsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetRowStreamTablesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetBlobStreamsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= pb.Code(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPspb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetPartitionOptionsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPspb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetPartitionOptionsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetPartitionOptionsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6607,7 +9989,7 @@ func (m *SetRowStreamTablesRequest) Unmarshal(dAtA []byte) error {
 			}
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Locs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Opts", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6634,10 +10016,10 @@ func (m *SetRowStreamTablesRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Locs == nil {
-				m.Locs = &TableLocations{}
+			if m.Opts == nil {
+				m.Opts = &PartitionOptions{}
 			}
-			if err := m.Locs.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Opts.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -6665,7 +10047,7 @@ func (m *SetRowStreamTablesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetRowStreamTablesResponse) Unmarshal(dAtA []byte) error {
+func (m *SetPartitionOptionsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6688,10 +10070,10 @@ func (m *SetRowStreamTablesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetRowStreamTablesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetPartitionOptionsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetRowStreamTablesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetPartitionOptionsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7298,6 +10680,25 @@ func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlobID", wireType)
+			}
+			m.BlobID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlobID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPspb(dAtA[iNdEx:])
@@ -8780,6 +12181,26 @@ func (m *RangeRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WithValues", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WithValues = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPspb(dAtA[iNdEx:])
@@ -8884,6 +12305,38 @@ func (m *RangeResponse) Unmarshal(dAtA []byte) error {
 			m.Keys = append(m.Keys, make([]byte, postIndex-iNdEx))
 			copy(m.Keys[len(m.Keys)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPspb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthPspb
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPspb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, make([]byte, postIndex-iNdEx))
+			copy(m.Values[len(m.Values)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPspb(dAtA[iNdEx:])
@@ -0,0 +1,65 @@
+package rangepartition
+
+import (
+	"time"
+
+	"github.com/journeymidnight/autumn/utils"
+	"github.com/journeymidnight/autumn/xlog"
+)
+
+/*
+checkpoint:
+打开partition时从最新table记录的(VpExtentID, VpOffset)开始replay log, 如果很久没有flush memtable, 需要replay整个log
+1. 上次flush之后写入log的数据超过opt.MaxReplaySize时, 下一次写之前强制flush memtable
+2. memtable存在超过opt.FlushInterval时, 通过writeCh发送一个空的request, 由写线程强制flush memtable(没有写入的时候也会flush)
+3. flush出来的table记录了replay起点, table的位置保存在PM里, 这就是checkpoint; 有blob stream时,
+checkpoint之前的log stream会被truncate
+*/
+
+//needCheckpoint returns true if mt has to be flushed to bound replay, called with rp.Lock
+func (rp *RangePartition) needCheckpoint() bool {
+	if rp.mt.Empty() {
+		return false
+	}
+	if rp.opt.MaxReplaySize > 0 && rp.logSize >= rp.opt.MaxReplaySize {
+		return true
+	}
+	return rp.opt.FlushInterval > 0 && time.Since(rp.mtCreated) >= rp.opt.FlushInterval
+}
+
+//mtExpired returns true if mt is older than opt.FlushInterval
+func (rp *RangePartition) mtExpired() bool {
+	rp.RLock()
+	defer rp.RUnlock()
+	return !rp.mt.Empty() && time.Since(rp.mtCreated) >= rp.opt.FlushInterval
+}
+
+//startCheckpoint flushes mt when it is older than opt.FlushInterval even if there is no write
+func (rp *RangePartition) startCheckpoint() {
+	rp.checkpointStopper = utils.NewStopper()
+	if rp.opt.FlushInterval <= 0 {
+		return
+	}
+	rp.checkpointStopper.RunWorker(func() {
+		ticker := time.NewTicker(rp.opt.FlushInterval / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-rp.checkpointStopper.ShouldStop():
+				return
+			}
+			if !rp.mtExpired() {
+				continue
+			}
+			//the writer calls ensureRoomForWrite, which flushes mt
+			req, err := rp.sendToWriteCh(nil)
+			if err != nil {
+				return
+			}
+			if err = req.Wait(); err != nil {
+				xlog.Logger.Warnf("checkpoint of partition %d: %v", rp.PartID, err)
+			}
+		}
+	})
+}
@@ -2,8 +2,11 @@ package rangepartition
 
 import (
 	"context"
+	"sort"
+	"sync/atomic"
 	"time"
 
+	"github.com/journeymidnight/autumn/proto/pspb"
 	"github.com/journeymidnight/autumn/rangepartition/skiplist"
 	"github.com/journeymidnight/autumn/rangepartition/table"
 	"github.com/journeymidnight/autumn/rangepartition/y"
@@ -11,23 +14,275 @@ import (
 	"github.com/journeymidnight/autumn/xlog"
 )
 
+/*
+compaction采用universal(size-tiered)策略:
+1. 一次compaction的输出(LastSeq相同的多个table)是一个sorted run, 按照LastSeq从旧到新排列所有run
+2. size amplification: 除了最旧的run, 其他run的大小之和超过最旧的run(compactMaxSizeAmp), compact所有table
+3. size ratio: 从最新的run开始向旧的方向合并, 如果旧的run的大小不超过已选run大小之和的compactSizeRatio倍,
+就加入, 选出至少compactMinMerge个run时compact它们
+4. run的个数超过compactMaxRuns, compact最新的几个run, 使run的个数不超过compactMaxRuns
+5. 包括最旧run的compaction是major compaction, 删除tombstone和过期的key; 其他是minor compaction, 保留tombstone
+6. compaction的table是row stream最前面的table时, 等待这些table(以及之前compact掉的table)的引用计数都变成0,
+也就是没有reader再读它们之后, truncate row stream
+7. run的个数超过slowdownWritesRuns时, 每次写延迟几ms; 超过stopWritesRuns时, 写等待compaction
+*/
+
+const (
+	compactMinMerge    = 4
+	compactSizeRatio   = 1.2
+	compactMaxSizeAmp  = 1.0
+	compactMaxRuns     = 12
+	slowdownWritesRuns = 20
+	stopWritesRuns     = 32
+)
+
 func (rp *RangePartition) startCompact() {
 	rp.compactStopper = utils.NewStopper()
+	rp.compactCh = make(chan struct{}, 1)
 	rp.compactStopper.RunWorker(rp.compact)
 }
 
+//triggerCompact is called after memtable is flushed
+func (rp *RangePartition) triggerCompact() {
+	if rp.compactCh == nil {
+		return
+	}
+	select {
+	case rp.compactCh <- struct{}{}:
+	default:
+	}
+}
+
 func (rp *RangePartition) compact() {
-	randTicker := utils.NewRandomTicker(10*time.Minute, 20*time.Minute)
+	randTicker := utils.NewRandomTicker(10*time.Second, 20*time.Second)
+	defer randTicker.Stop()
 	for {
 		select {
 		// Can add a done channel or other stuff.
 		case <-randTicker.C:
-			//pick tables
-			//doCompact
+		case <-rp.compactCh:
 		case <-rp.compactStopper.ShouldStop():
 			return
 		}
+		for {
+			select {
+			case <-rp.compactStopper.ShouldStop():
+				return
+			default:
+			}
+			rp.tableLock.RLock()
+			tbls, major := pickTables(rp.tables)
+			for _, t := range tbls {
+				t.IncrRef()
+			}
+			rp.tableLock.RUnlock()
+			if len(tbls) == 0 {
+				break
+			}
+			xlog.Logger.Infof("compact %d tables of partition %d, major: %v", len(tbls), rp.PartID, major)
+			rp.doCompact(tbls, major)
+			rp.removeTables(tbls)
+		}
+	}
+}
+
+type sortedRun struct {
+	tables []*table.Table
+	size   uint64
+}
+
+//sortedRuns groups tables by LastSeq, returns runs from the oldest to the newest
+func sortedRuns(tables []*table.Table) []sortedRun {
+	tbls := make([]*table.Table, len(tables))
+	copy(tbls, tables)
+	sort.SliceStable(tbls, func(i, j int) bool {
+		return tbls[i].LastSeq < tbls[j].LastSeq
+	})
+	var runs []sortedRun
+	for i, t := range tbls {
+		if i == 0 || t.LastSeq != tbls[i-1].LastSeq {
+			runs = append(runs, sortedRun{})
+		}
+		run := &runs[len(runs)-1]
+		run.tables = append(run.tables, t)
+		run.size += t.EstimatedSize()
 	}
+	return runs
+}
+
+//pickTables returns tables to be compacted and whether it is a major compaction
+func pickTables(tables []*table.Table) ([]*table.Table, bool) {
+	runs := sortedRuns(tables)
+	sizes := make([]uint64, len(runs))
+	for i := range runs {
+		sizes[i] = runs[i].size
+	}
+	start := pickRuns(sizes)
+	if start < 0 {
+		return nil, false
+	}
+	var tbls []*table.Table
+	for _, run := range runs[start:] {
+		tbls = append(tbls, run.tables...)
+	}
+	return tbls, start == 0
+}
+
+//pickRuns returns the first of the newest runs to be compacted, -1 means no compaction.
+//sizes are sizes of runs from the oldest to the newest
+func pickRuns(sizes []uint64) int {
+	n := len(sizes)
+	if n < 2 {
+		return -1
+	}
+
+	//size amplification
+	var newer uint64
+	for _, size := range sizes[1:] {
+		newer += size
+	}
+	if float64(newer) >= float64(sizes[0])*compactMaxSizeAmp {
+		return 0
+	}
+
+	//size ratio
+	start := n - 1
+	size := sizes[start]
+	for start > 0 && float64(sizes[start-1]) <= float64(size)*compactSizeRatio {
+		start--
+		size += sizes[start]
+	}
+	if n-start >= compactMinMerge {
+		return start
+	}
+
+	//too many runs
+	if n > compactMaxRuns {
+		return compactMaxRuns - 1
+	}
+	return -1
+}
+
+//numOfRuns is the compaction debt
+func (rp *RangePartition) numOfRuns() int {
+	rp.tableLock.RLock()
+	defer rp.tableLock.RUnlock()
+	return len(sortedRuns(rp.tables))
+}
+
+//throttleWrites delays writes if compaction can not catch up
+func (rp *RangePartition) throttleWrites() {
+	for i := 0; ; i++ {
+		n := rp.numOfRuns()
+		if n < slowdownWritesRuns {
+			return
+		}
+		if n < stopWritesRuns {
+			time.Sleep(time.Duration(n-slowdownWritesRuns+1) * time.Millisecond)
+			return
+		}
+		select {
+		case <-rp.writeStopper.ShouldStop():
+			return
+		default:
+		}
+		if i%100 == 0 {
+			xlog.Logger.Infof("writes of partition %d are stopped, %d sorted runs are waiting for compaction", rp.PartID, n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+//removeTables removes compacted tables from rp.tables and saves table locations in PM.
+//If they are the first tables in row stream, row stream is truncated after they are released
+func (rp *RangePartition) removeTables(tbls []*table.Table) {
+	if len(tbls) == 0 {
+		return
+	}
+	compacted := make(map[*table.Table]bool)
+	for _, t := range tbls {
+		compacted[t] = true
+	}
+
+	rp.tableLock.Lock()
+	var newTables []*table.Table
+	var last *table.Table
+	prefix := true
+	for _, t := range rp.tables {
+		if compacted[t] {
+			if len(newTables) > 0 {
+				prefix = false
+			}
+			last = t
+			continue
+		}
+		newTables = append(newTables, t)
+	}
+	var tableLocs []*pspb.Location
+	for _, t := range newTables {
+		tableLocs = append(tableLocs, &t.Loc)
+	}
+	rp.updateTableLocs(tableLocs)
+	rp.tables = newTables
+	//tables removed before may be still read, they are also in front of last
+	rp.obsoleteTables = append(rp.obsoleteTables, tbls...)
+	obsolete := make([]*table.Table, len(rp.obsoleteTables))
+	copy(obsolete, rp.obsoleteTables)
+	rp.tableLock.Unlock()
+
+	//release rp.tables's ref, readers may still hold refs
+	for _, t := range tbls {
+		t.DecrRef()
+	}
+
+	if !prefix || last == nil {
+		return
+	}
+	seq := atomic.AddUint64(&rp.truncateSeq, 1)
+	extentID := last.Loc.ExtentID
+	rp.truncateStopper.RunWorker(func() {
+		rp.truncateRowStream(seq, extentID, obsolete)
+	})
+}
+
+//truncateRowStream waits until all obsolete tables are released, and truncates row stream before
+//extentID(last table's meta extent). seq is the order of truncation, an older truncation is skipped
+//if a newer one has been done
+func (rp *RangePartition) truncateRowStream(seq uint64, extentID uint64, obsolete []*table.Table) {
+	for _, t := range obsolete {
+		select {
+		case <-t.Released():
+		case <-rp.truncateStopper.ShouldStop():
+			//extents are truncated by next compaction after partition is opened again
+			return
+		}
+	}
+
+	rp.truncateLock.Lock()
+	defer rp.truncateLock.Unlock()
+	if seq <= rp.truncatedSeq {
+		return
+	}
+	_, _, err := rp.rowStream.Truncate(context.Background(), extentID)
+	if err != nil {
+		xlog.Logger.Infof("row stream of partition %d is not truncated: %v", rp.PartID, err)
+		return
+	}
+	//FIXME: send frontStream/endStream to sm
+	rp.truncatedSeq = seq
+
+	//forget released tables
+	rp.tableLock.Lock()
+	var remain []*table.Table
+	for _, t := range rp.obsoleteTables {
+		select {
+		case <-t.Released():
+		default:
+			remain = append(remain, t)
+		}
+	}
+	rp.obsoleteTables = remain
+	rp.tableLock.Unlock()
 }
 
 //tbls已经inc
@@ -42,10 +297,17 @@ func (rp *RangePartition) doCompact(tbls []*table.Table, major bool) {
 	}()
 	//tbls的顺序是在stream里面的顺序
 
+	//merge iterator keeps the first one of the same keys, newer tables must be in front, because
+	//value log gc rewrites values with the same version
+	sorted := make([]*table.Table, len(tbls))
+	copy(sorted, tbls)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].LastSeq > sorted[j].LastSeq
+	})
 	var iters []y.Iterator
 	var maxSeq uint64
 	var head valuePointer
-	for _, table := range tbls {
+	for _, table := range sorted {
 		if table.LastSeq > maxSeq {
 			maxSeq = table.LastSeq
 			head = valuePointer{extentID: table.VpExtentID, offset: table.VpOffset}
@@ -58,20 +320,19 @@ func (rp *RangePartition) doCompact(tbls []*table.Table, major bool) {
 
 	it.Rewind()
 
-	//FIXME
-	discardStats := make(map[uint32]int64)
+	discardStats := make(map[uint64]int64)
 	updateStats := func(vs y.ValueStruct) {
 		if vs.Meta&y.BitValuePointer > 0 { //big Value
 			var vp valuePointer
 			vp.Decode(vs.Value)
-			discardStats[uint32(vp.extentID)] += int64(vp.len)
+			discardStats[vp.extentID] += int64(vp.len)
 		}
 	}
 
 	var numBuilds int
 	resultCh := make(chan struct{})
 	//ignore keep multiple versions and snapshot support
-	capacity := int64(2 * maxSkipList)
+	capacity := 2 * rp.opt.MaxSkipList
 	for it.Valid() {
 		var skipKey []byte
 		timeStart := time.Now()
@@ -119,14 +380,11 @@ func (rp *RangePartition) doCompact(tbls []*table.Table, major bool) {
 		<-resultCh
 	}
 
-	eID := tbls[len(tbls)-1].Loc.ExtentID
-
-	//last table's meta extentd
-	_, _, err := rp.rowStream.Truncate(context.Background(), eID)
-	if err == nil {
-		_ = err
-		//FIXME: send frontStream/endStream to sm
+	if len(discardStats) > 0 {
+		rp.discard.UpdateDiscardStats(discardStats)
+		rp.saveDiscardStats()
 	}
+
 }
 
 func isDeletedOrExpired(meta byte, expiresAt uint64) bool {
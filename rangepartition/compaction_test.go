@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/journeymidnight/autumn/manager/pmclient"
+	"github.com/journeymidnight/autumn/proto/pspb"
 	"github.com/journeymidnight/autumn/rangepartition/table"
 	"github.com/journeymidnight/autumn/streamclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCompaction(t *testing.T) {
@@ -18,20 +22,35 @@ func TestCompaction(t *testing.T) {
 	defer logStream.Close()
 	defer rowStream.Close()
 
-	rp := OpenRangePartition(3, rowStream, logStream, logStream.(streamclient.BlockReader),
-		[]byte(""), []byte(""), nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock)
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
 	defer rp.Close()
+	//stop background compaction, tables are compacted by test
+	rp.compactStopper.Stop()
+	defer rp.startCompact()
 
 	var wg sync.WaitGroup
-	for i := 0; i < 1000; i++ {
+	//about 3 memtables
+	for i := 0; i < 4000; i++ {
 		wg.Add(1)
 		k := fmt.Sprintf("%04d", i)
-		v := fmt.Sprintf("%d", i)
+		v := fmt.Sprintf("%01000d", i)
 		rp.WriteAsync([]byte(k), []byte(v), func(e error) {
 			wg.Done()
 		})
 	}
 	wg.Wait()
+	//wait for flushing
+	for {
+		rp.RLock()
+		n := len(rp.imm)
+		rp.RUnlock()
+		if n == 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.True(t, rp.numOfRuns() > 1)
 
 	var tbls []*table.Table
 
@@ -42,6 +61,45 @@ func TestCompaction(t *testing.T) {
 	}
 	rp.tableLock.RUnlock()
 
+	//a reader holds the old table
+	it := tbls[0].NewIterator(false)
 	rp.doCompact(tbls, true)
+	rp.removeTables(tbls)
 	fmt.Printf("%d\n", len(rp.tables))
+	assert.Equal(t, 1, rp.numOfRuns())
+
+	select {
+	case <-tbls[0].Released():
+		t.Fatalf("table is released while it is read")
+	default:
+	}
+	for _, tbl := range tbls[1:] {
+		<-tbl.Released()
+	}
+	it.Close()
+	<-tbls[0].Released()
+
+	for i := 0; i < 4000; i += 100 {
+		v, err := rp.Get([]byte(fmt.Sprintf("%04d", i)), 0)
+		require.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("%01000d", i), string(v))
+	}
+}
+
+func TestPickRuns(t *testing.T) {
+	//newer runs are larger than the oldest, major compaction
+	assert.Equal(t, 0, pickRuns([]uint64{100, 60, 50}))
+	//only one run
+	assert.Equal(t, -1, pickRuns([]uint64{100}))
+	//newest 4 runs have similar sizes
+	assert.Equal(t, 1, pickRuns([]uint64{1000, 10, 10, 10, 10}))
+	//not enough similar runs
+	assert.Equal(t, -1, pickRuns([]uint64{1000, 100, 30, 10}))
+
+	//too many runs
+	sizes := []uint64{1 << 40}
+	for i := 0; i < compactMaxRuns; i++ {
+		sizes = append(sizes, uint64(1)<<uint(30-2*i))
+	}
+	assert.Equal(t, compactMaxRuns-1, pickRuns(sizes))
 }
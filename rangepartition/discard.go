@@ -2,62 +2,114 @@ package rangepartition
 
 import (
 	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/proto/pspb"
 	"github.com/journeymidnight/autumn/utils"
 )
 
+//discardManager records discarded bytes of each extent in log stream and blob streams
 type discardManager struct {
-	streams         map[uint64]pb.StreamInfo
-	reverseIndex    map[uint64][]uint64 //extentID=>[stream1, stream2]
-	discardOfStream map[uint64]int64
+	streams      map[uint64]pb.StreamInfo //blob streams
+	reverseIndex map[uint64][]uint64      //extentID=>[stream1, stream2]
+	discard      map[uint64]int64         //extentID=>discarded bytes, saved in PM
+	//extents checked by gc but not rewritten, extentID=>discarded bytes when checked
+	checked map[uint64]int64
 	utils.SafeMutex
 }
 
-func NewDiscardManager(si map[uint64]pb.StreamInfo) *discardManager {
+func NewDiscardManager(si map[uint64]pb.StreamInfo, stats *pspb.DiscardStats) *discardManager {
 	dsm := &discardManager{
-		reverseIndex:    make(map[uint64][]uint64),
-		discardOfStream: make(map[uint64]int64),
+		streams:      make(map[uint64]pb.StreamInfo),
+		reverseIndex: make(map[uint64][]uint64),
+		discard:      make(map[uint64]int64),
+		checked:      make(map[uint64]int64),
 	}
 
-	dsm.streams = si
-	for streamID, extentIDs := range si {
-		for _, extentID := range extentIDs.ExtentIDs {
+	for streamID, streamInfo := range si {
+		dsm.streams[streamID] = streamInfo
+		for _, extentID := range streamInfo.ExtentIDs {
 			dsm.reverseIndex[extentID] = append(dsm.reverseIndex[extentID], streamID)
 		}
 	}
+	if stats != nil {
+		for extentID, discard := range stats.Discard {
+			dsm.discard[extentID] = discard
+		}
+	}
 	return dsm
 }
 
 //input : map[exteintID]=>len(free data)
 func (dsm *discardManager) UpdateDiscardStats(stats map[uint64]int64) {
+	dsm.Lock()
+	defer dsm.Unlock()
+	for extentID, discard := range stats {
+		dsm.discard[extentID] += discard
+	}
+}
+
+//Stats returns a copy of discard stats to be saved in PM
+func (dsm *discardManager) Stats() *pspb.DiscardStats {
 	dsm.RLock()
 	defer dsm.RUnlock()
-	for extentID, discard := range stats {
-		for _, streamID := range dsm.reverseIndex[extentID] {
-			dsm.discardOfStream[streamID] += discard
-		}
+	stats := &pspb.DiscardStats{Discard: make(map[uint64]int64, len(dsm.discard))}
+	for extentID, discard := range dsm.discard {
+		stats.Discard[extentID] = discard
 	}
+	return stats
+}
 
+func (dsm *discardManager) Discard(extentID uint64) int64 {
+	dsm.RLock()
+	defer dsm.RUnlock()
+	return dsm.discard[extentID]
 }
 
+//MaxDiscard returns the blob stream which has the most discarded bytes
 func (dsm *discardManager) MaxDiscard() *pb.StreamInfo {
 	dsm.RLock()
 	defer dsm.RUnlock()
 	maxDiscard := int64(0)
 	maxStreamID := uint64(0)
-	if len(dsm.discardOfStream) == 0 {
-		return nil
-	}
-	for streamID, discard := range dsm.discardOfStream {
+	for streamID, si := range dsm.streams {
+		var discard int64
+		for _, extentID := range si.ExtentIDs {
+			discard += dsm.discard[extentID]
+		}
 		if maxDiscard < discard {
 			maxStreamID = streamID
 			maxDiscard = discard
 		}
-
+	}
+	if maxStreamID == 0 {
+		return nil
 	}
 	ret := dsm.streams[maxStreamID]
 	return &ret
 }
 
+//BlobStreamInfos returns copies of blob stream infos
+func (dsm *discardManager) BlobStreamInfos() []pb.StreamInfo {
+	dsm.RLock()
+	defer dsm.RUnlock()
+	var ret []pb.StreamInfo
+	for _, si := range dsm.streams {
+		si.ExtentIDs = append([]uint64(nil), si.ExtentIDs...)
+		ret = append(ret, si)
+	}
+	return ret
+}
+
+//BlobStreams returns IDs of blob streams
+func (dsm *discardManager) BlobStreams() []uint64 {
+	dsm.RLock()
+	defer dsm.RUnlock()
+	var ret []uint64
+	for streamID := range dsm.streams {
+		ret = append(ret, streamID)
+	}
+	return ret
+}
+
 func (dsm *discardManager) AddBlobStream(si pb.StreamInfo) {
 	dsm.Lock()
 	defer dsm.Unlock()
@@ -65,6 +117,66 @@ func (dsm *discardManager) AddBlobStream(si pb.StreamInfo) {
 	dsm.streams[si.StreamID] = si
 
 	for _, extentID := range si.ExtentIDs {
-		dsm.reverseIndex[extentID] = append(dsm.reverseIndex[extentID], extentID)
+		dsm.reverseIndex[extentID] = append(dsm.reverseIndex[extentID], si.StreamID)
+	}
+}
+
+//UpdateBlobStream replaces blob stream info after it is truncated, a blob stream without
+//extents is removed
+func (dsm *discardManager) UpdateBlobStream(si pb.StreamInfo) {
+	dsm.Lock()
+	defer dsm.Unlock()
+	old, ok := dsm.streams[si.StreamID]
+	if !ok {
+		return
+	}
+	for _, extentID := range old.ExtentIDs {
+		dsm.removeIndex(extentID, si.StreamID)
+	}
+	if len(si.ExtentIDs) == 0 {
+		delete(dsm.streams, si.StreamID)
+		return
+	}
+	dsm.streams[si.StreamID] = si
+	for _, extentID := range si.ExtentIDs {
+		dsm.reverseIndex[extentID] = append(dsm.reverseIndex[extentID], si.StreamID)
+	}
+}
+
+func (dsm *discardManager) removeIndex(extentID uint64, streamID uint64) {
+	var streams []uint64
+	for _, id := range dsm.reverseIndex[extentID] {
+		if id != streamID {
+			streams = append(streams, id)
+		}
+	}
+	if len(streams) == 0 {
+		delete(dsm.reverseIndex, extentID)
+		return
 	}
+	dsm.reverseIndex[extentID] = streams
+}
+
+//RemoveExtent forgets an extent after it is reclaimed by gc
+func (dsm *discardManager) RemoveExtent(extentID uint64) {
+	dsm.Lock()
+	defer dsm.Unlock()
+	delete(dsm.discard, extentID)
+	delete(dsm.checked, extentID)
+}
+
+//SetChecked records that extent is checked by gc when its discard is discard
+func (dsm *discardManager) SetChecked(extentID uint64, discard int64) {
+	dsm.Lock()
+	defer dsm.Unlock()
+	dsm.checked[extentID] = discard
+}
+
+//NeedCheck returns false if the extent has been checked by gc, and no value in it is discarded
+//after that
+func (dsm *discardManager) NeedCheck(extentID uint64) bool {
+	dsm.RLock()
+	defer dsm.RUnlock()
+	discard, ok := dsm.checked[extentID]
+	return !ok || dsm.discard[extentID] > discard
 }
@@ -0,0 +1,319 @@
+package encryption
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/y"
+	"github.com/journeymidnight/autumn/utils"
+	"github.com/pkg/errors"
+)
+
+/*
+加密流程:
+1. ps从本地key file读取master key, 每行"{masterKeyID} {64位hex}", ID最大的是当前的master key
+2. 每个partition有自己的data key(AES-256), 用master key做AES-GCM wrap之后保存在PM的PART/{PartID}/keys,
+master key不离开ps
+3. table的data block和meta block先压缩再加密, RawBlockMeta.KeyID记录data key, 0表示没有加密
+4. log entry的key和value分别加密, key的前8个字节是keyID, Meta设置BitEncrypted. extent node只看到密文,
+但是仍然可以解析entry
+5. 新的数据总是用最新的data key, rotate之后, 旧的table通过compaction重写, 旧的log通过value log gc重写
+*/
+
+const (
+	keySize   = 32
+	nonceSize = 12
+	keyIDSize = 8
+)
+
+var errNoKey = errors.New("no such data key")
+
+//MasterKeys are loaded from local key file, and only used to wrap data keys
+type MasterKeys struct {
+	keys    map[uint32]cipher.AEAD
+	current uint32
+}
+
+//LoadMasterKeys reads key file, each line is "{masterKeyID} {hex of 32 bytes key}",
+//empty lines and lines starting with # are ignored
+func LoadMasterKeys(fileName string) (*MasterKeys, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mk := &MasterKeys{keys: make(map[uint32]cipher.AEAD)}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("invalid line in key file %s", fileName)
+		}
+		id, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil || id == 0 {
+			return nil, errors.Errorf("invalid master key id %s", fields[0])
+		}
+		key, err := hex.DecodeString(fields[1])
+		if err != nil || len(key) != keySize {
+			return nil, errors.Errorf("master key %d must be %d bytes in hex", id, keySize)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		mk.keys[uint32(id)] = aead
+		if uint32(id) > mk.current {
+			mk.current = uint32(id)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(mk.keys) == 0 {
+		return nil, errors.Errorf("no master key in %s", fileName)
+	}
+	return mk, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+//seal returns nonce + ciphertext
+func seal(aead cipher.AEAD, plaintext []byte, dst []byte) ([]byte, error) {
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(append(dst, nonce...), nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < nonceSize {
+		return nil, errors.Errorf("ciphertext is too short")
+	}
+	return aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+}
+
+func (mk *MasterKeys) wrap(keyID uint64, dataKey []byte) (*pspb.DataKey, error) {
+	wrapped, err := seal(mk.keys[mk.current], dataKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pspb.DataKey{
+		KeyID:       keyID,
+		MasterKeyID: mk.current,
+		Wrapped:     wrapped,
+		CreatedAt:   time.Now().Unix(),
+	}, nil
+}
+
+func (mk *MasterKeys) unwrap(dk *pspb.DataKey) ([]byte, error) {
+	aead, ok := mk.keys[dk.MasterKeyID]
+	if !ok {
+		return nil, errors.Errorf("master key %d of data key %d is not in key file", dk.MasterKeyID, dk.KeyID)
+	}
+	key, err := open(aead, dk.Wrapped)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unwrap data key %d", dk.KeyID)
+	}
+	return key, nil
+}
+
+//KeyRegistry holds all data keys of a partition
+type KeyRegistry struct {
+	utils.SafeMutex
+	master  *MasterKeys
+	wrapped *pspb.DataKeys
+	keys    map[uint64]cipher.AEAD
+	current uint64 //0 means no data key yet
+}
+
+//OpenKeyRegistry unwraps data keys stored in PM, dataKeys could be nil
+func OpenKeyRegistry(master *MasterKeys, dataKeys *pspb.DataKeys) (*KeyRegistry, error) {
+	r := &KeyRegistry{
+		master:  master,
+		wrapped: &pspb.DataKeys{},
+		keys:    make(map[uint64]cipher.AEAD),
+	}
+	if dataKeys == nil {
+		return r, nil
+	}
+	for _, dk := range dataKeys.Keys {
+		key, err := master.unwrap(dk)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		r.keys[dk.KeyID] = aead
+		if dk.KeyID > r.current {
+			r.current = dk.KeyID
+		}
+	}
+	r.wrapped = proto.Clone(dataKeys).(*pspb.DataKeys)
+	return r, nil
+}
+
+//Current returns ID of the data key for new data
+func (r *KeyRegistry) Current() uint64 {
+	r.RLock()
+	defer r.RUnlock()
+	return r.current
+}
+
+//NeedRotate returns true if there is no data key, or the current key is older than period
+func (r *KeyRegistry) NeedRotate(period time.Duration) bool {
+	r.RLock()
+	defer r.RUnlock()
+	for _, dk := range r.wrapped.Keys {
+		if dk.KeyID == r.current {
+			return time.Since(time.Unix(dk.CreatedAt, 0)) > period
+		}
+	}
+	return true
+}
+
+//Rotate generates a new data key, the key is used only after persist succeeds
+func (r *KeyRegistry) Rotate(persist func(*pspb.DataKeys) error) error {
+	r.Lock()
+	defer r.Unlock()
+
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return err
+	}
+	keyID := r.current + 1
+	dk, err := r.master.wrap(keyID, key)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	wrapped := proto.Clone(r.wrapped).(*pspb.DataKeys)
+	wrapped.Keys = append(wrapped.Keys, dk)
+	if err = persist(wrapped); err != nil {
+		return err
+	}
+	r.wrapped = wrapped
+	r.keys[keyID] = aead
+	r.current = keyID
+	return nil
+}
+
+func (r *KeyRegistry) aead(keyID uint64) (cipher.AEAD, error) {
+	//ps is started without master key
+	if r == nil {
+		return nil, errNoKey
+	}
+	r.RLock()
+	defer r.RUnlock()
+	aead, ok := r.keys[keyID]
+	if !ok {
+		return nil, errNoKey
+	}
+	return aead, nil
+}
+
+//Encrypt encrypts data with the current key, returns keyID and nonce + ciphertext
+func (r *KeyRegistry) Encrypt(data []byte) (uint64, []byte, error) {
+	r.RLock()
+	keyID := r.current
+	aead, ok := r.keys[keyID]
+	r.RUnlock()
+	if !ok {
+		return 0, nil, errNoKey
+	}
+	out, err := seal(aead, data, nil)
+	return keyID, out, err
+}
+
+//Decrypt decrypts data returned by Encrypt
+func (r *KeyRegistry) Decrypt(keyID uint64, data []byte) ([]byte, error) {
+	aead, err := r.aead(keyID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "key %d", keyID)
+	}
+	return open(aead, data)
+}
+
+//EncryptEntry returns a copy of entry whose key is keyID + nonce + ciphertext and value is
+//nonce + ciphertext
+func (r *KeyRegistry) EncryptEntry(entry *pb.Entry) (*pb.Entry, error) {
+	r.RLock()
+	keyID := r.current
+	aead, ok := r.keys[keyID]
+	r.RUnlock()
+	if !ok {
+		return nil, errNoKey
+	}
+	var prefix [keyIDSize]byte
+	binary.BigEndian.PutUint64(prefix[:], keyID)
+	key, err := seal(aead, entry.Key, prefix[:])
+	if err != nil {
+		return nil, err
+	}
+	value, err := seal(aead, entry.Value, nil)
+	if err != nil {
+		return nil, err
+	}
+	ret := *entry
+	ret.Key = key
+	ret.Value = value
+	ret.Meta |= uint32(y.BitEncrypted)
+	return &ret, nil
+}
+
+//DecryptEntry decrypts entry in place, value could be nil if it is a big value returned by extent node
+func (r *KeyRegistry) DecryptEntry(entry *pb.Entry) error {
+	if entry.Meta&uint32(y.BitEncrypted) == 0 {
+		return nil
+	}
+	if len(entry.Key) < keyIDSize {
+		return errors.Errorf("encrypted key is too short")
+	}
+	keyID := binary.BigEndian.Uint64(entry.Key)
+	aead, err := r.aead(keyID)
+	if err != nil {
+		return errors.Wrapf(err, "key %d", keyID)
+	}
+	key, err := open(aead, entry.Key[keyIDSize:])
+	if err != nil {
+		return err
+	}
+	var value []byte
+	if entry.Value != nil {
+		if value, err = open(aead, entry.Value); err != nil {
+			return err
+		}
+	}
+	entry.Key = key
+	entry.Value = value
+	entry.Meta &^= uint32(y.BitEncrypted)
+	return nil
+}
@@ -0,0 +1,73 @@
+package encryption
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyFile = "# test keys\n1 000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f\n" +
+	"2 1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100\n"
+
+func TestKeyRegistry(t *testing.T) {
+	f, err := ioutil.TempFile("", "masterkey")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(testKeyFile)
+	require.NoError(t, err)
+	f.Close()
+
+	mk, err := LoadMasterKeys(f.Name())
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), mk.current)
+
+	r, err := OpenKeyRegistry(mk, nil)
+	require.NoError(t, err)
+	require.True(t, r.NeedRotate(time.Hour))
+	_, err = r.EncryptEntry(&pb.Entry{Key: []byte("key")})
+	require.Equal(t, errNoKey, err)
+
+	var saved *pspb.DataKeys
+	persist := func(keys *pspb.DataKeys) error {
+		saved = keys
+		return nil
+	}
+	require.NoError(t, r.Rotate(persist))
+	require.Equal(t, uint64(1), r.Current())
+	require.False(t, r.NeedRotate(time.Hour))
+
+	entry := &pb.Entry{Key: []byte("key"), Value: []byte("value"), Meta: 1}
+	encrypted, err := r.EncryptEntry(entry)
+	require.NoError(t, err)
+	require.NotContains(t, string(encrypted.Key), "key")
+	require.NotContains(t, string(encrypted.Value), "value")
+
+	//entries encrypted by old key are still readable after rotation
+	require.NoError(t, r.Rotate(persist))
+	require.Equal(t, uint64(2), r.Current())
+	require.Equal(t, 2, len(saved.Keys))
+
+	//reopen from keys saved in PM
+	r, err = OpenKeyRegistry(mk, saved)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), r.Current())
+	require.NoError(t, r.DecryptEntry(encrypted))
+	require.Equal(t, entry, encrypted)
+
+	keyID, data, err := r.Encrypt([]byte("block"))
+	require.NoError(t, err)
+	data[len(data)-1] ^= 0xff
+	_, err = r.Decrypt(keyID, data)
+	require.Error(t, err)
+
+	//no registry
+	var nilRegistry *KeyRegistry
+	encrypted, err = r.EncryptEntry(entry)
+	require.NoError(t, err)
+	require.Error(t, nilRegistry.DecryptEntry(encrypted))
+}
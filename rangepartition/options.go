@@ -0,0 +1,92 @@
+package rangepartition
+
+import (
+	"time"
+
+	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/table"
+	"github.com/journeymidnight/autumn/streamclient"
+	"github.com/pkg/errors"
+)
+
+//Options of a range partition, ps opens partitions with its options overridden by
+//PartitionOptions saved in PM(PART/{id}/options)
+type Options struct {
+	//size of memtable, a full memtable is flushed into a table
+	MaxSkipList int64
+	//capacity of write channel, a batch of writes has about 3*WriteChCapacity entries
+	WriteChCapacity int
+	//mt is flushed if log written after last flush is more than MaxReplaySize, or it is older
+	//than FlushInterval, the flushed table records where to replay log, 0 means no limit
+	MaxReplaySize uint64
+	FlushInterval time.Duration
+	//data blocks of tables
+	Table table.Options
+	//blocks of log stream and blob stream, values bigger than Stream.ValueThreshold are
+	//written to blob stream and only their valuePointers are in memtable
+	Stream streamclient.Options
+}
+
+func DefaultOptions() Options {
+	return Options{
+		MaxSkipList:     64 * MB,
+		WriteChCapacity: 64,
+		MaxReplaySize:   256 * MB,
+		FlushInterval:   10 * time.Minute,
+		Table:           table.DefaultOptions(),
+		Stream:          streamclient.DefaultOptions(),
+	}
+}
+
+//WithOverrides returns opt whose fields are replaced by non-zero fields of opts, opts could be nil
+func (opt Options) WithOverrides(opts *pspb.PartitionOptions) Options {
+	if opts == nil {
+		return opt
+	}
+	if opts.MemtableSize > 0 {
+		opt.MaxSkipList = int64(opts.MemtableSize)
+	}
+	if opts.WriteChCapacity > 0 {
+		opt.WriteChCapacity = int(opts.WriteChCapacity)
+	}
+	if opts.MaxReplaySize > 0 {
+		opt.MaxReplaySize = opts.MaxReplaySize
+	}
+	if opts.FlushInterval > 0 {
+		opt.FlushInterval = time.Duration(opts.FlushInterval) * time.Second
+	}
+	if opts.TableBlockSize > 0 {
+		opt.Table.BlockSize = opts.TableBlockSize
+	}
+	if opts.ValueThreshold > 0 {
+		opt.Stream.ValueThreshold = opts.ValueThreshold
+	}
+	if opts.MixedBlockSize > 0 {
+		opt.Stream.MaxMixedBlockSize = opts.MixedBlockSize
+	}
+	return opt
+}
+
+//Validate returns error if a partition can not be opened with opt
+func (opt Options) Validate() error {
+	if opt.MaxSkipList < 1*MB {
+		return errors.Errorf("memtable size %d is less than 1MB", opt.MaxSkipList)
+	}
+	if opt.WriteChCapacity <= 0 {
+		return errors.Errorf("write channel capacity %d is not positive", opt.WriteChCapacity)
+	}
+	if opt.FlushInterval < 0 {
+		return errors.Errorf("flush interval %v is negative", opt.FlushInterval)
+	}
+	if opt.Table.BlockSize == 0 || opt.Table.MaxEntriesInBlock <= 0 {
+		return errors.Errorf("invalid table options %+v", opt.Table)
+	}
+	if opt.Stream.ValueThreshold == 0 || opt.Stream.MaxMixedBlockSize == 0 || opt.Stream.MaxEntriesInBlock <= 0 {
+		return errors.Errorf("invalid stream options %+v", opt.Stream)
+	}
+	//a batch of small values has to fit in an empty memtable
+	if int64(3*opt.WriteChCapacity)*int64(opt.Stream.ValueThreshold) > opt.MaxSkipList/2 {
+		return errors.Errorf("value threshold %d is too big for memtable of %d bytes", opt.Stream.ValueThreshold, opt.MaxSkipList)
+	}
+	return nil
+}
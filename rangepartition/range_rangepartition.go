@@ -2,7 +2,6 @@ package rangepartition
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"sort"
 	"sync"
@@ -14,6 +13,7 @@ import (
 	"github.com/journeymidnight/autumn/manager/pmclient"
 	"github.com/journeymidnight/autumn/proto/pb"
 	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
 	"github.com/journeymidnight/autumn/rangepartition/skiplist"
 	"github.com/journeymidnight/autumn/rangepartition/table"
 	"github.com/journeymidnight/autumn/rangepartition/y"
@@ -24,32 +24,40 @@ import (
 )
 
 const (
-	KB                = 1024
-	MB                = KB * 1024
-	maxEntriesInBlock = 100
-
-	//1 * MB for test
-	//60 * MB for production
-	maxSkipList = 1 * MB
-	//64 for production
-	//16 for test
-	writeChCapacity = 64
+	KB = 1024
+	MB = KB * 1024
+
+	//new data key is generated when partition is opened, old tables are rewritten by compaction
+	keyRotationPeriod = 30 * 24 * time.Hour
 )
 
 var (
-	errNoRoom         = errors.New("No room for write")
-	errNotFound       = errors.New("not found")
-	ErrBlockedWrites  = errors.New("Writes are blocked, possibly due to DropAll or Close")
-	maxEntriesInQueue = maxSkipList / (y.ValueThrottle + 20) / 2
+	errNoRoom        = errors.New("No room for write")
+	errNotFound      = errors.New("not found")
+	ErrBlockedWrites = errors.New("Writes are blocked, possibly due to DropAll or Close")
 )
 
 type OpenStreamFunc func(si pb.StreamInfo) streamclient.StreamClient
 type UpdateStreamFunc func([]pb.StreamInfo)
 
+//Deps of a partition which are created by ps, nil fields disable the features
+type Deps struct {
+	Keys       *encryption.KeyRegistry //nil if data is not encrypted
+	BlockCache *table.BlockCache       //shared by partitions of a ps, nil means no cache
+	ValueCache *ValueCache             //big values, shared by partitions of a ps, nil means no cache
+	IndexCache *table.IndexCache       //index and bloom filter of tables, nil means they are not evicted
+}
+
 type RangePartition struct {
-	discard *discardManager
+	discard    *discardManager
+	gcStopper  *utils.Stopper //nil if value log gc is not started
+	gcLogFront uint64         //the first extent of log stream checked by gc
 	//metaStream *streamclient.StreamClient //设定metadata结构
 	logStream streamclient.StreamClient
+	//big values are written to blobStream, logStream only has their valuePointers, nil for
+	//partitions bootstrapped without blob stream
+	blobStream streamclient.StreamClient
+	walCh      chan struct{} //notify truncating log stream after memtable is flushed
 	//从ValueStruct中得到的地址, 用blockReader读出来, 因为它可能在[logStream, []blobStreams]里面
 	//我们不需要读每个stream
 	blockReader streamclient.BlockReader
@@ -66,10 +74,18 @@ type RangePartition struct {
 	flushChan      chan flushTask
 	writeStopper   *utils.Stopper
 	compactStopper *utils.Stopper
+	compactCh      chan struct{}   //notify compaction after memtable is flushed
 	tableLock      utils.SafeMutex //protect tables
 	tables         []*table.Table
 	seqNumber      uint64
 
+	//tables removed by compaction, but may be still read, protected by tableLock
+	obsoleteTables  []*table.Table
+	truncateStopper *utils.Stopper
+	truncateLock    sync.Mutex
+	truncateSeq     uint64 //atomic
+	truncatedSeq    uint64
+
 	PartID   uint64
 	StartKey []byte
 	EndKey   []byte
@@ -78,24 +94,43 @@ type RangePartition struct {
 	closeOnce    sync.Once    // For closing DB only once.
 	vhead        valuePointer //vhead前的都在mt中
 	openStream   OpenStreamFunc
+	opt          Options
+	keys         *encryption.KeyRegistry //nil if data is not encrypted
+	blockCache   *table.BlockCache       //shared by partitions of a ps, nil means no cache
+	valueCache   *ValueCache             //big values, shared by partitions of a ps, nil means no cache
+	indexCache   *table.IndexCache       //index and bloom filter of tables, nil means they are not evicted
 	updateStream UpdateStreamFunc
+
+	//mt is flushed by checkpoint if log written after last flush is more than opt.MaxReplaySize,
+	//or it is older than opt.FlushInterval
+	logSize           uint64    //log written after last flush, only used by writer
+	mtCreated         time.Time //protected by rp.SafeMutex
+	checkpointStopper *utils.Stopper
 }
 
 //TODO
 //interface KV save some values
 
 func OpenRangePartition(id uint64, rowStream streamclient.StreamClient,
-	logStream streamclient.StreamClient, blockReader streamclient.BlockReader,
-	startKey []byte, endKey []byte, tableLocs []*pspb.Location, blobStreams []uint64,
+	logStream streamclient.StreamClient, blobStream streamclient.StreamClient, blockReader streamclient.BlockReader,
+	startKey []byte, endKey []byte, tableLocs []*pspb.Location, blobStreams []pb.StreamInfo, discard *pspb.DiscardStats,
 	pmclient pmclient.PMClient,
 	openStream OpenStreamFunc, updateStream UpdateStreamFunc,
+	deps Deps, opt Options,
 ) *RangePartition {
+	//big values are decided by the stream client
+	logStream.SetOptions(opt.Stream)
+	if blobStream != nil {
+		blobStream.SetOptions(opt.Stream)
+	}
 	rp := &RangePartition{
 		rowStream:    rowStream,
 		logStream:    logStream,
+		blobStream:   blobStream,
+		walCh:        make(chan struct{}, 1),
 		blockReader:  blockReader,
 		logRotates:   0,
-		mt:           skiplist.NewSkiplist(maxSkipList),
+		mt:           skiplist.NewSkiplist(opt.MaxSkipList),
 		imm:          nil,
 		blockWrites:  0,
 		StartKey:     startKey,
@@ -104,7 +139,24 @@ func OpenRangePartition(id uint64, rowStream streamclient.StreamClient,
 		PartID:       id,
 		openStream:   openStream,
 		updateStream: updateStream,
+		opt:          opt,
+		keys:         deps.Keys,
+		blockCache:   deps.BlockCache,
+		valueCache:   deps.ValueCache,
+		indexCache:   deps.IndexCache,
+
+		mtCreated: time.Now(),
+	}
+	blobs := make(map[uint64]pb.StreamInfo)
+	for _, si := range blobStreams {
+		blobs[si.StreamID] = si
 	}
+	if blobStream != nil {
+		si := blobStream.StreamInfo()
+		blobs[si.StreamID] = si
+	}
+	rp.discard = NewDiscardManager(blobs, discard)
+	rp.rotateKey()
 	rp.startMemoryFlush()
 
 	//replay log
@@ -112,7 +164,7 @@ func OpenRangePartition(id uint64, rowStream streamclient.StreamClient,
 	//tableLocs的顺序就是在logStream里面的顺序
 	for _, tLoc := range tableLocs {
 	retry:
-		tbl, err := table.OpenTable(rp.rowStream, tLoc.ExtentID, tLoc.Offset)
+		tbl, err := table.OpenTableWithOptions(rp.rowStream, rp.keys, rp.blockCache, rp.indexCache, tLoc.ExtentID, tLoc.Offset)
 		if err != nil {
 			xlog.Logger.Error(err)
 			time.Sleep(1 * time.Second)
@@ -143,8 +195,9 @@ func OpenRangePartition(id uint64, rowStream streamclient.StreamClient,
 
 		head := valuePointer{extentID: ei.ExtentID, offset: ei.Offset}
 
-		if y.ParseTs(ei.Log.Key) > rp.seqNumber {
-			rp.seqNumber++
+		//entries in a block are sorted by size, not by seqNum
+		if ts := y.ParseTs(ei.Log.Key); ts > rp.seqNumber {
+			rp.seqNumber = ts
 		}
 
 		i := 0
@@ -171,11 +224,15 @@ func OpenRangePartition(id uint64, rowStream streamclient.StreamClient,
 		*/
 		//fmt.Printf("replay %s, %d\n", ei.Log.Key, len(ei.Log.Value))
 		rp.writeToLSM([]*pb.EntryInfo{ei})
+		rp.logSize += uint64(ei.Log.Size())
+		//table flushed before next write replays from the last block, so log stream before
+		//it can be truncated
+		rp.vhead = head
 		return true, nil
 	}
 
 	if lastTable == nil {
-		replayLog(rp.logStream, 0, 0, true, replay)
+		replayLog(rp.logStream, rp.keys, 0, 0, true, replay)
 	} else {
 		fmt.Printf("replay log from vp offset [%d]\n", lastTable.VpOffset)
 		/*
@@ -184,14 +241,17 @@ func OpenRangePartition(id uint64, rowStream streamclient.StreamClient,
 				offset:   lastTable.VpOffset,
 			}
 		*/
-		replayLog(rp.logStream, lastTable.VpExtentID, lastTable.VpOffset, true, replay)
+		replayLog(rp.logStream, rp.keys, lastTable.VpExtentID, lastTable.VpOffset, true, replay)
 	}
 	fmt.Printf("replayed log number: %d\n", replayedLog)
 
 	//start real write
 	rp.startWriteLoop()
+	rp.startCheckpoint()
 
-	//do compactions:FIXME, doCompactions放到另一个goroutine里面执行
+	rp.truncateStopper = utils.NewStopper()
+	rp.startTruncateLog()
+	//compact all tables once at open, later compactions are picked by rp.compact
 	var tbls []*table.Table
 	rp.tableLock.RLock()
 	for _, t := range rp.tables {
@@ -200,45 +260,17 @@ func OpenRangePartition(id uint64, rowStream streamclient.StreamClient,
 	}
 	rp.tableLock.RUnlock()
 
-	if len(tbls) <= 1 {
-		return rp
-	}
-
-	rp.doCompact(tbls, true)
-
-	//remove tbls from rp.tables, and update etcd,
-	rp.tableLock.Lock()
-	var i, j int
-	var newTables []*table.Table
-	for i < len(tbls) && j < len(rp.tables) {
-		if tbls[i].LastSeq == rp.tables[j].LastSeq {
-			i++
-			j++ //同时存在
-		} else if tbls[i].LastSeq < rp.tables[j].LastSeq {
-			i++ //只在tbls存在
-		} else {
-			newTables = append(newTables, rp.tables[j])
-			rp.tables[j].IncrRef()
-			j++ //只在rp.tables存在
+	//a single table is rewritten only if its data key is rotated
+	if len(tbls) > 1 || (len(tbls) == 1 && rp.staleKey(tbls[0])) {
+		rp.doCompact(tbls, true)
+		rp.removeTables(tbls)
+	} else {
+		for _, t := range tbls {
+			t.DecrRef()
 		}
 	}
-	for ; j < len(rp.tables); j++ {
-		newTables = append(newTables, rp.tables[j])
-		rp.tables[j].IncrRef()
-	}
-
-	tableLocs = nil
-	for _, t := range newTables {
-		tableLocs = append(tableLocs, &t.Loc)
-	}
-	rp.updateTableLocs(tableLocs)
-
-	for _, t := range rp.tables {
-		t.DecrRef()
-	}
-	rp.tables = newTables
-	rp.tableLock.Unlock()
 
+	rp.startCompact()
 	return rp
 }
 
@@ -252,7 +284,7 @@ type flushTask struct {
 
 func (rp *RangePartition) startWriteLoop() {
 	rp.writeStopper = utils.NewStopper()
-	rp.writeCh = make(chan *request, writeChCapacity)
+	rp.writeCh = make(chan *request, rp.opt.WriteChCapacity)
 
 	rp.writeStopper.RunWorker(rp.doWrites)
 }
@@ -276,7 +308,7 @@ func (rp *RangePartition) handleFlushTask(ft flushTask) error {
 
 	iter := ft.mt.NewIterator()
 	defer iter.Close()
-	b := table.NewTableBuilder(rp.rowStream)
+	b := table.NewTableBuilderWithOptions(rp.rowStream, rp.opt.Table, rp.keys)
 	defer b.Close()
 
 	//var vp valuePointer
@@ -305,7 +337,7 @@ func (rp *RangePartition) handleFlushTask(ft flushTask) error {
 	}
 
 	//todo
-	tbl, err := table.OpenTable(rp.rowStream, id, offset)
+	tbl, err := table.OpenTableWithOptions(rp.rowStream, rp.keys, rp.blockCache, rp.indexCache, id, offset)
 	if err != nil {
 		xlog.Logger.Errorf("ERROR while opening table: %v", err)
 		return err
@@ -386,11 +418,36 @@ func (rp *RangePartition) flushMemtable() {
 
 		if ft.isCompact {
 			ft.resultCh <- struct{}{}
+		} else {
+			rp.triggerCompact()
+			rp.triggerTruncateLog()
 		}
 
 	}
 }
 
+//rotateKey generates a new data key if the current one is too old, keys are saved in PM
+//before they are used
+func (rp *RangePartition) rotateKey() {
+	if rp.keys == nil || !rp.keys.NeedRotate(keyRotationPeriod) {
+		return
+	}
+	err := rp.keys.Rotate(func(dataKeys *pspb.DataKeys) error {
+		return rp.pmClient.SetDataKeys(rp.PartID, dataKeys)
+	})
+	if err != nil {
+		//new data is encrypted by the old key, if there is no key, writes fail
+		xlog.Logger.Errorf("failed to rotate data key of partition %d: %v", rp.PartID, err)
+		return
+	}
+	xlog.Logger.Infof("partition %d uses data key %d", rp.PartID, rp.keys.Current())
+}
+
+//staleKey returns true if table is not encrypted by the current data key
+func (rp *RangePartition) staleKey(t *table.Table) bool {
+	return rp.keys != nil && t.KeyID != rp.keys.Current()
+}
+
 func (rp *RangePartition) updateTableLocs(tableLocs []*pspb.Location) {
 	if len(tableLocs) == 0 {
 		return
@@ -429,10 +486,10 @@ type request struct {
 	ref int32
 }
 
-//key + valueStruct
+//key + valueStruct, big values are marked by stream client after they are written
 func estimatedSizeInSkl(e *pb.Entry) int {
 	sz := len(e.Key)
-	if y.ShouldWriteValueToLSM(e) {
+	if e.Meta&uint32(y.BitValuePointer) == 0 {
 		sz += len(e.Value) + 2 // Meta, UserMeta
 	} else {
 		sz += int(vptrSize) + 2 // vptrSize for valuePointer, 2 for metas.
@@ -446,7 +503,7 @@ func estimatedSizeInSkl(e *pb.Entry) int {
 
 func estimatedVS(key []byte, vs y.ValueStruct) int {
 	sz := len(key)
-	if vs.Meta&y.BitValuePointer == 0 && len(vs.Value) <= y.ValueThrottle {
+	if vs.Meta&y.BitValuePointer == 0 {
 		sz += len(vs.Value) + 2 // Meta, UserMeta
 	} else {
 		sz += int(vptrSize) + 2 // vptrSize for valuePointer, 2 for metas.
@@ -518,6 +575,9 @@ func (rp *RangePartition) writeRequests(reqs []*request) error {
 
 	xlog.Logger.Debugf("writeRequests called. Writing to log, len[%d]", len(reqs))
 
+	//compaction debt
+	rp.throttleWrites()
+
 	entriesReady, head, err := rp.writeValueLog(reqs)
 	if err != nil {
 		done(err)
@@ -552,6 +612,7 @@ func (rp *RangePartition) writeRequests(reqs []*request) error {
 			done(err)
 			return errors.Wrap(err, "writeRequests")
 		}
+		rp.logSize += uint64(b.Size())
 	}
 
 	rp.vhead = head
@@ -565,7 +626,15 @@ func getLowerByte(a uint32) byte {
 
 func (rp *RangePartition) writeToLSM(entries []*pb.EntryInfo) error {
 	for _, entry := range entries {
-		if y.ShouldWriteValueToLSM(entry.Log) { // Will include deletion / tombstone case.
+		if entry.Log.Meta&uint32(y.BitBlobPointer) > 0 { //valuePointer into blob stream
+			rp.mt.Put(entry.Log.Key,
+				y.ValueStruct{
+					Value:     entry.Log.Value,
+					Meta:      getLowerByte(entry.Log.Meta)&^y.BitBlobPointer | y.BitValuePointer,
+					UserMeta:  getLowerByte(entry.Log.UserMeta),
+					ExpiresAt: entry.Log.ExpiresAt,
+				})
+		} else if entry.Log.Meta&uint32(y.BitValuePointer) == 0 { // Will include deletion / tombstone case.
 			rp.mt.Put(entry.Log.Key,
 				y.ValueStruct{
 					Value:     entry.Log.Value,
@@ -591,7 +660,7 @@ func (rp *RangePartition) writeToLSM(entries []*pb.EntryInfo) error {
 	return nil
 }
 
-func isReqsTooBig(reqs []*request) bool {
+func (rp *RangePartition) isReqsTooBig(reqs []*request) bool {
 	//grpc limit
 	size := 0 //network size in grpc
 	n := 0
@@ -602,7 +671,7 @@ func isReqsTooBig(reqs []*request) bool {
 		}
 
 		n += len(req.entries)
-		if n > 3*writeChCapacity {
+		if n > 3*rp.opt.WriteChCapacity {
 			return true
 		}
 	}
@@ -643,7 +712,7 @@ func (rp *RangePartition) doWrites() {
 
 		for {
 			reqs = append(reqs, r)
-			if isReqsTooBig(reqs) {
+			if rp.isReqsTooBig(reqs) {
 				pendingCh <- struct{}{} // blocking.
 				goto writeCase
 			}
@@ -667,7 +736,7 @@ func (rp *RangePartition) doWrites() {
 			case r = <-rp.writeCh:
 				reqs = append(reqs, r)
 
-				if isReqsTooBig(reqs) {
+				if rp.isReqsTooBig(reqs) {
 					pendingCh <- struct{}{} // blocking.
 					goto writeCase
 				}
@@ -690,15 +759,15 @@ func (rp *RangePartition) ensureRoomForWrite(entries []*pb.EntryInfo, head value
 	rp.Lock()
 	defer rp.Unlock()
 
-	forceFlush := atomic.LoadInt32(&rp.logRotates) >= 1
+	forceFlush := atomic.LoadInt32(&rp.logRotates) >= 1 || rp.needCheckpoint()
 	n := int64(0)
 	for i := range entries {
 		n += int64(estimatedSizeInSkl(entries[i].Log))
 	}
 
-	utils.AssertTrue(n <= maxSkipList)
+	utils.AssertTrue(n <= rp.opt.MaxSkipList)
 
-	if !forceFlush && rp.mt.MemSize()+n < maxSkipList {
+	if !forceFlush && rp.mt.MemSize()+n < rp.opt.MaxSkipList {
 		return nil
 	}
 	utils.AssertTrue(rp.mt != nil)
@@ -717,7 +786,9 @@ func (rp *RangePartition) ensureRoomForWrite(entries []*pb.EntryInfo, head value
 			rp.mt.MemSize(), len(rp.flushChan))
 		rp.imm = append(rp.imm, rp.mt)
 
-		rp.mt = skiplist.NewSkiplist(maxSkipList)
+		rp.mt = skiplist.NewSkiplist(rp.opt.MaxSkipList)
+		rp.mtCreated = time.Now()
+		rp.logSize = 0
 		// New memtable is empty. We certainly have room.
 
 		return nil
@@ -776,13 +847,58 @@ func (rp *RangePartition) getTablesForKey(userKey []byte) ([]*table.Table, func(
 }
 
 func (rp *RangePartition) Range(prefix []byte, start []byte, limit uint32) [][]byte {
+	var out [][]byte
+	rp.scan(prefix, start, limit, func(key []byte, vs y.ValueStruct) {
+		//FIXME:slab allocation key
+		out = append(out, y.Copy(y.ParseKey(key)))
+	})
+	return out
+}
+
+//RangeValues returns keys and values like Range, big values missing in valueCache are read with
+//the following blocks, see big value cache
+func (rp *RangePartition) RangeValues(prefix []byte, start []byte, limit uint32) ([][]byte, [][]byte, error) {
+	var keys, values [][]byte
+	var vps []valuePointer
+	rp.scan(prefix, start, limit, func(key []byte, vs y.ValueStruct) {
+		keys = append(keys, y.Copy(y.ParseKey(key)))
+		var vp valuePointer
+		if vs.Meta&y.BitValuePointer > 0 {
+			vp.Decode(vs.Value)
+			values = append(values, nil)
+		} else {
+			values = append(values, y.Copy(vs.Value))
+		}
+		vps = append(vps, vp)
+	})
+
+	prefetched := make(map[valuePointer][]byte)
+	for i, vp := range vps {
+		if vp.extentID == 0 {
+			continue
+		}
+		if value, ok := prefetched[valuePointer{extentID: vp.extentID, offset: vp.offset}]; ok {
+			values[i] = value
+			continue
+		}
+		value, err := rp.readValue(vp, valuePrefetchBlocks, prefetched)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i] = value
+	}
+	return keys, values, nil
+}
+
+//scan calls fn with the latest version of each key which is not deleted or expired
+func (rp *RangePartition) scan(prefix []byte, start []byte, limit uint32, fn func(key []byte, vs y.ValueStruct)) {
 	iter := rp.newIterator()
 	defer iter.Close()
-	var out [][]byte
+	var n uint32
 	var skipKey []byte //note:包括seqnum
 	startTs := y.KeyWithTs(start, atomic.LoadUint64(&rp.seqNumber))
 	//readTs: 是否以后支持readTs:如果version比readTS大, 则忽略这个版本
-	for iter.Seek(startTs); iter.Valid() && uint32(len(out)) < limit; iter.Next() {
+	for iter.Seek(startTs); iter.Valid() && n < limit; iter.Next() {
 		if !bytes.HasPrefix(iter.Key(), prefix) {
 			break
 		}
@@ -800,10 +916,9 @@ func (rp *RangePartition) Range(prefix []byte, start []byte, limit uint32) [][]b
 		if isDeletedOrExpired(vs.Meta, vs.ExpiresAt) {
 			continue
 		}
-		//FIXME:slab allocation key
-		out = append(out, y.Copy(y.ParseKey(iter.Key())))
+		fn(iter.Key(), vs)
+		n++
 	}
-	return out
 }
 
 func (rp *RangePartition) Get(userKey []byte, version uint64) ([]byte, error) {
@@ -820,14 +935,7 @@ func (rp *RangePartition) Get(userKey []byte, version uint64) ([]byte, error) {
 
 		var vp valuePointer
 		vp.Decode(vs.Value)
-
-		blocks, err := rp.blockReader.Read(context.Background(), vp.extentID, vp.offset, 1)
-		if err != nil {
-			return nil, err
-		}
-
-		entries := y.ExtractLogEntry(blocks[0])
-		return entries[0].Value, nil
+		return rp.readValue(vp, 1, nil)
 	}
 	return vs.Value, nil
 
@@ -896,6 +1004,15 @@ func (rp *RangePartition) close(gracefull bool) error {
 	xlog.Logger.Infof("Closing database")
 	atomic.StoreInt32(&rp.blockWrites, 1)
 
+	//gc sends requests to writeCh
+	if rp.gcStopper != nil {
+		rp.gcStopper.Stop()
+	}
+	//checkpoint sends empty requests to writeCh
+	rp.checkpointStopper.Stop()
+	//compaction sends tasks to flushChan, stop it before flushChan is closed
+	rp.compactStopper.Stop()
+	rp.truncateStopper.Stop()
 	rp.writeStopper.Stop()
 	close(rp.writeCh)
 
@@ -933,6 +1050,14 @@ func (rp *RangePartition) close(gracefull bool) error {
 	//flushChan里面的任何都已经执行完了
 	rp.flushStopper.Wait()
 
+	//release tables from shared caches
+	rp.tableLock.Lock()
+	for _, t := range rp.tables {
+		t.DecrRef()
+	}
+	rp.tables = nil
+	rp.tableLock.Unlock()
+
 	return nil
 }
 
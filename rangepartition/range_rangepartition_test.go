@@ -1,13 +1,20 @@
 package rangepartition
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/journeymidnight/autumn/manager/pmclient"
 	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
 	"github.com/journeymidnight/autumn/rangepartition/skiplist"
 	"github.com/journeymidnight/autumn/streamclient"
 	"github.com/journeymidnight/autumn/utils"
@@ -46,6 +53,16 @@ func TestEstimateSize(t *testing.T) {
 
 }
 
+//testOptions returns options of 1MB memtable without checkpoint, ct is compression of tables
+func testOptions(ct pspb.CompressionType) Options {
+	opt := DefaultOptions()
+	opt.MaxSkipList = 1 * MB
+	opt.MaxReplaySize = 0
+	opt.FlushInterval = 0
+	opt.Table.Compression = ct
+	return opt
+}
+
 //helper function for TestEstimateSize.
 
 func _writeToLSM(skl *skiplist.Skiplist, entires []*pb.EntryInfo) int64 {
@@ -93,8 +110,8 @@ func runRPTest(t *testing.T, test func(t *testing.T, rp *RangePartition)) {
 	defer logStream.Close()
 	defer rowStream.Close()
 	pmclient := new(pmclient.MockPMClient)
-	rp := OpenRangePartition(3, rowStream, logStream, logStream.(streamclient.BlockReader),
-		[]byte(""), []byte(""), nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock)
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
 	defer func() {
 		require.NoError(t, rp.Close())
 	}()
@@ -164,8 +181,8 @@ func TestReopenRangePartition(t *testing.T) {
 	defer logStream.Close()
 	defer rowStream.Close()
 	pmclient := new(pmclient.MockPMClient)
-	rp := OpenRangePartition(3, rowStream, logStream, logStream.(streamclient.BlockReader),
-		[]byte(""), []byte(""), nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock)
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
 
 	var wg sync.WaitGroup
 	for i := 10; i < 100; i++ {
@@ -178,8 +195,8 @@ func TestReopenRangePartition(t *testing.T) {
 	rp.Close()
 
 	//reopen with tables
-	rp = OpenRangePartition(3, rowStream, logStream, logStream.(streamclient.BlockReader),
-		[]byte(""), []byte(""), pmclient.Tables, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock)
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
 
 	for i := 10; i < 100; i++ {
 		v, err := rp.Get([]byte(fmt.Sprintf("key%d", i)), 300)
@@ -193,6 +210,41 @@ func TestReopenRangePartition(t *testing.T) {
 	rp.Close()
 }
 
+//TestReopenCompressedRangePartition writes log blocks and tables with snappy, and reads them after reopen
+func TestReopenCompressedRangePartition(t *testing.T) {
+
+	logStream := streamclient.NewMockStreamClient("log")
+	rowStream := streamclient.NewMockStreamClient("sst")
+
+	defer logStream.Close()
+	defer rowStream.Close()
+	logStream.SetCompression(pspb.CompressionType_Snappy)
+	pmclient := new(pmclient.MockPMClient)
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_Snappy))
+
+	var wg sync.WaitGroup
+	for i := 10; i < 100; i++ {
+		wg.Add(1)
+		rp.WriteAsync([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("val%d", i)), func(e error) {
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	rp.Close()
+
+	//reopen with tables
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_Snappy))
+
+	for i := 10; i < 100; i++ {
+		v, err := rp.Get([]byte(fmt.Sprintf("key%d", i)), 300)
+		require.NoErrorf(t, err, "key%d failed", i)
+		require.Equal(t, []byte(fmt.Sprintf("val%d", i)), v)
+	}
+	rp.Close()
+}
+
 func TestReopenRangePartitionWithBig(t *testing.T) {
 
 	logStream := streamclient.NewMockStreamClient("log")
@@ -201,8 +253,8 @@ func TestReopenRangePartitionWithBig(t *testing.T) {
 	defer logStream.Close()
 	defer rowStream.Close()
 	pmclient := new(pmclient.MockPMClient)
-	rp := OpenRangePartition(3, rowStream, logStream, logStream.(streamclient.BlockReader),
-		[]byte(""), []byte(""), nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock)
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
 
 	var expectedValue [][]byte
 	var wg sync.WaitGroup
@@ -220,8 +272,8 @@ func TestReopenRangePartitionWithBig(t *testing.T) {
 	rp.close(false)
 
 	//reopen with tables
-	rp = OpenRangePartition(3, rowStream, logStream, logStream.(streamclient.BlockReader),
-		[]byte(""), []byte(""), pmclient.Tables, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock)
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
 
 	for i := 10; i < 100; i++ {
 		v, err := rp.Get([]byte(fmt.Sprintf("key%d", i)), 300)
@@ -235,6 +287,150 @@ func TestReopenRangePartitionWithBig(t *testing.T) {
 	rp.Close()
 }
 
+func TestCheckpoint(t *testing.T) {
+	logStream := streamclient.NewMockStreamClient("log")
+	rowStream := streamclient.NewMockStreamClient("sst")
+	defer logStream.Close()
+	defer rowStream.Close()
+	pmclient := new(pmclient.MockPMClient)
+
+	//flush by size of log
+	opt := testOptions(pspb.CompressionType_None)
+	opt.MaxReplaySize = 64 << 10
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, opt)
+	value := []byte(fmt.Sprintf("%01000d", 0))
+	for i := 0; i < 200; i++ {
+		require.NoError(t, rp.Write([]byte(fmt.Sprintf("key%03d", i)), value))
+	}
+	require.NoError(t, rp.close(false))
+
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, opt)
+	//replay starts from the block of the last checkpoint
+	require.True(t, rp.logSize < (64+8)<<10, "replayed %d bytes", rp.logSize)
+	for i := 0; i < 200; i++ {
+		v, err := rp.Get([]byte(fmt.Sprintf("key%03d", i)), 0)
+		require.NoError(t, err)
+		require.Equal(t, value, v)
+	}
+	require.NoError(t, rp.close(false))
+
+	//flush by age of memtable without writes
+	opt = testOptions(pspb.CompressionType_None)
+	opt.FlushInterval = 100 * time.Millisecond
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, opt)
+	require.NoError(t, rp.Write([]byte("key200"), value))
+	require.Eventually(t, func() bool {
+		rp.RLock()
+		defer rp.RUnlock()
+		return rp.mt.Empty() && len(rp.imm) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rp.close(false))
+
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
+	require.True(t, rp.logSize < 8<<10, "replayed %d bytes", rp.logSize)
+	v, err := rp.Get([]byte("key200"), 0)
+	require.NoError(t, err)
+	require.Equal(t, value, v)
+	rp.Close()
+}
+
+func TestValueThreshold(t *testing.T) {
+	logStream := streamclient.NewMockStreamClient("log")
+	rowStream := streamclient.NewMockStreamClient("sst")
+	defer logStream.Close()
+	defer rowStream.Close()
+	pmclient := new(pmclient.MockPMClient)
+
+	opt := testOptions(pspb.CompressionType_None)
+	opt.Stream.ValueThreshold = 4 << 10
+	opt.Stream.MaxMixedBlockSize = 16 << 10
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, opt)
+
+	small := []byte(fmt.Sprintf("%02048d", 0))
+	big := []byte(fmt.Sprintf("%08192d", 0))
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		rp.WriteAsync([]byte(fmt.Sprintf("small%02d", i)), small, func(e error) { wg.Done() })
+		rp.WriteAsync([]byte(fmt.Sprintf("big%02d", i)), big, func(e error) { wg.Done() })
+	}
+	wg.Wait()
+	//values of 2KB are in memtable
+	for i := 0; i < 20; i++ {
+		vs := rp.getValueStruct([]byte(fmt.Sprintf("small%02d", i)), 0)
+		require.Zero(t, vs.Meta&y.BitValuePointer)
+		vs = rp.getValueStruct([]byte(fmt.Sprintf("big%02d", i)), 0)
+		require.NotZero(t, vs.Meta&y.BitValuePointer)
+	}
+	require.NoError(t, rp.close(false))
+
+	//replay does not depend on the threshold
+	opt.Stream.ValueThreshold = 512
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, opt)
+	for i := 0; i < 20; i++ {
+		v, err := rp.Get([]byte(fmt.Sprintf("small%02d", i)), 0)
+		require.NoError(t, err)
+		require.Equal(t, small, v)
+		v, err = rp.Get([]byte(fmt.Sprintf("big%02d", i)), 0)
+		require.NoError(t, err)
+		require.Equal(t, big, v)
+	}
+	require.NoError(t, rp.close(false))
+}
+
+func TestRangeValuesWithValueCache(t *testing.T) {
+	logStream := streamclient.NewMockStreamClient("log")
+	rowStream := streamclient.NewMockStreamClient("sst")
+	defer logStream.Close()
+	defer rowStream.Close()
+	cache, err := NewValueCache(16 << 20)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	pmclient := new(pmclient.MockPMClient)
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{ValueCache: cache}, testOptions(pspb.CompressionType_None))
+	defer rp.Close()
+
+	var expectedValue [][]byte
+	var wg sync.WaitGroup
+	for i := 10; i < 50; i++ {
+		wg.Add(1)
+		//small and big values
+		val := make([]byte, 1024+(i%2)*8192)
+		utils.SetRandStringBytes(val)
+		expectedValue = append(expectedValue, val)
+		rp.WriteAsync([]byte(fmt.Sprintf("key%d", i)), val, func(e error) {
+			wg.Done()
+		})
+	}
+	wg.Wait()
+
+	keys, values, err := rp.RangeValues([]byte("key"), []byte("key"), 100)
+	require.NoError(t, err)
+	require.Equal(t, 40, len(keys))
+	for i := range keys {
+		require.Equal(t, []byte(fmt.Sprintf("key%d", i+10)), keys[i])
+		require.Equal(t, expectedValue[i], values[i])
+	}
+
+	//big values are prefetched, most of them are read only once
+	require.True(t, cache.Misses() < 20)
+	//ristretto sets asynchronously
+	time.Sleep(10 * time.Millisecond)
+	hits := cache.Hits()
+	v, err := rp.Get([]byte("key11"), 0)
+	require.NoError(t, err)
+	require.Equal(t, expectedValue[1], v)
+	require.Equal(t, hits+1, cache.Hits())
+}
+
 func TestRange(t *testing.T) {
 	runRPTest(t, func(t *testing.T, rp *RangePartition) {
 		var wg sync.WaitGroup
@@ -280,3 +476,70 @@ func TestRange(t *testing.T) {
 
 	})
 }
+
+func TestEncryptedRangePartition(t *testing.T) {
+	keyFile, err := ioutil.TempFile("", "masterkey")
+	require.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	_, err = keyFile.WriteString("1 000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f\n")
+	require.NoError(t, err)
+	keyFile.Close()
+	mk, err := encryption.LoadMasterKeys(keyFile.Name())
+	require.NoError(t, err)
+
+	logStream := streamclient.NewMockStreamClient("log")
+	rowStream := streamclient.NewMockStreamClient("sst")
+	defer logStream.Close()
+	defer rowStream.Close()
+	pmclient := new(pmclient.MockPMClient)
+
+	keys, err := encryption.OpenKeyRegistry(mk, nil)
+	require.NoError(t, err)
+	logStream.SetEncryption(keys)
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{Keys: keys}, testOptions(pspb.CompressionType_Snappy))
+	//first data key is saved in pm
+	require.Equal(t, 1, len(pmclient.Keys.Keys))
+
+	bigValue := []byte(fmt.Sprintf("secret%04096d", 1))
+	var wg sync.WaitGroup
+	for i := 10; i < 100; i++ {
+		wg.Add(1)
+		value := []byte(fmt.Sprintf("secret%d", i))
+		if i%10 == 0 {
+			value = bigValue
+		}
+		rp.WriteAsync([]byte(fmt.Sprintf("enckey%d", i)), value, func(e error) {
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	rp.Close()
+
+	//no plaintext in tables and logs
+	names, err := filepath.Glob("mockextent_*")
+	require.NoError(t, err)
+	for _, name := range names {
+		data, err := ioutil.ReadFile(name)
+		require.NoError(t, err)
+		require.False(t, bytes.Contains(data, []byte("enckey")), name)
+		require.False(t, bytes.Contains(data, []byte("secret")), name)
+	}
+
+	//reopen with keys from pm
+	keys, err = encryption.OpenKeyRegistry(mk, pmclient.Keys)
+	require.NoError(t, err)
+	logStream.SetEncryption(keys)
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{Keys: keys}, testOptions(pspb.CompressionType_Snappy))
+	for i := 10; i < 100; i++ {
+		v, err := rp.Get([]byte(fmt.Sprintf("enckey%d", i)), 300)
+		require.NoError(t, err)
+		if i%10 == 0 {
+			require.Equal(t, bigValue, v)
+		} else {
+			require.Equal(t, []byte(fmt.Sprintf("secret%d", i)), v)
+		}
+	}
+	rp.Close()
+}
@@ -21,10 +21,10 @@ import (
 	"math"
 	"unsafe"
 
-	"github.com/dgraph-io/ristretto/z"
 	"github.com/dgryski/go-farm"
 	"github.com/journeymidnight/autumn/proto/pb"
 	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
 	"github.com/journeymidnight/autumn/rangepartition/y"
 	"github.com/journeymidnight/autumn/streamclient"
 	"github.com/journeymidnight/autumn/utils"
@@ -74,19 +74,58 @@ type Builder struct {
 	entryOffsets []uint32 // Offsets of entries present in current block.
 	tableIndex   *pspb.TableIndex
 	keyHashes    []uint64 // Used for building the bloomfilter.
+	smallest     []byte
+	biggest      []byte
 	stream       streamclient.StreamClient
 	writeCh      chan writeBlock
 	stopper      *utils.Stopper
+	opt          Options                 //block size and compression of data blocks
+	keys         *encryption.KeyRegistry //nil if blocks are not encrypted
+}
+
+// Options of data blocks in a table
+type Options struct {
+	//a block is finished when its estimated size is more than BlockSize
+	BlockSize uint32
+	//or it has more than MaxEntriesInBlock entries
+	MaxEntriesInBlock int
+	//compression of data blocks, meta block is never compressed
+	Compression pspb.CompressionType
+}
+
+// DefaultOptions returns 64KB blocks of at most 1000 entries without compression
+func DefaultOptions() Options {
+	return Options{
+		BlockSize:         64 * KB,
+		MaxEntriesInBlock: 1000,
+		Compression:       pspb.CompressionType_None,
+	}
 }
 
 // NewTableBuilder makes a new TableBuilder.
 func NewTableBuilder(stream streamclient.StreamClient) *Builder {
+	return NewTableBuilderWithOptions(stream, DefaultOptions(), nil)
+}
+
+// NewTableBuilderWithOptions makes a new TableBuilder whose data blocks are compressed,
+// meta block is never compressed. If keys is not nil, all blocks are encrypted by the current key.
+// Zero fields of opt are taken from DefaultOptions
+func NewTableBuilderWithOptions(stream streamclient.StreamClient, opt Options, keys *encryption.KeyRegistry) *Builder {
+	def := DefaultOptions()
+	if opt.BlockSize == 0 {
+		opt.BlockSize = def.BlockSize
+	}
+	if opt.MaxEntriesInBlock == 0 {
+		opt.MaxEntriesInBlock = def.MaxEntriesInBlock
+	}
 	b := &Builder{
 		tableIndex: &pspb.TableIndex{},
 		keyHashes:  make([]uint64, 0, 1024), // Avoid some malloc calls.
 		stream:     stream,
 		writeCh:    make(chan writeBlock, 16),
 		stopper:    utils.NewStopper(),
+		opt:        opt,
+		keys:       keys,
 	}
 
 	b.stopper.RunWorker(func() {
@@ -193,6 +232,10 @@ func (b *Builder) append(data []byte) {
 
 func (b *Builder) addHelper(key []byte, v y.ValueStruct) {
 	b.keyHashes = append(b.keyHashes, farm.Fingerprint64(y.ParseKey(key)))
+	if len(b.smallest) == 0 {
+		b.smallest = y.Copy(key)
+	}
+	b.biggest = append(b.biggest[:0], key...)
 
 	// diffKey stores the difference of key with baseKey.
 	var diffKey []byte
@@ -249,12 +292,29 @@ func (b *Builder) FinishBlock() {
 	b.append(y.U32SliceToBytes(b.entryOffsets))
 	b.append(y.U32ToBytes(uint32(len(b.entryOffsets))))
 
-	b.currentBlock.CheckSum = utils.AdlerCheckSum(b.currentBlock.Data)
-	b.currentBlock.UserData = utils.MustMarshal(&pspb.RawBlockMeta{
+	blockMeta := &pspb.RawBlockMeta{
 		Type:             pspb.RawBlockType_data,
 		CompressedSize:   0,
 		UnCompressedSize: b.sz,
-	})
+	}
+	content := b.currentBlock.Data[:b.sz]
+	//keep the original block if compression does not help
+	if compressed, ok := y.Compress(b.opt.Compression, content); ok {
+		content = compressed
+		blockMeta.CompressedSize = uint32(len(compressed))
+		blockMeta.Compression = b.opt.Compression
+	}
+	content = b.encrypt(content, blockMeta)
+	if blockMeta.Compression != pspb.CompressionType_None || blockMeta.KeyID != 0 {
+		blockLength := utils.Ceil(uint32(len(content)), 512)
+		data := make([]byte, blockLength)
+		copy(data, content)
+		b.currentBlock.Data = data
+		b.currentBlock.BlockLength = blockLength
+	}
+
+	b.currentBlock.CheckSum = utils.AdlerCheckSum(b.currentBlock.Data)
+	b.currentBlock.UserData = utils.MustMarshal(blockMeta)
 
 	xlog.Logger.Debugf("real block size is %d, len of entries is %d\n", b.sz, len(b.entryOffsets))
 	b.writeCh <- writeBlock{
@@ -264,6 +324,41 @@ func (b *Builder) FinishBlock() {
 	return
 }
 
+//rawBlock compresses and encrypts content into a new block
+func (b *Builder) rawBlock(blockType pspb.RawBlockType, content []byte) *pb.Block {
+	blockMeta := &pspb.RawBlockMeta{
+		Type:             blockType,
+		UnCompressedSize: uint32(len(content)),
+	}
+	if compressed, ok := y.Compress(b.opt.Compression, content); ok {
+		content = compressed
+		blockMeta.CompressedSize = uint32(len(compressed))
+		blockMeta.Compression = b.opt.Compression
+	}
+	content = b.encrypt(content, blockMeta)
+	blockLength := utils.Ceil(uint32(len(content)), 512)
+	block := &pb.Block{
+		BlockLength: blockLength,
+		Data:        make([]byte, blockLength),
+		UserData:    utils.MustMarshal(blockMeta),
+	}
+	copy(block.Data, content)
+	block.CheckSum = utils.AdlerCheckSum(block.Data)
+	return block
+}
+
+//encrypt returns content if b.keys is nil, otherwise sets KeyID and EncryptedSize
+func (b *Builder) encrypt(content []byte, blockMeta *pspb.RawBlockMeta) []byte {
+	if b.keys == nil {
+		return content
+	}
+	keyID, encrypted, err := b.keys.Encrypt(content)
+	utils.Check(err)
+	blockMeta.KeyID = keyID
+	blockMeta.EncryptedSize = uint32(len(encrypted))
+	return encrypted
+}
+
 func (b *Builder) addBlockToIndex(baseKey []byte, extentID uint64, offset uint32) {
 	// Add key to the block index.
 	bo := &pspb.BlockOffset{
@@ -283,7 +378,7 @@ func (b *Builder) shouldFinishBlock(key []byte, value y.ValueStruct) bool {
 	entriesOffsetsSize := uint32(len(b.entryOffsets)*4 + 4) //size of list
 	estimatedSize := uint32(b.sz) + uint32(headerSize) +
 		uint32(len(key)) + uint32(value.EncodedSize()) + entriesOffsetsSize
-	return estimatedSize > 64*KB || len(b.entryOffsets) > 1000
+	return estimatedSize > b.opt.BlockSize || len(b.entryOffsets) >= b.opt.MaxEntriesInBlock
 }
 
 // Add adds a key-value pair to the block.
@@ -309,8 +404,10 @@ The table structure looks like
 +---------+------------+-----------+---------------+
 | Block 5 | Block 6    | Block ... | Block N       |
 +---------+------------+-----------+---------------+
-| MetaBlock |
+| IndexBlock | BloomBlock | MetaBlock |
 +---------+------------+-----------+---------------+
+IndexBlock是所有data block的BlockOffset, BloomBlock是y.Filter, 这两个block在读的时候才加载,
+可以被IndexCache淘汰. MetaBlock只记录它们的位置和smallest/biggest, 打开table只需要读MetaBlock
 */
 //return metablock position(extentID, offset, error)
 //tailExtentID和tailOffset表示当前commitLog对应的结尾, 在打开commitlog后, 从(tailExtentID, tailOffset)开始的
@@ -320,35 +417,51 @@ func (b *Builder) FinishAll(headExtentID uint64, headOffset uint32, seqNum uint6
 	close(b.writeCh)
 	b.stopper.Wait()
 
-	bf := z.NewBloomFilter(float64(len(b.keyHashes)), 0.01)
-	for _, h := range b.keyHashes {
-		bf.Add(h)
+	//index and bloom filter are separate blocks, loaded lazily
+	index := &pspb.TableIndex{Offsets: b.tableIndex.Offsets}
+	bf := y.NewFilter(b.keyHashes, y.BloomBitsPerKey(len(b.keyHashes), 0.01))
+	extentID, offsets, err := b.stream.Append(context.Background(), []*pb.Block{
+		b.rawBlock(pspb.RawBlockType_index, utils.MustMarshal(index)),
+		b.rawBlock(pspb.RawBlockType_bloom, bf),
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	b.tableIndex = &pspb.TableIndex{
+		EstimatedSize: b.tableIndex.EstimatedSize,
+		NumOfBlocks:   uint32(len(index.Offsets)),
+		IndexLoc:      &pspb.Location{ExtentID: extentID, Offset: offsets[0]},
+		BloomLoc:      &pspb.Location{ExtentID: extentID, Offset: offsets[1]},
+		Smallest:      b.smallest,
+		Biggest:       b.biggest,
 	}
-	// Add bloom filter to the index.
-	b.tableIndex.BloomFilter = bf.JSONMarshal()
 
 	//alloc a new meta block
 
-	sz := utils.Ceil(uint32(b.tableIndex.Size()), 4*KB)
+	blockMeta := &pspb.RawBlockMeta{
+		Type:             pspb.RawBlockType_meta,
+		UnCompressedSize: uint32(b.tableIndex.Size()),
+		CompressedSize:   0,
+		VpExtentID:       headExtentID,
+		VpOffset:         headOffset,
+		SeqNum:           seqNum,
+	}
+	//index has keys, encrypt it too
+	content := b.encrypt(utils.MustMarshal(b.tableIndex), blockMeta)
+
+	sz := utils.Ceil(uint32(len(content)), 4*KB)
 
 	metaBlock := &pb.Block{
 		BlockLength: sz,
 		Data:        make([]byte, sz, sz),
 	}
 
-	b.tableIndex.MarshalTo(metaBlock.Data)
+	copy(metaBlock.Data, content)
 
-	metaBlock.UserData = utils.MustMarshal(&pspb.RawBlockMeta{
-		Type:             pspb.RawBlockType_meta,
-		UnCompressedSize: uint32(b.tableIndex.Size()),
-		CompressedSize:   0,
-		VpExtentID:       headExtentID,
-		VpOffset:         headOffset,
-		SeqNum:           seqNum,
-	})
+	metaBlock.UserData = utils.MustMarshal(blockMeta)
 	metaBlock.CheckSum = utils.AdlerCheckSum(metaBlock.Data)
 
-	extentID, offsets, err := b.stream.Append(context.Background(), []*pb.Block{metaBlock})
+	extentID, offsets, err = b.stream.Append(context.Background(), []*pb.Block{metaBlock})
 	if err != nil {
 		return 0, 0, err
 	}
@@ -17,6 +17,7 @@
 package table
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -26,6 +27,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/journeymidnight/autumn/proto/pspb"
 	"github.com/journeymidnight/autumn/rangepartition/y"
 	"github.com/journeymidnight/autumn/streamclient"
 	"github.com/journeymidnight/autumn/xlog"
@@ -79,10 +81,76 @@ func TestTableIndex(t *testing.T) {
 	table, err := OpenTable(stream, id, offset)
 	assert.Nil(t, err)
 	//fmt.Printf("big %s, small %s\n", table.biggest, table.smallest)
-	assert.Equal(t, blockCount, len(table.blockIndex))
+	index, err := table.blockOffsets()
+	assert.Nil(t, err)
+	assert.Equal(t, blockCount, len(index))
 	assert.Equal(t, fmt.Sprintf("%016x", 99999), string(table.biggest))
 	assert.Equal(t, fmt.Sprintf("%016x", 0), string(table.smallest))
 	for i := range blockFirstKeys {
-		assert.Equal(t, table.blockIndex[i].Key, blockFirstKeys[i])
+		assert.Equal(t, index[i].Key, blockFirstKeys[i])
+	}
+}
+
+func TestTableCompression(t *testing.T) {
+	stream := streamclient.NewMockStreamClient("log")
+	defer stream.Close()
+
+	builder := NewTableBuilderWithOptions(stream, Options{Compression: pspb.CompressionType_Snappy}, nil)
+	n := 10000
+	for i := 0; i < n; i++ {
+		k := y.KeyWithTs([]byte(fmt.Sprintf("key%016x", i)), 0)
+		builder.Add(k, y.ValueStruct{Value: []byte(fmt.Sprintf("value%016x", i))})
 	}
+	builder.FinishBlock()
+	id, offset, err := builder.FinishAll(100, 200, 100)
+	require.NoError(t, err)
+
+	table, err := OpenTable(stream, id, offset)
+	require.NoError(t, err)
+
+	//data blocks are compressed on stream
+	index, err := table.blockOffsets()
+	require.NoError(t, err)
+	blocks, err := stream.Read(context.Background(), index[0].ExtentID, index[0].Offset, 1)
+	require.NoError(t, err)
+	var blockMeta pspb.RawBlockMeta
+	require.NoError(t, blockMeta.Unmarshal(blocks[0].UserData))
+	require.Equal(t, pspb.CompressionType_Snappy, blockMeta.Compression)
+	require.True(t, blockMeta.CompressedSize < blockMeta.UnCompressedSize)
+
+	it := table.NewIterator(false)
+	defer it.Close()
+	i := 0
+	for it.Rewind(); it.Valid(); it.Next() {
+		require.Equal(t, fmt.Sprintf("key%016x", i), string(y.ParseKey(it.Key())))
+		require.Equal(t, fmt.Sprintf("value%016x", i), string(it.Value().Value))
+		i++
+	}
+	require.Equal(t, n, i)
+}
+
+func TestTableBlockSize(t *testing.T) {
+	countBlocks := func(opt Options) int {
+		stream := streamclient.NewMockStreamClient("log")
+		defer stream.Close()
+		builder := NewTableBuilderWithOptions(stream, opt, nil)
+		for i := 0; i < 10000; i++ {
+			k := y.KeyWithTs([]byte(fmt.Sprintf("key%016x", i)), 0)
+			builder.Add(k, y.ValueStruct{Value: []byte(fmt.Sprintf("value%016x", i))})
+		}
+		builder.FinishBlock()
+		id, offset, err := builder.FinishAll(100, 200, 100)
+		require.NoError(t, err)
+		table, err := OpenTable(stream, id, offset)
+		require.NoError(t, err)
+		index, err := table.blockOffsets()
+		require.NoError(t, err)
+		return len(index)
+	}
+
+	//zero options are defaults
+	require.Equal(t, countBlocks(DefaultOptions()), countBlocks(Options{}))
+	//4KB blocks hold about 70 entries of 58 bytes
+	require.True(t, countBlocks(Options{BlockSize: 4 * KB}) > 100)
+	require.Equal(t, 100, countBlocks(Options{MaxEntriesInBlock: 100}))
 }
@@ -0,0 +1,151 @@
+package table
+
+import (
+	"encoding/binary"
+
+	"github.com/dgraph-io/ristretto"
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/proto/pspb"
+)
+
+//BlockCache caches decoded(decrypted and uncompressed) data blocks, it is shared by all tables of
+//a partition server, key is (extentID, offset). A nil *BlockCache caches nothing
+type BlockCache struct {
+	cache *ristretto.Cache
+}
+
+//NewBlockCache creates a block cache which holds at most size bytes
+func NewBlockCache(size int64) (*BlockCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		//about 10 counters per 4KB block
+		NumCounters: size/(4<<10)*10 + 1,
+		MaxCost:     size,
+		BufferItems: 64,
+		Metrics:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BlockCache{cache: cache}, nil
+}
+
+func blockCacheKey(extentID uint64, offset uint32) []byte {
+	var key [12]byte
+	binary.BigEndian.PutUint64(key[:], extentID)
+	binary.BigEndian.PutUint32(key[8:], offset)
+	return key[:]
+}
+
+func (c *BlockCache) get(extentID uint64, offset uint32) (*pb.Block, bool) {
+	if c == nil {
+		return nil, false
+	}
+	v, ok := c.cache.Get(blockCacheKey(extentID, offset))
+	if !ok {
+		return nil, false
+	}
+	return v.(*pb.Block), true
+}
+
+func (c *BlockCache) set(extentID uint64, offset uint32, block *pb.Block) {
+	if c == nil {
+		return
+	}
+	c.cache.Set(blockCacheKey(extentID, offset), block, int64(len(block.Data)+len(block.UserData)))
+}
+
+func (c *BlockCache) del(extentID uint64, offset uint32) {
+	if c == nil {
+		return
+	}
+	c.cache.Del(blockCacheKey(extentID, offset))
+}
+
+//Hits returns the number of cache hits
+func (c *BlockCache) Hits() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Metrics.Hits()
+}
+
+//Misses returns the number of cache misses
+func (c *BlockCache) Misses() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Metrics.Misses()
+}
+
+func (c *BlockCache) Close() {
+	if c == nil {
+		return
+	}
+	c.cache.Close()
+}
+
+//IndexCache caches index blocks(*pspb.TableIndex) and bloom blocks(y.Filter) of tables under a
+//memory budget, key is the location of the block. A nil *IndexCache means index and bloom filter
+//are kept in Table after they are loaded
+type IndexCache struct {
+	cache *ristretto.Cache
+}
+
+//NewIndexCache creates an index cache which holds at most size bytes
+func NewIndexCache(size int64) (*IndexCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		//index and bloom blocks are about 64KB
+		NumCounters: size/(64<<10)*10 + 1024,
+		MaxCost:     size,
+		BufferItems: 64,
+		Metrics:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &IndexCache{cache: cache}, nil
+}
+
+func (c *IndexCache) get(loc *pspb.Location) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	return c.cache.Get(blockCacheKey(loc.ExtentID, loc.Offset))
+}
+
+func (c *IndexCache) set(loc *pspb.Location, v interface{}, cost int64) {
+	if c == nil {
+		return
+	}
+	c.cache.Set(blockCacheKey(loc.ExtentID, loc.Offset), v, cost)
+}
+
+func (c *IndexCache) del(loc *pspb.Location) {
+	if c == nil {
+		return
+	}
+	c.cache.Del(blockCacheKey(loc.ExtentID, loc.Offset))
+}
+
+//Hits returns the number of cache hits
+func (c *IndexCache) Hits() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Metrics.Hits()
+}
+
+//Misses returns the number of cache misses
+func (c *IndexCache) Misses() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Metrics.Misses()
+}
+
+func (c *IndexCache) Close() {
+	if c == nil {
+		return
+	}
+	c.cache.Close()
+}
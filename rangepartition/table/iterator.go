@@ -174,6 +174,9 @@ type Iterator struct {
 	bpos int
 	bi   blockIterator
 	err  error
+	//index of data blocks is held by iterator, it could be evicted from IndexCache
+	index    []*pspb.BlockOffset
+	indexErr error
 
 	// Internally, Iterator is bidirectional. However, we only expose the
 	// unidirectional functionality for now.
@@ -196,13 +199,17 @@ func (itr *Iterator) Valid() bool {
 }
 
 func (itr *Iterator) seekToFirst() {
-	numBlocks := len(itr.t.blockIndex)
+	if itr.indexErr != nil {
+		itr.err = itr.indexErr
+		return
+	}
+	numBlocks := len(itr.index)
 	if numBlocks == 0 {
 		itr.err = io.EOF
 		return
 	}
 	itr.bpos = 0
-	block, err := itr.t.block(itr.bpos)
+	block, err := itr.t.block(itr.index[itr.bpos])
 	if err != nil {
 		itr.err = err
 		return
@@ -213,13 +220,17 @@ func (itr *Iterator) seekToFirst() {
 }
 
 func (itr *Iterator) seekToLast() {
-	numBlocks := len(itr.t.blockIndex)
+	if itr.indexErr != nil {
+		itr.err = itr.indexErr
+		return
+	}
+	numBlocks := len(itr.index)
 	if numBlocks == 0 {
 		itr.err = io.EOF
 		return
 	}
 	itr.bpos = numBlocks - 1
-	block, err := itr.t.block(itr.bpos)
+	block, err := itr.t.block(itr.index[itr.bpos])
 	if err != nil {
 		itr.err = err
 		return
@@ -231,7 +242,7 @@ func (itr *Iterator) seekToLast() {
 
 func (itr *Iterator) seekHelper(blockIdx int, key []byte) {
 	itr.bpos = blockIdx
-	block, err := itr.t.block(blockIdx)
+	block, err := itr.t.block(itr.index[blockIdx])
 	if err != nil {
 		itr.err = err
 		return
@@ -244,6 +255,10 @@ func (itr *Iterator) seekHelper(blockIdx int, key []byte) {
 // seekFrom brings us to a key that is >= input key.
 func (itr *Iterator) seekFrom(key []byte, whence int) {
 	utils.AssertTrue(len(key) >= 8)
+	if itr.indexErr != nil {
+		itr.err = itr.indexErr
+		return
+	}
 	itr.err = nil
 	switch whence {
 	case origin:
@@ -251,8 +266,8 @@ func (itr *Iterator) seekFrom(key []byte, whence int) {
 	case current:
 	}
 
-	idx := sort.Search(len(itr.t.blockIndex), func(idx int) bool {
-		ko := itr.t.blockIndex[idx]
+	idx := sort.Search(len(itr.index), func(idx int) bool {
+		ko := itr.index[idx]
 		return y.CompareKeys(ko.Key, key) > 0
 	})
 	if idx == 0 {
@@ -271,8 +286,8 @@ func (itr *Iterator) seekFrom(key []byte, whence int) {
 	itr.seekHelper(idx-1, key)
 	if itr.err == io.EOF {
 		// Case 1. Need to visit block[idx].
-		if idx == len(itr.t.blockIndex) {
-			// If idx == len(itr.t.blockIndex), then input key is greater than ANY element of table.
+		if idx == len(itr.index) {
+			// If idx == len(itr.index), then input key is greater than ANY element of table.
 			// There's nothing we can do. Valid() should return false as we seek to end of table.
 			return
 		}
@@ -297,15 +312,19 @@ func (itr *Iterator) seekForPrev(key []byte) {
 }
 
 func (itr *Iterator) next() {
+	if itr.indexErr != nil {
+		itr.err = itr.indexErr
+		return
+	}
 	itr.err = nil
 
-	if itr.bpos >= len(itr.t.blockIndex) {
+	if itr.bpos >= len(itr.index) {
 		itr.err = io.EOF
 		return
 	}
 
 	if len(itr.bi.data) == 0 {
-		block, err := itr.t.block(itr.bpos)
+		block, err := itr.t.block(itr.index[itr.bpos])
 		if err != nil {
 			itr.err = err
 			return
@@ -326,6 +345,10 @@ func (itr *Iterator) next() {
 }
 
 func (itr *Iterator) prev() {
+	if itr.indexErr != nil {
+		itr.err = itr.indexErr
+		return
+	}
 	itr.err = nil
 	if itr.bpos < 0 {
 		itr.err = io.EOF
@@ -333,7 +356,7 @@ func (itr *Iterator) prev() {
 	}
 
 	if len(itr.bi.data) == 0 {
-		block, err := itr.t.block(itr.bpos)
+		block, err := itr.t.block(itr.index[itr.bpos])
 		if err != nil {
 			itr.err = err
 			return
@@ -9,6 +9,8 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/journeymidnight/autumn/proto/pb"
 	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
+	"github.com/journeymidnight/autumn/rangepartition/y"
 	"github.com/journeymidnight/autumn/streamclient"
 	"github.com/journeymidnight/autumn/utils"
 	"github.com/journeymidnight/autumn/xlog"
@@ -24,9 +26,20 @@ type TableInterface interface {
 
 type Table struct {
 	utils.SafeMutex
-	stream     streamclient.BlockReader
-	blockIndex []*pspb.BlockOffset
-	ref        int32 // For file garbage collection. Atomic.
+	stream streamclient.BlockReader
+	ref    int32 // For file garbage collection. Atomic.
+	//closed when ref reaches zero
+	released chan struct{}
+
+	//index and bloom filter of new tables are loaded when they are used, they are saved in
+	//indexCache, or in blockIndex/filter if indexCache is nil.
+	//old tables have no indexLoc/bloomLoc, blockIndex and bf are loaded when opening
+	indexLoc    *pspb.Location
+	bloomLoc    *pspb.Location
+	indexCache  *IndexCache
+	blockIndex  []*pspb.BlockOffset
+	filter      y.Filter
+	numOfBlocks int
 
 	// The following are initialized once and const.
 	smallest, biggest []byte // Smallest and largest keys (with timestamps).
@@ -34,13 +47,19 @@ type Table struct {
 	// Stores the total size of key-values stored in this table (including the size on vlog).
 	estimatedSize uint64
 	bf            *z.Bloom
-	Cache         *ristretto.Cache
 	BfCache       *ristretto.Cache
+	//shared block cache, could be nil
+	cache *BlockCache
+	//set after the table is dropped, blocks are not cached any more
+	dropped int32
 
 	Loc        pspb.Location
 	LastSeq    uint64
 	VpExtentID uint64
 	VpOffset   uint32
+	//data key of this table, 0 if it is not encrypted
+	KeyID uint64
+	keys  *encryption.KeyRegistry
 }
 
 // IncrRef increments the refcount (having to do with whether the file should be deleted)
@@ -48,14 +67,42 @@ func (t *Table) IncrRef() {
 	atomic.AddInt32(&t.ref, 1)
 }
 
-// DecrRef decrements the refcount and possibly deletes the table
+// DecrRef decrements the refcount, the table is released from caches when refcount reaches zero
 func (t *Table) DecrRef() error {
-	//FIXME:
+	newRef := atomic.AddInt32(&t.ref, -1)
+	if newRef < 0 {
+		return errors.Errorf("negative refcount of table %v", t.Loc)
+	}
+	if newRef > 0 {
+		return nil
+	}
+	t.DropCache()
+	//index and bloom filter of new tables can be loaded again
+	if t.indexLoc != nil {
+		t.Lock()
+		t.blockIndex = nil
+		t.filter = nil
+		t.Unlock()
+	}
+	close(t.released)
 	return nil
 }
 
+//Released returns a channel which is closed when refcount of the table reaches zero,
+//after that no reader reads the table's blocks
+func (t *Table) Released() <-chan struct{} {
+	return t.released
+}
+
 func OpenTable(stream streamclient.StreamClient,
 	extentID uint64, offset uint32) (*Table, error) {
+	return OpenTableWithOptions(stream, nil, nil, nil, extentID, offset)
+}
+
+//OpenTableWithOptions opens a table which could be encrypted by keys, data blocks are cached in cache,
+//index and bloom filter are cached in indexCache. keys, cache and indexCache could be nil
+func OpenTableWithOptions(stream streamclient.StreamClient, keys *encryption.KeyRegistry, cache *BlockCache,
+	indexCache *IndexCache, extentID uint64, offset uint32) (*Table, error) {
 
 	utils.AssertTrue(xlog.Logger != nil)
 
@@ -73,13 +120,19 @@ func OpenTable(stream streamclient.StreamClient,
 		return nil, errors.Errorf("block type error")
 	}
 
+	data, err := decryptBlock(keys, &metaBlock, blocks[0].Data)
+	if err != nil {
+		return nil, err
+	}
 	var tableIndex pspb.TableIndex
-	if err = tableIndex.Unmarshal(blocks[0].Data[:metaBlock.UnCompressedSize]); err != nil {
+	if err = tableIndex.Unmarshal(data[:metaBlock.UnCompressedSize]); err != nil {
 		return nil, err
 	}
 
+	//the caller owns a ref
 	t := &Table{
-		blockIndex:    make([]*pspb.BlockOffset, len(tableIndex.Offsets)),
+		ref:           1,
+		released:      make(chan struct{}),
 		stream:        stream,
 		estimatedSize: tableIndex.EstimatedSize,
 		Loc: pspb.Location{
@@ -89,17 +142,32 @@ func OpenTable(stream streamclient.StreamClient,
 		LastSeq:    metaBlock.SeqNum,
 		VpExtentID: metaBlock.VpExtentID,
 		VpOffset:   metaBlock.VpOffset,
+		KeyID:      metaBlock.KeyID,
+		keys:       keys,
+		cache:      cache,
+		indexCache: indexCache,
+	}
+
+	if tableIndex.IndexLoc != nil {
+		t.indexLoc = tableIndex.IndexLoc
+		t.bloomLoc = tableIndex.BloomLoc
+		t.numOfBlocks = int(tableIndex.NumOfBlocks)
+		t.smallest = tableIndex.Smallest
+		t.biggest = tableIndex.Biggest
+		return t, nil
 	}
 
-	//read bloom filter
+	//old table, read bloom filter
 	if t.bf, err = z.JSONUnmarshal(tableIndex.BloomFilter); err != nil {
 		return nil, err
 	}
 
 	//clone BlockOffset
+	t.blockIndex = make([]*pspb.BlockOffset, len(tableIndex.Offsets))
 	for i, offset := range tableIndex.Offsets {
 		t.blockIndex[i] = proto.Clone(offset).(*pspb.BlockOffset)
 	}
+	t.numOfBlocks = len(t.blockIndex)
 
 	//get range of table
 	if err = t.initBiggestAndSmallest(); err != nil {
@@ -108,10 +176,90 @@ func OpenTable(stream streamclient.StreamClient,
 	return t, nil
 }
 
-//TODO: cache block
-func (t *Table) block(idx int) (*pb.Block, error) {
-	extentID := t.blockIndex[idx].ExtentID
-	offset := t.blockIndex[idx].Offset
+//readRawBlock reads the block at loc, returns decoded data
+func (t *Table) readRawBlock(loc *pspb.Location, blockType pspb.RawBlockType) ([]byte, error) {
+	blocks, err := t.stream.Read(context.Background(), loc.ExtentID, loc.Offset, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) != 1 {
+		return nil, errors.Errorf("len of blocks is not 1")
+	}
+	block, err := decodeBlock(t.keys, blocks[0])
+	if err != nil {
+		return nil, err
+	}
+	var blockMeta pspb.RawBlockMeta
+	if err = blockMeta.Unmarshal(block.UserData); err != nil {
+		return nil, err
+	}
+	if blockMeta.Type != blockType {
+		return nil, errors.Errorf("block type is %v, expected %v", blockMeta.Type, blockType)
+	}
+	return block.Data[:blockMeta.UnCompressedSize], nil
+}
+
+//blockOffsets returns index of data blocks, it may read index block from stream
+func (t *Table) blockOffsets() ([]*pspb.BlockOffset, error) {
+	t.RLock()
+	index := t.blockIndex
+	t.RUnlock()
+	if index != nil {
+		return index, nil
+	}
+	if v, ok := t.indexCache.get(t.indexLoc); ok {
+		return v.([]*pspb.BlockOffset), nil
+	}
+	data, err := t.readRawBlock(t.indexLoc, pspb.RawBlockType_index)
+	if err != nil {
+		return nil, err
+	}
+	var tableIndex pspb.TableIndex
+	if err = tableIndex.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	if t.indexCache == nil {
+		t.Lock()
+		t.blockIndex = tableIndex.Offsets
+		t.Unlock()
+	} else {
+		t.indexCache.set(t.indexLoc, tableIndex.Offsets, int64(len(data)))
+	}
+	return tableIndex.Offsets, nil
+}
+
+//bloomFilter returns bloom filter of new tables, it may read bloom block from stream
+func (t *Table) bloomFilter() (y.Filter, error) {
+	t.RLock()
+	filter := t.filter
+	t.RUnlock()
+	if filter != nil {
+		return filter, nil
+	}
+	if v, ok := t.indexCache.get(t.bloomLoc); ok {
+		return v.(y.Filter), nil
+	}
+	data, err := t.readRawBlock(t.bloomLoc, pspb.RawBlockType_bloom)
+	if err != nil {
+		return nil, err
+	}
+	filter = y.Filter(data)
+	if t.indexCache == nil {
+		t.Lock()
+		t.filter = filter
+		t.Unlock()
+	} else {
+		t.indexCache.set(t.bloomLoc, filter, int64(len(filter)))
+	}
+	return filter, nil
+}
+
+func (t *Table) block(bo *pspb.BlockOffset) (*pb.Block, error) {
+	extentID := bo.ExtentID
+	offset := bo.Offset
+	if block, ok := t.cache.get(extentID, offset); ok {
+		return block, nil
+	}
 	blocks, err := t.stream.Read(context.Background(), extentID, offset, 1)
 	if err != nil {
 		return nil, err
@@ -119,9 +267,80 @@ func (t *Table) block(idx int) (*pb.Block, error) {
 	if len(blocks) != 1 {
 		return nil, errors.Errorf("len of blocks is not 1")
 	}
-	return blocks[0], nil
+	block, err := decodeBlock(t.keys, blocks[0])
+	if err != nil {
+		return nil, err
+	}
+	if atomic.LoadInt32(&t.dropped) == 0 {
+		t.cache.set(extentID, offset, block)
+	}
+	return block, nil
+}
+
+//DropCache evicts all blocks of the table from block cache and index cache, it is called when
+//refcount reaches zero
+func (t *Table) DropCache() {
+	atomic.StoreInt32(&t.dropped, 1)
+	if t.cache != nil {
+		index, err := t.blockOffsets()
+		if err != nil {
+			xlog.Logger.Warnf("failed to read index of table %v: %v", t.Loc, err)
+		}
+		for _, bo := range index {
+			t.cache.del(bo.ExtentID, bo.Offset)
+		}
+	}
+	if t.indexLoc != nil {
+		t.indexCache.del(t.indexLoc)
+		t.indexCache.del(t.bloomLoc)
+	}
 }
 
+//decryptBlock returns plaintext of block data, data is returned if the block is not encrypted
+func decryptBlock(keys *encryption.KeyRegistry, blockMeta *pspb.RawBlockMeta, data []byte) ([]byte, error) {
+	if blockMeta.KeyID == 0 {
+		return data, nil
+	}
+	if blockMeta.EncryptedSize > uint32(len(data)) {
+		return nil, errors.Errorf("encrypted size %d is larger than block %d", blockMeta.EncryptedSize, len(data))
+	}
+	return keys.Decrypt(blockMeta.KeyID, data[:blockMeta.EncryptedSize])
+}
+
+//decodeBlock returns a block whose data is decrypted and uncompressed, RawBlockMeta is unchanged
+func decodeBlock(keys *encryption.KeyRegistry, block *pb.Block) (*pb.Block, error) {
+	var blockMeta pspb.RawBlockMeta
+	if err := blockMeta.Unmarshal(block.UserData); err != nil {
+		return nil, err
+	}
+	if blockMeta.Compression == pspb.CompressionType_None && blockMeta.KeyID == 0 {
+		return block, nil
+	}
+	data, err := decryptBlock(keys, &blockMeta, block.Data)
+	if err != nil {
+		return nil, err
+	}
+	if blockMeta.Compression != pspb.CompressionType_None {
+		if blockMeta.CompressedSize > uint32(len(data)) {
+			return nil, errors.Errorf("compressed size %d is larger than block %d", blockMeta.CompressedSize, len(data))
+		}
+		if data, err = y.Decompress(blockMeta.Compression, data[:blockMeta.CompressedSize]); err != nil {
+			return nil, err
+		}
+	}
+	if uint32(len(data)) != blockMeta.UnCompressedSize {
+		return nil, errors.Errorf("uncompressed size is %d, expected %d", len(data), blockMeta.UnCompressedSize)
+	}
+	return &pb.Block{
+		BlockLength: block.BlockLength,
+		UserData:    block.UserData,
+		Data:        data,
+	}, nil
+}
+
+// EstimatedSize returns the total size of key-values stored in this table
+func (t *Table) EstimatedSize() uint64 { return t.estimatedSize }
+
 // Smallest is its smallest key, or nil if there are none
 func (t *Table) Smallest() []byte { return t.smallest }
 
@@ -145,10 +364,20 @@ func (t *Table) initBiggestAndSmallest() error {
 func (t *Table) NewIterator(reversed bool) *Iterator {
 	t.IncrRef() // Important.
 	ti := &Iterator{t: t, reversed: reversed}
+	ti.index, ti.indexErr = t.blockOffsets()
 	ti.next()
 	return ti
 }
 
 func (t *Table) DoesNotHave(hash uint64) bool {
-	return !t.bf.Has(hash)
+	if t.bf != nil {
+		return !t.bf.Has(hash)
+	}
+	filter, err := t.bloomFilter()
+	if err != nil {
+		//the table has to be searched
+		xlog.Logger.Warnf("failed to read bloom filter of table %v: %v", t.Loc, err)
+		return false
+	}
+	return !filter.MayContain(hash)
 }
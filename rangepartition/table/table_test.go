@@ -21,7 +21,9 @@ import (
 	"math"
 	"sort"
 	"testing"
+	"time"
 
+	"github.com/dgryski/go-farm"
 	"github.com/journeymidnight/autumn/rangepartition/y"
 	"github.com/journeymidnight/autumn/utils"
 
@@ -86,6 +88,76 @@ func TestSeekToFirst(t *testing.T) {
 	}
 }
 
+func TestBlockCache(t *testing.T) {
+	stream, id, offset := buildTestTable(t, "key", 1000)
+	defer stream.Close()
+	cache, err := NewBlockCache(1 << 20)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	table, err := OpenTableWithOptions(stream, nil, cache, nil, id, offset)
+	require.NoError(t, err)
+	defer table.DecrRef()
+
+	it := table.NewIterator(false)
+	defer it.Close()
+	it.seekToFirst()
+	require.True(t, it.Valid())
+	//ristretto sets asynchronously
+	time.Sleep(10 * time.Millisecond)
+	_, ok := cache.get(table.blockIndex[0].ExtentID, table.blockIndex[0].Offset)
+	require.True(t, ok)
+
+	hits := cache.Hits()
+	it.seekToFirst()
+	require.True(t, it.Valid())
+	require.EqualValues(t, "0", string(it.Value().Value))
+	require.Equal(t, hits+1, cache.Hits())
+
+	table.DropCache()
+	_, ok = cache.get(table.blockIndex[0].ExtentID, table.blockIndex[0].Offset)
+	require.False(t, ok)
+}
+
+func TestLazyIndexAndBloom(t *testing.T) {
+	stream, id, offset := buildTestTable(t, "key", 5000)
+	defer stream.Close()
+	indexCache, err := NewIndexCache(1 << 20)
+	require.NoError(t, err)
+	defer indexCache.Close()
+
+	table, err := OpenTableWithOptions(stream, nil, nil, indexCache, id, offset)
+	require.NoError(t, err)
+	defer table.DecrRef()
+	require.EqualValues(t, key("key", 0), y.ParseKey(table.Smallest()))
+	require.EqualValues(t, key("key", 4999), y.ParseKey(table.Biggest()))
+
+	var falsePositive int
+	for i := 0; i < 5000; i++ {
+		require.False(t, table.DoesNotHave(farm.Fingerprint64([]byte(key("key", i)))))
+		if !table.DoesNotHave(farm.Fingerprint64([]byte(key("nokey", i)))) {
+			falsePositive++
+		}
+	}
+	require.True(t, falsePositive < 5000*3/100, "false positive %d", falsePositive)
+
+	it := table.NewIterator(false)
+	defer it.Close()
+	it.seekToLast()
+	require.True(t, it.Valid())
+	require.EqualValues(t, "4999", string(it.Value().Value))
+	//loaded blocks are kept in indexCache instead of table
+	require.Nil(t, table.blockIndex)
+	require.Nil(t, table.filter)
+	time.Sleep(10 * time.Millisecond)
+	_, ok := indexCache.get(table.indexLoc)
+	require.True(t, ok)
+
+	table.DropCache()
+	_, ok = indexCache.get(table.indexLoc)
+	require.False(t, ok)
+}
+
 func TestSeekToLast(t *testing.T) {
 	for _, n := range []int{101, 199, 200, 250, 9999, 10000} {
 		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
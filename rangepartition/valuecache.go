@@ -0,0 +1,141 @@
+package rangepartition
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+
+	"github.com/dgraph-io/ristretto"
+	"github.com/journeymidnight/autumn/rangepartition/y"
+	"github.com/pkg/errors"
+)
+
+/*
+big value cache:
+1. key是valuePointer的(extentID, offset), value是解密之后的value, 和block cache分开, 有自己的内存上限
+2. admission: 超过maxCost/16的value不进入cache; 其他value由ristretto的TinyLFU决定,
+只读过一次的value不会挤掉经常读的value
+3. Get只读一个block; RangeValues读到cache里没有的value时, 从这个offset开始一次读valuePrefetchBlocks个block,
+后面的block通常是同一批写入的相邻key的value
+4. extent里的数据不会修改, value log gc之后旧的位置不再被引用, 所以不需要主动删除
+*/
+
+const (
+	//number of blocks read at once when a value is missing during range scan
+	valuePrefetchBlocks = 8
+)
+
+//ValueCache caches big values stored in log stream, it is shared by all partitions of a
+//partition server. A nil *ValueCache caches nothing
+type ValueCache struct {
+	cache   *ristretto.Cache
+	maxItem int64
+}
+
+//NewValueCache creates a value cache which holds at most size bytes
+func NewValueCache(size int64) (*ValueCache, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		//big values are larger than 4KB
+		NumCounters: size/(4<<10)*10 + 1,
+		MaxCost:     size,
+		BufferItems: 64,
+		Metrics:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ValueCache{cache: cache, maxItem: size / 16}, nil
+}
+
+func valueCacheKey(extentID uint64, offset uint32) []byte {
+	var key [12]byte
+	binary.BigEndian.PutUint64(key[:], extentID)
+	binary.BigEndian.PutUint32(key[8:], offset)
+	return key[:]
+}
+
+func (c *ValueCache) get(extentID uint64, offset uint32) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	v, ok := c.cache.Get(valueCacheKey(extentID, offset))
+	if !ok {
+		return nil, false
+	}
+	return v.([]byte), true
+}
+
+func (c *ValueCache) set(extentID uint64, offset uint32, value []byte) {
+	if c == nil || int64(len(value)) > c.maxItem {
+		return
+	}
+	c.cache.Set(valueCacheKey(extentID, offset), value, int64(len(value)))
+}
+
+//Hits returns the number of cache hits
+func (c *ValueCache) Hits() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Metrics.Hits()
+}
+
+//Misses returns the number of cache misses
+func (c *ValueCache) Misses() uint64 {
+	if c == nil {
+		return 0
+	}
+	return c.cache.Metrics.Misses()
+}
+
+func (c *ValueCache) Close() {
+	if c == nil {
+		return
+	}
+	c.cache.Close()
+}
+
+//readValue returns the big value of vp. If prefetch > 1, the following blocks are also read and
+//their values are saved in valueCache and local(could be nil)
+func (rp *RangePartition) readValue(vp valuePointer, prefetch uint32, local map[valuePointer][]byte) ([]byte, error) {
+	if value, ok := rp.valueCache.get(vp.extentID, vp.offset); ok {
+		return value, nil
+	}
+	blocks, err := rp.blockReader.Read(context.Background(), vp.extentID, vp.offset, prefetch)
+	//reached the end of extent
+	if err == io.EOF && len(blocks) > 0 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, errors.Errorf("no block at extent %d, offset %d", vp.extentID, vp.offset)
+	}
+
+	var ret []byte
+	offset := vp.offset
+	for i, block := range blocks {
+		blockOffset := offset
+		offset += block.BlockLength + 512
+		entries := y.ExtractLogEntry(block)
+		//big value is the only entry in its block, mixed blocks are not referenced by value pointers
+		if i > 0 && len(entries) != 1 {
+			continue
+		}
+		if err = rp.keys.DecryptEntry(entries[0]); err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			continue
+		}
+		value := entries[0].Value
+		rp.valueCache.set(vp.extentID, blockOffset, value)
+		if i == 0 {
+			ret = value
+		} else if local != nil {
+			local[valuePointer{extentID: vp.extentID, offset: blockOffset}] = value
+		}
+	}
+	return ret, nil
+}
@@ -3,8 +3,11 @@ package rangepartition
 import (
 	"bytes"
 	"context"
+	"sort"
+	"time"
 
 	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
 	"github.com/journeymidnight/autumn/rangepartition/y"
 	"github.com/journeymidnight/autumn/streamclient"
 	"github.com/journeymidnight/autumn/utils"
@@ -15,23 +18,70 @@ import (
 //compact valuelog
 func (rp *RangePartition) writeValueLog(reqs []*request) ([]*pb.EntryInfo, valuePointer, error) {
 
+	if rp.blobStream != nil {
+		if err := rp.writeBlobs(reqs); err != nil {
+			return nil, valuePointer{}, err
+		}
+	}
+
 	var entries []*pb.EntryInfo
 
 	for _, req := range reqs {
 		entries = append(entries, req.entries...)
 	}
+	//requests of checkpoint are empty
+	if len(entries) == 0 {
+		return nil, rp.vhead, nil
+	}
 
 	extentID, offset, err := rp.logStream.AppendEntries(context.Background(), entries)
 	if err != nil {
 		return nil, valuePointer{}, err
 	}
 
-	//FIXME
-	//if rp.logStream.Size() > 4GB then rp.logStream.Truncate()
 	return entries, valuePointer{extentID: extentID, offset: offset}, nil
 }
 
-func replayLog(stream streamclient.StreamClient, startExtentID uint64, startOffset uint32, replay bool, replayFunc func(*pb.EntryInfo) (bool, error)) error {
+//writeBlobs writes big values to blob stream, and replaces them in reqs by entries whose value
+//is the valuePointer, so log stream is small and it can be truncated after memtable is flushed
+func (rp *RangePartition) writeBlobs(reqs []*request) error {
+	var blobs []*pb.EntryInfo
+	for _, req := range reqs {
+		for _, e := range req.entries {
+			if !rp.opt.Stream.ShouldWriteValueToLSM(e.Log) {
+				blobs = append(blobs, e)
+			}
+		}
+	}
+	if len(blobs) == 0 {
+		return nil
+	}
+	//ExtentID and Offset of each big value are filled, and it is marked by BitValuePointer
+	if _, _, err := rp.blobStream.AppendEntries(context.Background(), blobs); err != nil {
+		return err
+	}
+	for _, req := range reqs {
+		for i, e := range req.entries {
+			if e.Log.Meta&uint32(y.BitValuePointer) == 0 {
+				continue
+			}
+			vp := valuePointer{e.ExtentID, e.Offset, uint32(len(e.Log.Value))}
+			req.entries[i] = &pb.EntryInfo{
+				Log: &pb.Entry{
+					Key:       e.Log.Key,
+					Value:     vp.Encode(),
+					Meta:      e.Log.Meta&^uint32(y.BitValuePointer) | uint32(y.BitBlobPointer),
+					UserMeta:  e.Log.UserMeta,
+					ExpiresAt: e.Log.ExpiresAt,
+				},
+			}
+		}
+	}
+	return nil
+}
+
+//replayLog reads entries from stream, encrypted entries are decrypted by keys before replayFunc
+func replayLog(stream streamclient.StreamClient, keys *encryption.KeyRegistry, startExtentID uint64, startOffset uint32, replay bool, replayFunc func(*pb.EntryInfo) (bool, error)) error {
 	var opt streamclient.ReadOption
 	if replay {
 		opt = opt.WithReplay()
@@ -51,6 +101,9 @@ func replayLog(stream streamclient.StreamClient, startExtentID uint64, startOffs
 			break
 		}
 		ei := iter.Next()
+		if err = keys.DecryptEntry(ei.Log); err != nil {
+			return err
+		}
 		next, err := replayFunc(ei)
 		if err != nil {
 			return err
@@ -63,10 +116,48 @@ func replayLog(stream streamclient.StreamClient, startExtentID uint64, startOffs
 	return nil
 }
 
-//policy
-func (rp *RangePartition) pickLog(discardRatio float64) *pb.StreamInfo {
-	return rp.discard.MaxDiscard()
+/*
+value log gc:
+1. compaction丢弃big value(旧版本, 删除或者过期)时, 记录value所在extent的discard bytes, 保存在PM的PART/{id}/discard
+2. truncate只能删除stream前面的extent, 所以候选extent是每个blob stream的第一个extent, blob stream按照discard从大到小检查,
+正在写的blob stream(PART/{id}/blobStreams的最后一个)不检查最后一个extent, 也不会被删除
+3. 先读一遍extent, 计算live big value的大小, discard比例(1-live/total)不小于discardRatio时, 再读一遍,
+把live的value重新写入(key和version不变, 读的时候新的table优先), 写入完成之后truncate这个extent,
+blob stream最后一个extent回收之后, 从PART/{id}/blobStreams删除这个blob stream
+4. big value写入blob stream, log stream(WAL)里面只有big value的valuePointer(BitBlobPointer), memtable flush之后,
+replay起点所在extent之前的extent直接truncate, 以前写在log stream里的big value先重写到blob stream
+5. 没有blob stream的partition, big value还是写在log stream里面, log stream的第一个extent(不能是replay起点所在的extent)
+和blob stream一样参与gc
+6. 检查过但是没有回收的extent, 在discard增加之前不再检查
+*/
 
+const (
+	//entries rewritten by gc in one request
+	gcBatchCount = 64
+	gcBatchSize  = 16 * MB
+)
+
+type gcCandidate struct {
+	stream streamclient.StreamClient
+	blob   *pb.StreamInfo //nil if the candidate is in log stream
+	active bool           //rp.blobStream, which is not closed or removed by gc
+}
+
+//scanFrontExtent calls fn for each entry in the first extent of stream, returns the first extent
+//and the next extent(0 if the first extent is the last one)
+func scanFrontExtent(stream streamclient.StreamClient, keys *encryption.KeyRegistry, fn func(*pb.EntryInfo) error) (uint64, uint64, error) {
+	var front, next uint64
+	err := replayLog(stream, keys, 0, 0, false, func(ei *pb.EntryInfo) (bool, error) {
+		if front == 0 {
+			front = ei.ExtentID
+		}
+		if ei.ExtentID != front {
+			next = ei.ExtentID
+			return false, nil
+		}
+		return true, fn(ei)
+	})
+	return front, next, err
 }
 
 func discardEntry(ei *pb.EntryInfo, vs y.ValueStruct) bool {
@@ -80,82 +171,334 @@ func discardEntry(ei *pb.EntryInfo, vs y.ValueStruct) bool {
 	return false
 }
 
-func (rp *RangePartition) runGC(discardRatio float64) {
-	streamInfo := rp.pickLog(discardRatio)
-	if streamInfo == nil {
-		return
+//isLive returns true if ei is the latest version of its key, and the value is not saved in table
+func (rp *RangePartition) isLive(ei *pb.EntryInfo) bool {
+	if ei.Log.Key == nil && ei.Log.Value == nil {
+		return false
 	}
+	userKey := y.ParseKey(ei.Log.Key)
+	if bytes.Compare(userKey, rp.StartKey) < 0 {
+		return false
+	}
+	if len(rp.EndKey) > 0 && bytes.Compare(rp.EndKey, userKey) < 0 {
+		return false
+	}
+	//startKey <=userKey <= endKey
 
-	var candidate streamclient.StreamClient
-
-	candidate = rp.openStream(*streamInfo)
-
-	candidate.Connect()
-	var count, moved int
-	var freed uint64
-	var size uint64
-	wb := make([]*pb.EntryInfo, 0, 100)
+	vs := rp.getValueStruct(userKey, 0) //get the lasted version, do not support multiversion
+	if discardEntry(ei, vs) {
+		return false
+	}
+	//small value is saved in table
+	if vs.Meta&y.BitValuePointer == 0 {
+		return false
+	}
+	var vp valuePointer
+	vp.Decode(vs.Value)
+	return vp.extentID == ei.ExtentID && vp.offset == ei.Offset
+}
 
-	fe := func(ei *pb.EntryInfo) (bool, error) {
-		count++
-		if count%100000 == 0 {
-			xlog.Logger.Debugf("Processing entry %d", count)
+//replayHead returns the extent where replay starts, 0 if there is no table
+func (rp *RangePartition) replayHead() uint64 {
+	rp.tableLock.RLock()
+	defer rp.tableLock.RUnlock()
+	var seq, head uint64
+	for _, t := range rp.tables {
+		if t.LastSeq > seq {
+			seq = t.LastSeq
+			head = t.VpExtentID
 		}
+	}
+	return head
+}
 
-		freed += ei.EstimatedSize
-		if ei.Log.Key == nil && ei.Log.Value == nil {
-			return true, nil
+func (rp *RangePartition) gcCandidates() []gcCandidate {
+	var candidates []gcCandidate
+	var active uint64
+	if rp.blobStream == nil {
+		candidates = append(candidates, gcCandidate{stream: rp.logStream})
+	} else {
+		//log stream is truncated by truncateLog
+		si := rp.blobStream.StreamInfo()
+		active = si.StreamID
+		rp.discard.UpdateBlobStream(si)
+	}
+	var blobs []pb.StreamInfo
+	for _, si := range rp.discard.BlobStreamInfos() {
+		if len(si.ExtentIDs) > 0 {
+			blobs = append(blobs, si)
 		}
+	}
+	sort.Slice(blobs, func(i, j int) bool {
+		return rp.discard.Discard(blobs[i].ExtentIDs[0]) > rp.discard.Discard(blobs[j].ExtentIDs[0])
+	})
+	for i := range blobs {
+		if blobs[i].StreamID == active {
+			candidates = append(candidates, gcCandidate{stream: rp.blobStream, blob: &blobs[i], active: true})
+			continue
+		}
+		candidates = append(candidates, gcCandidate{stream: rp.openStream(blobs[i]), blob: &blobs[i]})
+	}
+	return candidates
+}
 
-		userKey := y.ParseKey(ei.Log.Key)
+//runGC reclaims at most one extent whose discard ratio is not less than discardRatio,
+//returns true if an extent is reclaimed
+func (rp *RangePartition) runGC(discardRatio float64) bool {
+	for _, c := range rp.gcCandidates() {
+		if c.blob == nil && rp.gcLogFront != 0 && !rp.discard.NeedCheck(rp.gcLogFront) {
+			continue
+		}
+		//the last extent of rp.blobStream is being written
+		if c.active && (len(c.blob.ExtentIDs) < 2 || !rp.discard.NeedCheck(c.blob.ExtentIDs[0])) {
+			continue
+		}
+		if c.blob != nil && !c.active {
+			if err := c.stream.Connect(); err != nil {
+				xlog.Logger.Warnf("gc: failed to connect blob stream %d: %v", c.blob.StreamID, err)
+				continue
+			}
+			//there is no new entry in blob streams
+			if !rp.discard.NeedCheck(c.blob.ExtentIDs[0]) {
+				c.stream.Close()
+				continue
+			}
+		}
+		ok, err := rp.gcFrontExtent(c, discardRatio)
+		if c.blob != nil && !c.active {
+			c.stream.Close()
+		}
+		if err != nil {
+			xlog.Logger.Warnf("gc of partition %d: %v", rp.PartID, err)
+			continue
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
 
-		if bytes.Compare(userKey, rp.StartKey) < 0 {
-			return true, nil
+func (rp *RangePartition) gcFrontExtent(c gcCandidate, discardRatio float64) (bool, error) {
+	//check discard ratio
+	var total, live int64
+	front, next, err := scanFrontExtent(c.stream, rp.keys, func(ei *pb.EntryInfo) error {
+		total += int64(ei.EstimatedSize)
+		if rp.isLive(ei) {
+			live += int64(ei.EstimatedSize)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if front == 0 {
+		return false, nil
+	}
+	if c.blob == nil {
+		rp.gcLogFront = front
+		//entries after replay head are not in tables
+		head := rp.replayHead()
+		if next == 0 || head == 0 || front == head {
+			return false, nil
 		}
-		if len(rp.EndKey) > 0 && bytes.Compare(rp.EndKey, userKey) < 0 {
-			return true, nil
+	} else if c.active && next == 0 {
+		return false, nil
+	}
+	discard := rp.discard.Discard(front)
+	if total > 0 && float64(total-live) < float64(total)*discardRatio {
+		rp.discard.SetChecked(front, discard)
+		return false, nil
+	}
+	if live > 0 {
+		xlog.Logger.Infof("gc of partition %d: rewrite extent %d, %d of %d bytes are live", rp.PartID, front, live, total)
+		if err = rp.rewriteFrontExtent(c.stream); err != nil {
+			return false, err
 		}
+	}
 
-		//startKey <=userKey <= endKey
+	//all live values are rewritten, reclaim the extent
+	if next != 0 {
+		_, si, err := c.stream.Truncate(context.Background(), next)
+		if err != nil {
+			return false, err
+		}
+		if c.blob != nil {
+			rp.discard.UpdateBlobStream(si)
+		}
+	} else {
+		//the last extent of blob stream
+		//FIXME: ask stream manager to delete the blob stream
+		rp.discard.UpdateBlobStream(pb.StreamInfo{StreamID: c.blob.StreamID})
+		if err = rp.pmClient.SetBlobStreams(rp.PartID, rp.blobStreamIDs()); err != nil {
+			return false, err
+		}
+	}
+	rp.discard.RemoveExtent(front)
+	if discard > 0 {
+		rp.saveDiscardStats()
+	}
+	return true, nil
+}
 
-		vs := rp.getValueStruct(userKey, 0) //get the lasted version, do not support multiversion
+//rewriteFrontExtent rewrites live values in the first extent of stream with the same keys
+func (rp *RangePartition) rewriteFrontExtent(stream streamclient.StreamClient) error {
+	var reqs []*request
+	var wb []*pb.EntryInfo
+	var size uint64
+	send := func() error {
+		if len(wb) == 0 {
+			return nil
+		}
+		req, err := rp.sendToWriteCh(wb)
+		if err != nil {
+			return err
+		}
+		reqs = append(reqs, req)
+		wb = nil
+		size = 0
+		return nil
+	}
+	_, _, err := scanFrontExtent(stream, rp.keys, func(ei *pb.EntryInfo) error {
+		if !rp.isLive(ei) {
+			return nil
+		}
+		//gc read does not return big value
+		value, err := rp.readValue(valuePointer{ei.ExtentID, ei.Offset, 0}, 1, nil)
+		if err != nil {
+			return err
+		}
+		//keep seqNum
+		wb = append(wb, &pb.EntryInfo{
+			Log: &pb.Entry{
+				Key:       ei.Log.Key,
+				Value:     value,
+				Meta:      ei.Log.Meta &^ uint32(y.BitValuePointer),
+				UserMeta:  ei.Log.UserMeta,
+				ExpiresAt: ei.Log.ExpiresAt,
+			},
+		})
+		size += ei.EstimatedSize
+		if len(wb) >= gcBatchCount || size >= gcBatchSize {
+			return send()
+		}
+		return nil
+	})
+	if err == nil {
+		err = send()
+	}
+	//Wait() will release req
+	for _, req := range reqs {
+		if e := req.Wait(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
 
-		if discardEntry(ei, vs) {
-			return true, nil
+//blobStreamIDs returns blob streams to be saved in PM, rp.blobStream is the last one
+func (rp *RangePartition) blobStreamIDs() []uint64 {
+	var active uint64
+	if rp.blobStream != nil {
+		active = rp.blobStream.StreamInfo().StreamID
+	}
+	var ids []uint64
+	for _, id := range rp.discard.BlobStreams() {
+		if id != active {
+			ids = append(ids, id)
 		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	if active != 0 {
+		ids = append(ids, active)
+	}
+	return ids
+}
 
-		utils.AssertTrue(len(vs.Value) > 0)
-		var vp valuePointer
-		vp.Decode(vs.Value)
-		if vp.extentID == ei.ExtentID && vp.offset == ei.Offset {
-			moved++
-			//write ne to mt
-			//keep seqNum
-			ne := &pb.EntryInfo{
-				Log: &pb.Entry{
-					Key:   ei.Log.Key,
-					Value: ei.Log.Value,
-				},
-			}
+//saveDiscardStats saves discard stats in PM
+func (rp *RangePartition) saveDiscardStats() {
+	if err := rp.pmClient.SetDiscardStats(rp.PartID, rp.discard.Stats()); err != nil {
+		xlog.Logger.Warnf("failed to save discard stats of partition %d: %v", rp.PartID, err)
+	}
+}
 
-			//?batch?
-			if len(wb) > 4 || ei.EstimatedSize+size > 16*MB {
-				req, err := rp.sendToWriteCh(wb)
-				if err != nil {
-					return false, err
+func (rp *RangePartition) startGC(discardRatio float64) {
+	rp.gcStopper = utils.NewStopper()
+	rp.gcStopper.RunWorker(func() {
+		randTicker := utils.NewRandomTicker(time.Minute, 2*time.Minute)
+		defer randTicker.Stop()
+		for {
+			select {
+			case <-randTicker.C:
+			case <-rp.gcStopper.ShouldStop():
+				return
+			}
+			//reclaim extents until there is no candidate
+			for rp.runGC(discardRatio) {
+				select {
+				case <-rp.gcStopper.ShouldStop():
+					return
+				default:
 				}
-				go func() {
-					//Wait() will release req
-					req.Wait()
-				}()
 			}
-			wb = append(wb, ne)
-			size += ei.EstimatedSize
-
 		}
-		return true, nil
+	})
+}
+
+//StartGC runs value log gc in background, extents are reclaimed if discarded bytes are more than
+//discardRatio of them
+func (rp *RangePartition) StartGC(discardRatio float64) {
+	if discardRatio <= 0 || discardRatio > 1 {
+		return
 	}
+	rp.startGC(discardRatio)
+}
 
-	replayLog(candidate, 0, 0, false, fe)
+//startTruncateLog truncates log stream after memtable is flushed if big values are written to
+//blob stream
+func (rp *RangePartition) startTruncateLog() {
+	if rp.blobStream == nil {
+		return
+	}
+	//extents flushed before last close
+	rp.triggerTruncateLog()
+	rp.truncateStopper.RunWorker(func() {
+		for {
+			select {
+			case <-rp.walCh:
+			case <-rp.truncateStopper.ShouldStop():
+				return
+			}
+			rp.truncateLog()
+		}
+	})
+}
+
+func (rp *RangePartition) triggerTruncateLog() {
+	select {
+	case rp.walCh <- struct{}{}:
+	default:
+	}
+}
 
+//truncateLog removes extents of log stream before the replay head, big values written to log
+//stream before there is blob stream are rewritten at first
+func (rp *RangePartition) truncateLog() {
+	for {
+		select {
+		case <-rp.truncateStopper.ShouldStop():
+			return
+		default:
+		}
+		ok, err := rp.gcFrontExtent(gcCandidate{stream: rp.logStream}, 0)
+		if err != nil {
+			xlog.Logger.Warnf("failed to truncate log stream of partition %d: %v", rp.PartID, err)
+			return
+		}
+		if !ok {
+			return
+		}
+	}
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/journeymidnight/autumn/manager/pmclient"
 	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/proto/pspb"
 	"github.com/journeymidnight/autumn/streamclient"
 	"github.com/journeymidnight/autumn/xlog"
 	"github.com/stretchr/testify/require"
@@ -77,7 +80,7 @@ func TestLogReplay(t *testing.T) {
 		},
 	}
 	i := 0
-	replayLog(logStream, extentID, offset, false, func(ei *pb.EntryInfo) (bool, error) {
+	replayLog(logStream, nil, extentID, offset, false, func(ei *pb.EntryInfo) (bool, error) {
 		fmt.Printf("%s\n", ei.Log.Key)
 		require.Equal(t, expecteEI[i], ei)
 		i++
@@ -85,3 +88,126 @@ func TestLogReplay(t *testing.T) {
 	})
 
 }
+
+func TestValueLogGC(t *testing.T) {
+	logStream := streamclient.NewMockStreamClient("log")
+	rowStream := streamclient.NewMockStreamClient("sst")
+	defer logStream.Close()
+	defer rowStream.Close()
+	logStream.SetMaxExtentSize(64 << 10)
+	pmclient := new(pmclient.MockPMClient)
+
+	rp := OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), nil, nil, nil, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
+	value := func(i int, version int) []byte {
+		return []byte(fmt.Sprintf("%08192d", i*1000+version))
+	}
+	//live0..live3 are never overwritten
+	for i := 0; i < 4; i++ {
+		require.NoError(t, rp.Write([]byte(fmt.Sprintf("live%d", i)), value(i, 0)))
+	}
+	for version := 0; version < 3; version++ {
+		for i := 0; i < 20; i++ {
+			require.NoError(t, rp.Write([]byte(fmt.Sprintf("key%02d", i)), value(i, version)))
+		}
+		//flush a table for each version
+		require.NoError(t, rp.close(true))
+		rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+			[]byte(""), []byte(""), pmclient.Tables, nil, pmclient.Discard, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
+	}
+	//old versions are dropped by compaction when the partition is opened
+	require.NotNil(t, pmclient.Discard)
+	require.True(t, len(pmclient.Discard.Discard) > 0)
+
+	frontExtent := func() uint64 {
+		front, _, err := scanFrontExtent(logStream, nil, func(*pb.EntryInfo) error { return nil })
+		require.NoError(t, err)
+		return front
+	}
+	front := frontExtent()
+	n := 0
+	for rp.runGC(0.5) {
+		n++
+	}
+	require.True(t, n > 0)
+	require.NotEqual(t, front, frontExtent())
+	_, ok := pmclient.Discard.Discard[front]
+	require.False(t, ok)
+
+	check := func() {
+		for i := 0; i < 4; i++ {
+			v, err := rp.Get([]byte(fmt.Sprintf("live%d", i)), 0)
+			require.NoError(t, err)
+			require.Equal(t, value(i, 0), v)
+		}
+		for i := 0; i < 20; i++ {
+			v, err := rp.Get([]byte(fmt.Sprintf("key%02d", i)), 0)
+			require.NoError(t, err)
+			require.Equal(t, value(i, 2), v)
+		}
+	}
+	check()
+
+	//rewritten values are replayed or flushed
+	require.NoError(t, rp.close(true))
+	rp = OpenRangePartition(3, rowStream, logStream, nil, logStream.(streamclient.BlockReader),
+		[]byte(""), []byte(""), pmclient.Tables, nil, pmclient.Discard, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
+	check()
+	require.NoError(t, rp.Close())
+}
+
+func TestBlobStream(t *testing.T) {
+	logStream := streamclient.NewMockStreamClient("log")
+	blobStream := streamclient.NewMockStreamClient("blob")
+	rowStream := streamclient.NewMockStreamClient("sst")
+	defer logStream.Close()
+	defer blobStream.Close()
+	defer rowStream.Close()
+	logStream.SetMaxExtentSize(16 << 10)
+	blockReader := streamclient.MockBlockReader{logStream, blobStream}
+	pmclient := new(pmclient.MockPMClient)
+
+	open := func() *RangePartition {
+		return OpenRangePartition(3, rowStream, logStream, blobStream, blockReader,
+			[]byte(""), []byte(""), pmclient.Tables, nil, pmclient.Discard, pmclient, streamclient.OpenMockStreamClient, streamclient.UpdateStreamMock, Deps{}, testOptions(pspb.CompressionType_None))
+	}
+	value := func(i int) []byte {
+		if i%2 == 0 {
+			return []byte(fmt.Sprintf("%08192d", i))
+		}
+		return []byte(fmt.Sprintf("%d", i))
+	}
+	check := func(rp *RangePartition, n int) {
+		for i := 0; i < n; i++ {
+			v, err := rp.Get([]byte(fmt.Sprintf("key%03d", i)), 0)
+			require.NoError(t, err)
+			require.Equal(t, value(i), v)
+		}
+	}
+
+	rp := open()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, rp.Write([]byte(fmt.Sprintf("key%03d", i)), value(i)))
+	}
+	check(rp, 100)
+	//big values are not in log stream
+	require.NoError(t, replayLog(logStream, nil, 0, 0, true, func(ei *pb.EntryInfo) (bool, error) {
+		require.True(t, len(ei.Log.Value) < 1024)
+		return true, nil
+	}))
+
+	//replay valuePointers from log stream
+	require.NoError(t, rp.close(false))
+	rp = open()
+	check(rp, 100)
+
+	//log stream is truncated after memtable is flushed
+	require.NoError(t, rp.close(true))
+	rp = open()
+	require.Eventually(t, func() bool {
+		front, _, err := scanFrontExtent(logStream, nil, func(*pb.EntryInfo) error { return nil })
+		return err == nil && front == rp.replayHead()
+	}, 5*time.Second, 10*time.Millisecond)
+	check(rp, 100)
+	require.NoError(t, rp.Close())
+}
@@ -0,0 +1,68 @@
+package y
+
+import "math"
+
+//Filter is a bloom filter in leveldb format: bit array followed by one byte of number of hash
+//functions. Each key is hashed by farm.Fingerprint64, the low and high 32 bits are used for
+//double hashing
+type Filter []byte
+
+//BloomBitsPerKey returns bits per key for numEntries keys and false positive rate fp
+func BloomBitsPerKey(numEntries int, fp float64) int {
+	if numEntries <= 0 {
+		return 1
+	}
+	size := -1 * float64(numEntries) * math.Log(fp) / math.Pow(float64(0.69314718056), 2)
+	return int(math.Ceil(size / float64(numEntries)))
+}
+
+//NewFilter builds a bloom filter of keyHashes
+func NewFilter(keyHashes []uint64, bitsPerKey int) Filter {
+	k := uint32(float64(bitsPerKey) * 0.69)
+	if k < 1 {
+		k = 1
+	}
+	if k > 30 {
+		k = 30
+	}
+	nBits := len(keyHashes) * bitsPerKey
+	//small filters have high false positive rate
+	if nBits < 64 {
+		nBits = 64
+	}
+	nBytes := (nBits + 7) / 8
+	nBits = nBytes * 8
+	filter := make([]byte, nBytes+1)
+	for _, hash := range keyHashes {
+		h, delta := uint32(hash), uint32(hash>>32)
+		for j := uint32(0); j < k; j++ {
+			bitPos := h % uint32(nBits)
+			filter[bitPos/8] |= 1 << (bitPos % 8)
+			h += delta
+		}
+	}
+	filter[nBytes] = uint8(k)
+	return Filter(filter)
+}
+
+//MayContain returns false if the key of hash is definitely not in the filter
+func (f Filter) MayContain(hash uint64) bool {
+	if len(f) < 2 {
+		return true
+	}
+	k := f[len(f)-1]
+	if k > 30 {
+		//reserved for other encodings
+		return true
+	}
+	nBits := uint32(8 * (len(f) - 1))
+	h, delta := uint32(hash), uint32(hash>>32)
+	for j := uint8(0); j < k; j++ {
+		bitPos := h % nBits
+		if f[bitPos/8]&(1<<(bitPos%8)) == 0 {
+			return false
+		}
+		h += delta
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package y
+
+import (
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/pkg/errors"
+)
+
+//ParseCompression parses "none" or "snappy"
+func ParseCompression(name string) (pspb.CompressionType, error) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		return pspb.CompressionType_None, nil
+	case "snappy":
+		return pspb.CompressionType_Snappy, nil
+	default:
+		return pspb.CompressionType_None, errors.Errorf("unknown compression %s", name)
+	}
+}
+
+//Compress returns compressed data and true, if data is not worth compressing(saves less than 1/8),
+//returns nil and false, caller should write the original data
+func Compress(ct pspb.CompressionType, data []byte) ([]byte, bool) {
+	var out []byte
+	switch ct {
+	case pspb.CompressionType_Snappy:
+		out = snappy.Encode(nil, data)
+	default:
+		return nil, false
+	}
+	if len(out) > len(data)-len(data)/8 {
+		return nil, false
+	}
+	return out, true
+}
+
+//Decompress decompresses data which is compressed by Compress
+func Decompress(ct pspb.CompressionType, data []byte) ([]byte, error) {
+	switch ct {
+	case pspb.CompressionType_None:
+		return data, nil
+	case pspb.CompressionType_Snappy:
+		return snappy.Decode(nil, data)
+	default:
+		return nil, errors.Errorf("unknown compression type %d", ct)
+	}
+}
+
+//DecompressMixedLog returns uncompressed data of a log block
+func DecompressMixedLog(mix *pspb.MixedLog, data []byte) ([]byte, error) {
+	if mix.Compression == pspb.CompressionType_None {
+		return data, nil
+	}
+	if int(mix.CompressedSize) > len(data) {
+		return nil, errors.Errorf("compressed size %d is larger than block %d", mix.CompressedSize, len(data))
+	}
+	return Decompress(mix.Compression, data[:mix.CompressedSize])
+}
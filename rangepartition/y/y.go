@@ -128,6 +128,8 @@ func BytesToU32Slice(b []byte) []uint32 {
 const (
 	BitDelete       byte = 1 << 0    // Set if the key has been deleted.
 	BitValuePointer byte = 1 << 1    // Set if the value is NOT stored directly next to key.
+	BitEncrypted    byte = 1 << 2    // Set if key and value of a log entry are encrypted, never in LSM.
+	BitBlobPointer  byte = 1 << 3    // Set if value of a log entry is a valuePointer into blob stream, never in LSM.
 	ValueThrottle        = (1 << 10) // 1 *KB
 )
 
@@ -138,12 +140,14 @@ func ShouldWriteValueToLSM(e *pb.Entry) bool {
 func ExtractLogEntry(block *pb.Block) []*pb.Entry {
 	var mix pspb.MixedLog
 	utils.Check(mix.Unmarshal(block.UserData))
+	data, err := DecompressMixedLog(&mix, block.Data)
+	utils.Check(err)
 	ret := make([]*pb.Entry, len(mix.Offsets)-1, len(mix.Offsets)-1)
 	//FIXME: offsets换成len, 表示end offset
 	for i := 0; i < len(mix.Offsets)-1; i++ {
 		length := mix.Offsets[i+1] - mix.Offsets[i]
 		entry := new(pb.Entry)
-		err := entry.Unmarshal(block.Data[mix.Offsets[i] : mix.Offsets[i]+length])
+		err := entry.Unmarshal(data[mix.Offsets[i] : mix.Offsets[i]+length])
 		utils.Check(err)
 		ret[i] = entry
 	}
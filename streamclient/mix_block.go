@@ -5,6 +5,7 @@ import (
 
 	"github.com/journeymidnight/autumn/proto/pb"
 	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
 	"github.com/journeymidnight/autumn/rangepartition/y"
 	"github.com/journeymidnight/autumn/utils"
 )
@@ -15,22 +16,34 @@ type mixedBlock struct {
 	tail    int
 }
 
-func NewMixedBlock() *mixedBlock {
+//NewMixedBlock returns an empty block, size is aligned to 512
+func NewMixedBlock(size uint32) *mixedBlock {
+	size = utils.Ceil(size, 512)
 	return &mixedBlock{
 		offsets: new(pspb.MixedLog),
-		data:    make([]byte, MaxMixedBlockSize, MaxMixedBlockSize),
+		data:    make([]byte, size, size),
 		tail:    0,
 	}
 }
 
-func (mb *mixedBlock) CanFill(entry *pb.Entry) bool {
-	if mb.tail+entry.Size() > MaxMixedBlockSize || len(mb.offsets.Offsets) >= MaxEntriesInBlock {
+//CanFill returns true if entry fits in mb or mb is empty, maxEntries is the limit of entries
+func (mb *mixedBlock) CanFill(entry *pb.Entry, maxEntries int) bool {
+	if len(mb.offsets.Offsets) == 0 {
+		return true
+	}
+	if mb.tail+entry.Size() > len(mb.data) || len(mb.offsets.Offsets) >= maxEntries {
 		return false
 	}
 	return true
 }
 
 func (mb *mixedBlock) Fill(entry *pb.Entry) uint32 {
+	//small value with a big key could be bigger than an empty block
+	if mb.tail+entry.Size() > len(mb.data) {
+		data := make([]byte, utils.Ceil(uint32(mb.tail+entry.Size()), 512))
+		copy(data, mb.data[:mb.tail])
+		mb.data = data
+	}
 	mb.offsets.Offsets = append(mb.offsets.Offsets, uint32(mb.tail))
 	entry.MarshalTo(mb.data[mb.tail:])
 	offset := mb.tail
@@ -38,71 +51,113 @@ func (mb *mixedBlock) Fill(entry *pb.Entry) uint32 {
 	return uint32(offset)
 }
 
-func (mb *mixedBlock) ToBlock() *pb.Block {
+func (mb *mixedBlock) ToBlock(compression pspb.CompressionType) *pb.Block {
 	mb.offsets.Offsets = append(mb.offsets.Offsets, uint32(mb.tail))
 	//len(mb.offsets) == actualSizeOfLog + 1
+	data := compressLog(mb.offsets, compression, mb.data, mb.data[:mb.tail])
 	userData, err := mb.offsets.Marshal()
 	utils.Check(err)
 	block := &pb.Block{
-		BlockLength: uint32(MaxMixedBlockSize),
+		BlockLength: uint32(len(data)),
 		UserData:    userData,
-		CheckSum:    utils.AdlerCheckSum(mb.data),
-		Data:        mb.data,
+		CheckSum:    utils.AdlerCheckSum(data),
+		Data:        data,
 	}
 	return block
 }
 
-func ShouldWriteValueToLSM(entry *pb.Entry) bool {
-	return entry.Meta&uint32(y.BitValuePointer) == 0 && len(entry.Value) <= 4*KB
+//compressLog returns block data, if content is compressed, mix.Compression and mix.CompressedSize
+//are set. Offsets in mix always point to uncompressed content
+func compressLog(mix *pspb.MixedLog, compression pspb.CompressionType, data []byte, content []byte) []byte {
+	compressed, ok := y.Compress(compression, content)
+	if !ok {
+		return data
+	}
+	mix.Compression = compression
+	mix.CompressedSize = uint32(len(compressed))
+	blockLength := utils.Ceil(uint32(len(compressed)), 512)
+	ret := make([]byte, blockLength)
+	copy(ret, compressed)
+	return ret
 }
 
-//sort,merge into blocks
-func entriesToBlocks(entries []*pb.EntryInfo) ([]*pb.Block, int, int) {
+//sort,merge into blocks, if keys is not nil, key and value of each entry are encrypted.
+//Entries bigger than opt.ValueThreshold are written in their own blocks and marked by
+//BitValuePointer, so extent node does not depend on the threshold
+func entriesToBlocks(entries []*pb.EntryInfo, opt Options, compression pspb.CompressionType, keys *encryption.KeyRegistry) ([]*pb.Block, int, int) {
 
 	utils.AssertTrue(len(entries) != 0)
 
-	//sort
+	for i := range entries {
+		if !opt.ShouldWriteValueToLSM(entries[i].Log) {
+			entries[i].Log.Meta |= uint32(y.BitValuePointer)
+		}
+	}
+	//sort, big values are the last, encryption adds the same overhead to every value, so the
+	//order is not changed
+	isBig := func(e *pb.Entry) bool {
+		return e.Meta&uint32(y.BitValuePointer) > 0
+	}
 	sort.Slice(entries, func(i, j int) bool {
+		if bi, bj := isBig(entries[i].Log), isBig(entries[j].Log); bi != bj {
+			return bj
+		}
 		return len(entries[i].Log.Value) < len(entries[j].Log.Value)
 	})
 
+	//entries written to log
+	logs := make([]*pb.Entry, len(entries))
+	for i := range entries {
+		logs[i] = entries[i].Log
+		if keys != nil {
+			var err error
+			logs[i], err = keys.EncryptEntry(entries[i].Log)
+			utils.Check(err)
+		}
+	}
+	//ciphertext is not compressible
+	if keys != nil {
+		compression = pspb.CompressionType_None
+	}
+
 	var blocks []*pb.Block
 	var mblock *mixedBlock = nil
 	i := 0
 
 	//merge small reqs into block
 	for ; i < len(entries); i++ {
-		if !y.ShouldWriteValueToLSM(entries[i].Log) {
+		if isBig(logs[i]) {
 			break
 		}
 		if mblock == nil {
-			mblock = NewMixedBlock()
+			mblock = NewMixedBlock(opt.MaxMixedBlockSize)
 		}
-		if !mblock.CanFill(entries[i].Log) {
-			blocks = append(blocks, mblock.ToBlock())
-			mblock = NewMixedBlock()
+		if !mblock.CanFill(logs[i], opt.MaxEntriesInBlock) {
+			blocks = append(blocks, mblock.ToBlock(compression))
+			mblock = NewMixedBlock(opt.MaxMixedBlockSize)
 		}
-		mblock.Fill(entries[i].Log)
+		mblock.Fill(logs[i])
 	}
 
 	if mblock != nil {
-		blocks = append(blocks, mblock.ToBlock())
+		blocks = append(blocks, mblock.ToBlock(compression))
 	}
 
 	j := i           //j is start of Value Block
 	k := len(blocks) //k is start of Value block
 
 	for ; i < len(entries); i++ {
-		blockLength := utils.Ceil(uint32(entries[i].Log.Size()), 512)
+		blockLength := utils.Ceil(uint32(logs[i].Size()), 512)
 		data := make([]byte, blockLength)
-		entries[i].Log.MarshalTo(data)
+		logs[i].MarshalTo(data)
 		var mix pspb.MixedLog
-		mix.Offsets = []uint32{0, uint32(entries[i].Log.Size())}
+		mix.Offsets = []uint32{0, uint32(logs[i].Size())}
+		data = compressLog(&mix, compression, data, data[:logs[i].Size()])
 		blockUserData, err := mix.Marshal()
 		utils.Check(err)
 
 		blocks = append(blocks, &pb.Block{
-			BlockLength: blockLength,
+			BlockLength: uint32(len(data)),
 			UserData:    blockUserData,
 			Data:        data,
 			CheckSum:    utils.AdlerCheckSum(data),
@@ -9,6 +9,8 @@ import (
 
 	"github.com/journeymidnight/autumn/extent"
 	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
 	"github.com/journeymidnight/autumn/utils"
 	"github.com/pkg/errors"
 )
@@ -24,6 +26,10 @@ type MockStreamClient struct {
 	names           []string
 	ID              uint64
 	suffix          string
+	compression     pspb.CompressionType
+	keys            *encryption.KeyRegistry
+	maxExtentSize   uint32
+	opt             Options
 }
 
 /*
@@ -51,9 +57,11 @@ func NewMockStreamClient(suffix string) StreamClient {
 	ex, err := extent.CreateExtent(name, sID)
 	utils.Check(err)
 	return &MockStreamClient{
-		exs:    []*extent.Extent{ex},
-		ID:     sID,
-		suffix: suffix,
+		exs:           []*extent.Extent{ex},
+		ID:            sID,
+		suffix:        suffix,
+		maxExtentSize: uint32(testThreshold),
+		opt:           DefaultOptions(),
 	}
 }
 
@@ -69,9 +77,11 @@ func OpenMockStreamClient(si pb.StreamInfo) StreamClient {
 
 	}
 	return &MockStreamClient{
-		exs:    exs,
-		ID:     sID,
-		suffix: "log",
+		exs:           exs,
+		ID:            sID,
+		suffix:        "log",
+		maxExtentSize: uint32(testThreshold),
+		opt:           DefaultOptions(),
 	}
 }
 
@@ -80,13 +90,19 @@ func UpdateStreamMock([]pb.StreamInfo) {
 }
 
 func (client *MockStreamClient) Truncate(ctx context.Context, extentID uint64) (pb.StreamInfo, pb.StreamInfo, error) {
+	client.Lock()
+	defer client.Unlock()
 
-	var i int
-	for i = range client.exs {
-		if client.exs[i].ID == extentID {
+	i := -1
+	for j := range client.exs {
+		if client.exs[j].ID == extentID {
+			i = j
 			break
 		}
 	}
+	if i < 0 {
+		return pb.StreamInfo{}, pb.StreamInfo{}, errors.Errorf("extent %d is not in stream %d", extentID, client.ID)
+	}
 	if i == 0 {
 		return pb.StreamInfo{}, pb.StreamInfo{}, errNoTrucate
 	}
@@ -112,13 +128,39 @@ func (client *MockStreamClient) Truncate(ctx context.Context, extentID uint64) (
 	return blobStreamInfo, myStreamInfo, nil
 }
 
+func (client *MockStreamClient) SetCompression(ct pspb.CompressionType) {
+	client.compression = ct
+}
+
+func (client *MockStreamClient) SetEncryption(keys *encryption.KeyRegistry) {
+	client.keys = keys
+}
+
+func (client *MockStreamClient) SetMaxExtentSize(size uint32) {
+	client.maxExtentSize = size
+}
+
+func (client *MockStreamClient) SetOptions(opt Options) {
+	client.opt = opt.WithDefaults()
+}
+
+func (client *MockStreamClient) StreamInfo() pb.StreamInfo {
+	client.RLock()
+	defer client.RUnlock()
+	si := pb.StreamInfo{StreamID: client.ID}
+	for _, ex := range client.exs {
+		si.ExtentIDs = append(si.ExtentIDs, ex.ID)
+	}
+	return si
+}
+
 //block API, entries has been batched
 func (client *MockStreamClient) AppendEntries(ctx context.Context, entries []*pb.EntryInfo) (uint64, uint32, error) {
 	//exID := len(client.exs) - 1
 	//ex := client.exs[exID]
 	//ex.Lock()
 	//commitLength := ex.CommitLength()
-	blocks, j, k := entriesToBlocks(entries)
+	blocks, j, k := entriesToBlocks(entries, client.opt, client.compression, client.keys)
 	//defer ex.Unlock()
 	exID, offsets, err := client.Append(ctx, blocks)
 	//offsets, err := ex.AppendBlocks(blocks, nil)
@@ -137,14 +179,16 @@ func (client *MockStreamClient) AppendEntries(ctx context.Context, entries []*pb
 
 //block API
 func (client *MockStreamClient) Append(ctx context.Context, blocks []*pb.Block) (uint64, []uint32, error) {
-	exIndex := len(client.exs) - 1
-	exID := client.exs[exIndex].ID
-	ex := client.exs[exIndex]
+	//exs may be truncated
+	client.RLock()
+	ex := client.exs[len(client.exs)-1]
+	client.RUnlock()
+	exID := ex.ID
 	commitLength := ex.CommitLength()
 	ex.Lock()
 	offsets, err := ex.AppendBlocks(blocks, &commitLength)
 	ex.Unlock()
-	if ex.CommitLength() > uint32(testThreshold) {
+	if ex.CommitLength() > client.maxExtentSize {
 		//seal
 		ex.Seal(ex.CommitLength())
 		//create new
@@ -196,6 +240,28 @@ func (client *MockStreamClient) Read(ctx context.Context, extentID uint64, offse
 	return blocks, err
 }
 
+//MockBlockReader reads blocks of extents in any of the mock streams
+type MockBlockReader []StreamClient
+
+func (r MockBlockReader) Read(ctx context.Context, extentID uint64, offset uint32, numOfBlocks uint32) ([]*pb.Block, error) {
+	for _, sc := range r {
+		client := sc.(*MockStreamClient)
+		client.RLock()
+		found := false
+		for _, ex := range client.exs {
+			if ex.ID == extentID {
+				found = true
+				break
+			}
+		}
+		client.RUnlock()
+		if found {
+			return client.Read(ctx, extentID, offset, numOfBlocks)
+		}
+	}
+	return nil, errors.New("extentID not good")
+}
+
 func (client *MockStreamClient) NewLogEntryIter(opt ReadOption) LogEntryIter {
 
 	x := &MockLockEntryIter{
@@ -208,11 +274,13 @@ func (client *MockStreamClient) NewLogEntryIter(opt ReadOption) LogEntryIter {
 	} else {
 		x.currentOffset = opt.Offset
 
+		client.RLock()
 		for i := range client.exs {
 			if client.exs[i].ID == opt.ExtentID {
 				x.currentIndex = i
 			}
 		}
+		client.RUnlock()
 
 	}
 	if opt.Replay {
@@ -246,7 +314,10 @@ func (iter *MockLockEntryIter) HasNext() (bool, error) {
 }
 
 func (iter *MockLockEntryIter) receiveEntries() error {
+	iter.sc.RLock()
 	ex := iter.sc.exs[iter.currentIndex]
+	n := len(iter.sc.exs)
+	iter.sc.RUnlock()
 
 	res, tail, err := ex.ReadEntries(iter.currentOffset, 16*KB, iter.replay)
 
@@ -261,7 +332,7 @@ func (iter *MockLockEntryIter) receiveEntries() error {
 	case extent.EndOfExtent:
 		iter.currentOffset = 512 //skip extent header
 		iter.currentIndex++
-		if iter.currentIndex == len(iter.sc.exs) {
+		if iter.currentIndex == n {
 			iter.noMore = true
 		}
 		return nil
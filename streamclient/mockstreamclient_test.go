@@ -200,3 +200,59 @@ func TestSplitExtent(t *testing.T) {
 	}
 
 }
+
+func TestHedgedRead(t *testing.T) {
+	lt := newLatencyTracker()
+	blocks := []*pb.Block{newTestBlock(512), newTestBlock(512)}
+	read := func(ctx context.Context, addr string) (*pb.ReadBlocksResponse, error) {
+		switch addr {
+		case "slow":
+			select {
+			case <-time.After(5 * time.Second):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		case "dead":
+			return nil, fmt.Errorf("connection refused")
+		case "lagging":
+			return &pb.ReadBlocksResponse{Code: pb.Code_EndOfStream, Blocks: blocks[:1]}, nil
+		}
+		return &pb.ReadBlocksResponse{Code: pb.Code_OK, Blocks: blocks}, nil
+	}
+
+	//hedge to the next replica if the first one is slow
+	start := time.Now()
+	bs, err := hedgedRead(context.Background(), lt, []string{"slow", "fast"}, 2, 10*time.Millisecond, read)
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(bs))
+	assert.True(t, time.Since(start) < time.Second)
+
+	//failover on error and incomplete blocks
+	bs, err = hedgedRead(context.Background(), lt, []string{"dead", "lagging", "fast"}, 2, time.Second, read)
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(bs))
+
+	//return the longest if all replicas are incomplete
+	bs, err = hedgedRead(context.Background(), lt, []string{"dead", "lagging"}, 2, time.Second, read)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(bs))
+
+	_, err = hedgedRead(context.Background(), lt, []string{"dead"}, 2, time.Second, read)
+	assert.NotNil(t, err)
+
+	//failed and unhealthy replicas are tried last
+	healthy := func(addr string) bool { return addr != "down" }
+	order := lt.order([]string{"down", "dead", "lagging", "fast"}, healthy)
+	assert.Equal(t, []string{"dead", "down"}, order[2:])
+}
+
+func TestShouldRefreshTail(t *testing.T) {
+	em := NewAutomnExtentManager(nil)
+	assert.True(t, em.shouldRefreshTail(100))
+	assert.False(t, em.shouldRefreshTail(100))
+	assert.True(t, em.shouldRefreshTail(101))
+
+	//sealed info clears the record
+	em.SetExtentInfo(100, &pb.ExtentInfo{ExtentID: 100, SealSize: 4096})
+	assert.True(t, em.shouldRefreshTail(100))
+}
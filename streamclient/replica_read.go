@@ -0,0 +1,297 @@
+package streamclient
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/journeymidnight/autumn/conn"
+	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/pkg/errors"
+)
+
+/*
+读副本流程:
+1. 按照健康状态和延迟给extent的副本排序: pool healthy的在前, 最近失败过的在后, 然后按照EWMA延迟从小到大
+2. 先读第一个副本, 如果超过hedgeDelay(最近读延迟的p95)还没有返回, 就同时读下一个副本, 先返回的结果生效, 其他请求被cancel
+3. 如果读出错, 或者返回的blocks不完整(EndOfStream/EndOfExtent但是blocks比请求的少), 立即读下一个副本
+4. 所有副本都不完整时, 返回blocks最多的结果; 都出错时返回最后一个错误
+5. 读sealed extent时带上ExtentInfo.SealSize, node只返回SealSize之前的block. 缓存的ExtentInfo可能是seal之前的(SealSize为0),
+所以allocNewExtent之后刷新旧extent的ExtentInfo, 读到副本末尾(blocks比请求的少)时也从sm刷新, 如果已经seal, 带上SealSize重新读.
+tail read经常读到没有seal的extent末尾, 每个extent最多每tailRefreshInterval刷新一次, 避免每次读都访问sm
+*/
+
+const (
+	//number of recent reads used to compute hedge delay
+	latencySamples = 256
+	//use defaultHedgeDelay until there are enough samples
+	minLatencySamples = 32
+	hedgePercentile   = 0.95
+	defaultHedgeDelay = 50 * time.Millisecond
+	minHedgeDelay     = 2 * time.Millisecond
+	maxHedgeDelay     = time.Second
+	//a replica failed within failurePenalty is tried after others
+	failurePenalty = 5 * time.Second
+	ewmaWeight     = 0.2
+	//min interval of refreshing info of an unsealed extent when its end is reached
+	tailRefreshInterval = time.Second
+)
+
+type nodeLatency struct {
+	ewma        float64 //nanoseconds, 0 means unknown
+	lastFailure time.Time
+}
+
+//latencyTracker records read latencies of extent nodes
+type latencyTracker struct {
+	sync.Mutex
+	nodes   map[string]*nodeLatency
+	samples [latencySamples]time.Duration
+	n       int //total number of samples
+}
+
+func newLatencyTracker() *latencyTracker {
+	return &latencyTracker{
+		nodes: make(map[string]*nodeLatency),
+	}
+}
+
+func (lt *latencyTracker) node(addr string) *nodeLatency {
+	nl, ok := lt.nodes[addr]
+	if !ok {
+		nl = &nodeLatency{}
+		lt.nodes[addr] = nl
+	}
+	return nl
+}
+
+func (lt *latencyTracker) recordSuccess(addr string, d time.Duration) {
+	lt.Lock()
+	defer lt.Unlock()
+	nl := lt.node(addr)
+	if nl.ewma == 0 {
+		nl.ewma = float64(d)
+	} else {
+		nl.ewma = nl.ewma*(1-ewmaWeight) + float64(d)*ewmaWeight
+	}
+	nl.lastFailure = time.Time{}
+	lt.samples[lt.n%latencySamples] = d
+	lt.n++
+}
+
+func (lt *latencyTracker) recordFailure(addr string) {
+	lt.Lock()
+	defer lt.Unlock()
+	lt.node(addr).lastFailure = time.Now()
+}
+
+//hedgeDelay returns the p95 of recent read latencies
+func (lt *latencyTracker) hedgeDelay() time.Duration {
+	lt.Lock()
+	if lt.n < minLatencySamples {
+		lt.Unlock()
+		return defaultHedgeDelay
+	}
+	size := lt.n
+	if size > latencySamples {
+		size = latencySamples
+	}
+	samples := make([]time.Duration, size)
+	copy(samples, lt.samples[:size])
+	lt.Unlock()
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	d := samples[int(float64(size-1)*hedgePercentile)]
+	if d < minHedgeDelay {
+		return minHedgeDelay
+	}
+	if d > maxHedgeDelay {
+		return maxHedgeDelay
+	}
+	return d
+}
+
+//order sorts addrs by health and latency, healthy(addr) tells if the connection is alive
+func (lt *latencyTracker) order(addrs []string, healthy func(string) bool) []string {
+	type replica struct {
+		addr string
+		rank int
+		ewma float64
+	}
+	now := time.Now()
+	replicas := make([]replica, len(addrs))
+	lt.Lock()
+	for i, addr := range addrs {
+		r := replica{addr: addr}
+		if nl, ok := lt.nodes[addr]; ok {
+			r.ewma = nl.ewma
+			if now.Sub(nl.lastFailure) < failurePenalty {
+				r.rank = 1
+			}
+		}
+		replicas[i] = r
+	}
+	lt.Unlock()
+	for i := range replicas {
+		if !healthy(replicas[i].addr) {
+			replicas[i].rank = 2
+		}
+	}
+	//keep the order of replicates if latency is unknown
+	sort.SliceStable(replicas, func(i, j int) bool {
+		if replicas[i].rank != replicas[j].rank {
+			return replicas[i].rank < replicas[j].rank
+		}
+		return replicas[i].ewma < replicas[j].ewma
+	})
+	ret := make([]string, len(replicas))
+	for i := range replicas {
+		ret[i] = replicas[i].addr
+	}
+	return ret
+}
+
+type readFunc func(ctx context.Context, addr string) (*pb.ReadBlocksResponse, error)
+
+type readResult struct {
+	addr string
+	res  *pb.ReadBlocksResponse
+	err  error
+}
+
+//complete returns false if the replica returned less blocks than requested, which means
+//the replica may be lagging behind others
+func (r readResult) complete(numOfBlocks uint32) bool {
+	return r.res.Code == pb.Code_OK || uint32(len(r.res.Blocks)) >= numOfBlocks
+}
+
+//hedgedRead reads replicas in the order of addrs, the next replica is read if the previous one
+//fails, returns incomplete blocks, or does not return in delay
+func hedgedRead(ctx context.Context, lt *latencyTracker, addrs []string, numOfBlocks uint32, delay time.Duration, read readFunc) ([]*pb.Block, error) {
+	if len(addrs) == 0 {
+		return nil, errors.Errorf("no replica")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	//buffered, so goroutines never block after we return
+	results := make(chan readResult, len(addrs))
+	next := 0
+	inflight := 0
+	launch := func() {
+		addr := addrs[next]
+		next++
+		inflight++
+		go func() {
+			start := time.Now()
+			res, err := read(ctx, addr)
+			if err == nil && res == nil {
+				err = errors.Errorf("empty response from %s", addr)
+			}
+			if err == nil {
+				lt.recordSuccess(addr, time.Since(start))
+			} else if ctx.Err() == nil {
+				lt.recordFailure(addr)
+			}
+			results <- readResult{addr: addr, res: res, err: err}
+		}()
+	}
+
+	var best *pb.ReadBlocksResponse
+	var lastErr error
+	launch()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for inflight > 0 {
+		select {
+		case <-timer.C:
+			//hedge
+			if next < len(addrs) {
+				launch()
+				timer.Reset(delay)
+			}
+		case r := <-results:
+			inflight--
+			if r.err == nil && r.complete(numOfBlocks) {
+				return r.res.Blocks, nil
+			}
+			if r.err != nil {
+				lastErr = errors.Wrapf(r.err, "read from %s", r.addr)
+			} else if best == nil || len(r.res.Blocks) > len(best.Blocks) {
+				best = r.res
+			}
+			//failover
+			if next < len(addrs) {
+				launch()
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(delay)
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if best != nil {
+		return best.Blocks, nil
+	}
+	return nil, lastErr
+}
+
+func isHealthy(addr string) bool {
+	pool, err := conn.GetPools().Get(addr)
+	return err == nil && pool.IsHealthy()
+}
+
+//ReadBlocks reads blocks from replicas of extentID, see 读副本流程
+func (em *AutumnExtentManager) ReadBlocks(ctx context.Context, extentID uint64, offset uint32, numOfBlocks uint32) ([]*pb.Block, error) {
+	info := em.GetExtentInfo(extentID)
+	blocks, err := em.readBlocks(ctx, extentID, offset, numOfBlocks, uint32(info.SealSize))
+	if err != nil || info.SealSize > 0 || uint32(len(blocks)) >= numOfBlocks {
+		return blocks, err
+	}
+	//the end of replica is reached, info could be cached before the extent was sealed,
+	//an unreconciled replica could be longer than SealSize, so refresh it and read again
+	if !em.shouldRefreshTail(extentID) {
+		return blocks, nil
+	}
+	newInfo, rerr := em.RefreshExtentInfo(ctx, extentID)
+	if rerr != nil || newInfo.SealSize == 0 {
+		return blocks, nil
+	}
+	return em.readBlocks(ctx, extentID, offset, numOfBlocks, uint32(newInfo.SealSize))
+}
+
+//shouldRefreshTail returns true if info of unsealed extentID was not refreshed in tailRefreshInterval
+func (em *AutumnExtentManager) shouldRefreshTail(extentID uint64) bool {
+	em.Lock()
+	defer em.Unlock()
+	now := time.Now()
+	if last, ok := em.tailRefreshed[extentID]; ok && now.Sub(last) < tailRefreshInterval {
+		return false
+	}
+	em.tailRefreshed[extentID] = now
+	return true
+}
+
+func (em *AutumnExtentManager) readBlocks(ctx context.Context, extentID uint64, offset uint32, numOfBlocks uint32, sealSize uint32) ([]*pb.Block, error) {
+	addrs := em.latency.order(em.GetPeers(extentID), isHealthy)
+	req := &pb.ReadBlocksRequest{
+		ExtentID:    extentID,
+		Offset:      offset,
+		NumOfBlocks: numOfBlocks,
+		SealSize:    sealSize,
+	}
+	return hedgedRead(ctx, em.latency, addrs, numOfBlocks, em.latency.hedgeDelay(),
+		func(ctx context.Context, addr string) (*pb.ReadBlocksResponse, error) {
+			pool := conn.GetPools().Connect(addr)
+			if pool == nil {
+				return nil, errors.Errorf("can not connect to %s", addr)
+			}
+			return pb.NewExtentServiceClient(pool.Get()).ReadBlocks(ctx, req)
+		})
+}
@@ -11,6 +11,9 @@ import (
 	"github.com/journeymidnight/autumn/conn"
 	"github.com/journeymidnight/autumn/manager/smclient"
 	"github.com/journeymidnight/autumn/proto/pb"
+	"github.com/journeymidnight/autumn/proto/pspb"
+	"github.com/journeymidnight/autumn/rangepartition/encryption"
+	"github.com/journeymidnight/autumn/rangepartition/y"
 	"github.com/journeymidnight/autumn/utils"
 	"github.com/journeymidnight/autumn/xlog"
 	"github.com/pkg/errors"
@@ -39,14 +42,20 @@ against a replica sealed by the SM
 */
 
 const (
-	KB             = 1024
-	MB             = 1024 * KB
-	GB             = 1024 * MB
-	ValueThreshold = 4 * KB
+	KB = 1024
+	MB = 1024 * KB
+	GB = 1024 * MB
 
+	//defaults of Options
 	MaxMixedBlockSize = 4 * KB
 	MaxExtentSize     = 2 * GB
 	MaxEntriesInBlock = 100
+
+	//retry times of append at the same offset before sealing the extent
+	MaxAppendRetry = 3
+	//times of allocating new extent in one append, and times of calling StreamAllocExtent
+	//in one allocation
+	MaxAllocRetry = 3
 )
 
 type StreamClient interface {
@@ -57,9 +66,61 @@ type StreamClient interface {
 	NewLogEntryIter(opt ReadOption) LogEntryIter
 	Read(ctx context.Context, extentID uint64, offset uint32, numOfBlocks uint32) ([]*pb.Block, error)
 	Truncate(ctx context.Context, extentID uint64) (pb.StreamInfo, pb.StreamInfo, error)
+	//SetCompression sets compression of blocks written by AppendEntries
+	SetCompression(ct pspb.CompressionType)
+	//SetEncryption sets data keys of entries written by AppendEntries, nil means no encryption
+	SetEncryption(keys *encryption.KeyRegistry)
+	//SetMaxExtentSize sets the size limit of extents, a new extent is allocated before the
+	//last extent exceeds it
+	SetMaxExtentSize(size uint32)
+	//SetOptions sets layout of blocks written by AppendEntries, zero fields are taken from
+	//DefaultOptions
+	SetOptions(opt Options)
+	//StreamInfo returns a copy of the stream ID and extents
+	StreamInfo() pb.StreamInfo
 	//FIXME: stat => ([]extentID , offset)
 }
 
+//Options of blocks written by AppendEntries
+type Options struct {
+	//values bigger than ValueThreshold are written in their own blocks, smaller ones are
+	//merged into mixed blocks
+	ValueThreshold uint32
+	//size of a mixed block, a bigger entry has a mixed block of its own
+	MaxMixedBlockSize uint32
+	//max number of entries in a mixed block
+	MaxEntriesInBlock int
+}
+
+func DefaultOptions() Options {
+	return Options{
+		ValueThreshold:    y.ValueThrottle,
+		MaxMixedBlockSize: MaxMixedBlockSize,
+		MaxEntriesInBlock: MaxEntriesInBlock,
+	}
+}
+
+//WithDefaults returns opt whose zero fields are taken from DefaultOptions
+func (opt Options) WithDefaults() Options {
+	def := DefaultOptions()
+	if opt.ValueThreshold == 0 {
+		opt.ValueThreshold = def.ValueThreshold
+	}
+	if opt.MaxMixedBlockSize == 0 {
+		opt.MaxMixedBlockSize = def.MaxMixedBlockSize
+	}
+	if opt.MaxEntriesInBlock == 0 {
+		opt.MaxEntriesInBlock = def.MaxEntriesInBlock
+	}
+	return opt
+}
+
+//ShouldWriteValueToLSM returns true if entry is merged into a mixed block, otherwise it is
+//written in its own block and its value is read by valuePointer
+func (opt Options) ShouldWriteValueToLSM(e *pb.Entry) bool {
+	return e.Meta&uint32(y.BitValuePointer) == 0 && len(e.Value) <= int(opt.ValueThreshold)
+}
+
 //random read block
 type BlockReader interface {
 	Read(ctx context.Context, extentID uint64, offset uint32, numOfBlocks uint32) ([]*pb.Block, error)
@@ -83,29 +144,25 @@ func NewAutumnBlockReader(em *AutumnExtentManager, sm *smclient.SMClient) *Autum
 }
 
 func (br *AutumnBlockReader) Read(ctx context.Context, extentID uint64, offset uint32, numOfBlocks uint32) ([]*pb.Block, error) {
-	conn := br.em.GetExtentConn(extentID)
-	if conn == nil {
-		return nil, errors.Errorf("no such extent")
-	}
-	c := pb.NewExtentServiceClient(conn)
-	res, err := c.ReadBlocks(ctx, &pb.ReadBlocksRequest{
-		ExtentID:    extentID,
-		Offset:      offset,
-		NumOfBlocks: numOfBlocks,
-	})
-	return res.Blocks, err
+	return br.em.ReadBlocks(ctx, extentID, offset, numOfBlocks)
 }
 
 type AutumnExtentManager struct {
 	sync.RWMutex
 	smClient   *smclient.SMClient
 	extentInfo map[uint64]*pb.ExtentInfo
+	//read latency of extent nodes
+	latency *latencyTracker
+	//last time ReadBlocks refreshed info of unsealed extents, see 读副本流程
+	tailRefreshed map[uint64]time.Time
 }
 
 func NewAutomnExtentManager(sm *smclient.SMClient) *AutumnExtentManager {
 	return &AutumnExtentManager{
-		smClient:   sm,
-		extentInfo: make(map[uint64]*pb.ExtentInfo),
+		smClient:      sm,
+		extentInfo:    make(map[uint64]*pb.ExtentInfo),
+		latency:       newLatencyTracker(),
+		tailRefreshed: make(map[uint64]time.Time),
 	}
 }
 
@@ -122,11 +179,18 @@ func (em *AutumnExtentManager) GetPeers(extentID uint64) []string {
 	return ret
 }
 
-//always get alive node: FIXME:确保pool是healthy
+//GetExtentConn returns connection to the primary(Replicates[0]) which is required by append,
+//returns nil if it can not connect. Reads should use ReadBlocks, which picks replicas
 func (em *AutumnExtentManager) GetExtentConn(extentID uint64) *grpc.ClientConn {
 	extentInfo := em.GetExtentInfo(extentID)
 	nodeInfo := em.smClient.LookupNode(extentInfo.Replicates[0])
+	if nodeInfo == nil {
+		return nil
+	}
 	pool := conn.GetPools().Connect(nodeInfo.Address)
+	if pool == nil {
+		return nil
+	}
 	return pool.Get()
 }
 
@@ -154,10 +218,28 @@ func (em *AutumnExtentManager) GetExtentInfo(extentID uint64) *pb.ExtentInfo {
 	return info
 }
 
+//RefreshExtentInfo reloads ExtentInfo from sm, cached info of an extent which was sealed
+//after it was cached has no SealSize
+func (em *AutumnExtentManager) RefreshExtentInfo(ctx context.Context, extentID uint64) (*pb.ExtentInfo, error) {
+	m, err := em.smClient.ExtentInfo(ctx, []uint64{extentID})
+	if err != nil {
+		return nil, err
+	}
+	if m[extentID] == nil {
+		return nil, errors.Errorf("no such extent %d", extentID)
+	}
+	info := proto.Clone(m[extentID]).(*pb.ExtentInfo)
+	em.SetExtentInfo(extentID, info)
+	return info, nil
+}
+
 func (em *AutumnExtentManager) SetExtentInfo(extentID uint64, info *pb.ExtentInfo) {
 	em.Lock()
 	defer em.Unlock()
 	em.extentInfo[extentID] = info
+	if info.SealSize > 0 {
+		delete(em.tailRefreshed, extentID)
+	}
 }
 
 type ReadOption struct {
@@ -191,14 +273,24 @@ type AutumnStreamClient struct {
 	//extentInfo  map[uint64]*pb.ExtentInfo
 	em       *AutumnExtentManager
 	streamID uint64
+	//end of the last extent, 0 means unknown. Retried append is idempotent only if end is known
+	end uint32
+	//compression of log blocks
+	compression pspb.CompressionType
+	keys        *encryption.KeyRegistry
+	//size limit of extents in this stream
+	maxExtentSize uint32
+	opt           Options
 }
 
 func NewStreamClient(sm *smclient.SMClient, em *AutumnExtentManager, streamID uint64) *AutumnStreamClient {
 	utils.AssertTrue(xlog.Logger != nil)
 	return &AutumnStreamClient{
-		smClient: sm,
-		em:       em,
-		streamID: streamID,
+		smClient:      sm,
+		em:            em,
+		streamID:      streamID,
+		maxExtentSize: MaxExtentSize,
+		opt:           DefaultOptions(),
 	}
 }
 
@@ -315,24 +407,36 @@ func (sc *AutumnStreamClient) NewLogEntryIter(opt ReadOption) LogEntryIter {
 	return leIter
 }
 
+//Truncate removes extents before extentID from the stream, refs of removed extents are decreased
+//and they are deleted by stream manager when no stream uses them. Returns removed extents and
+//the new stream info
 func (sc *AutumnStreamClient) Truncate(ctx context.Context, extentID uint64) (pb.StreamInfo, pb.StreamInfo, error) {
 	sc.Lock()
 	defer sc.Unlock()
-	var i int
-	for i = range sc.streamInfo.ExtentIDs {
-		if sc.streamInfo.ExtentIDs[i] == extentID {
+	i := -1
+	for j := range sc.streamInfo.ExtentIDs {
+		if sc.streamInfo.ExtentIDs[j] == extentID {
+			i = j
 			break
 		}
 	}
+	if i < 0 {
+		return pb.StreamInfo{}, pb.StreamInfo{}, errors.Errorf("extent %d is not in stream %d", extentID, sc.streamID)
+	}
 	if i == 0 {
 		return pb.StreamInfo{}, pb.StreamInfo{}, errNoTrucate
 	}
-	/*
-		sc.streamInfo.ExtentIDs = sc.streamInfo.ExtentIDs[i:]
-		return sc.smClient.TruncateStream(ctx, sc.streamID, sc.streamInfo.ExtentIDs)
-	*/
-	//FIXME:
-	return pb.StreamInfo{}, pb.StreamInfo{}, errNoTrucate
+	if err := sc.smClient.TruncateStream(ctx, sc.streamID, extentID); err != nil {
+		return pb.StreamInfo{}, pb.StreamInfo{}, err
+	}
+	removed := pb.StreamInfo{
+		StreamID:  sc.streamID,
+		ExtentIDs: append([]uint64(nil), sc.streamInfo.ExtentIDs[:i]...),
+	}
+	newInfo := proto.Clone(sc.streamInfo).(*pb.StreamInfo)
+	newInfo.ExtentIDs = newInfo.ExtentIDs[i:]
+	sc.streamInfo = newInfo
+	return removed, *proto.Clone(newInfo).(*pb.StreamInfo), nil
 }
 
 func (sc *AutumnStreamClient) getExtentIndexFromID(extentID uint64) int {
@@ -373,23 +477,54 @@ func (sc *AutumnStreamClient) getLastExtentConn() (uint64, *grpc.ClientConn) {
 	return extentID, sc.em.GetExtentConn(extentID)
 }
 
-func (sc *AutumnStreamClient) mustAllocNewExtent(oldExtentID uint64) {
+//allocNewExtent seals oldExtentID and appends a new extent to the stream, returns error
+//after MaxAllocRetry failures or ctx is done
+func (sc *AutumnStreamClient) allocNewExtent(ctx context.Context, oldExtentID uint64) error {
 	var newExInfo *pb.ExtentInfo
 	var err error
-	for {
-		//loop forever to seal and alloc new extent
-		newExInfo, err = sc.smClient.StreamAllocExtent(context.Background(), sc.streamID, oldExtentID)
+	for loop := 0; ; loop++ {
+		newExInfo, err = sc.smClient.StreamAllocExtent(ctx, sc.streamID, oldExtentID)
 		if err == nil {
 			break
 		}
-		xlog.Logger.Warn(err.Error())
-		time.Sleep(100 * time.Millisecond)
+		xlog.Logger.Warnf("failed to alloc new extent for stream %d: %v", sc.streamID, err)
+		if loop+1 >= MaxAllocRetry {
+			return errors.Wrapf(err, "alloc new extent for stream %d", sc.streamID)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
 	}
 	sc.Lock()
 	sc.streamInfo.ExtentIDs = append(sc.streamInfo.ExtentIDs, newExInfo.ExtentID)
+	sc.end = 512 //skip extent header
 	sc.Unlock()
 
 	sc.em.SetExtentInfo(newExInfo.ExtentID, newExInfo)
+	//oldExtentID is sealed, reads need its SealSize
+	if _, err = sc.em.RefreshExtentInfo(ctx, oldExtentID); err != nil {
+		xlog.Logger.Warnf("failed to refresh extent %d: %v", oldExtentID, err)
+	}
+	return nil
+}
+
+//lastExtentSize returns the known end of the last extent, if it is unknown(after Connect),
+//asks the primary for commit length. Returns 0 if the size can not be decided
+func (sc *AutumnStreamClient) lastExtentSize(ctx context.Context, extentID uint64, end uint32, conn *grpc.ClientConn) uint32 {
+	if end != 0 || conn == nil {
+		return end
+	}
+	pctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+	res, err := pb.NewExtentServiceClient(conn).CommitLength(pctx, &pb.CommitLengthRequest{
+		ExtentID: extentID,
+	})
+	if err != nil || res.Code != pb.Code_OK {
+		return 0
+	}
+	return res.Length
 }
 
 func (sc *AutumnStreamClient) Connect() error {
@@ -399,7 +534,9 @@ func (sc *AutumnStreamClient) Connect() error {
 	if err != nil {
 		return err
 	}
+	sc.Lock()
 	sc.streamInfo = s[sc.streamID]
+	sc.Unlock()
 	return nil
 }
 
@@ -407,13 +544,39 @@ func (sc *AutumnStreamClient) Close() {
 
 }
 
+func (sc *AutumnStreamClient) SetCompression(ct pspb.CompressionType) {
+	sc.compression = ct
+}
+
+func (sc *AutumnStreamClient) SetEncryption(keys *encryption.KeyRegistry) {
+	sc.keys = keys
+}
+
+func (sc *AutumnStreamClient) SetMaxExtentSize(size uint32) {
+	sc.maxExtentSize = size
+}
+
+func (sc *AutumnStreamClient) SetOptions(opt Options) {
+	sc.opt = opt.WithDefaults()
+}
+
+func (sc *AutumnStreamClient) StreamInfo() pb.StreamInfo {
+	sc.RLock()
+	defer sc.RUnlock()
+	if sc.streamInfo == nil {
+		return pb.StreamInfo{StreamID: sc.streamID}
+	}
+	return *proto.Clone(sc.streamInfo).(*pb.StreamInfo)
+}
+
 //AppendEntries blocks until success
-//make all entries in the same extentID, and fill entires.
+//make all entries in the same extentID, and fill entires. Entries bigger than opt.ValueThreshold
+//are written in their own blocks, their Meta are marked by BitValuePointer
 func (sc *AutumnStreamClient) AppendEntries(ctx context.Context, entries []*pb.EntryInfo) (uint64, uint32, error) {
 	if len(entries) == 0 {
 		return 0, 0, errors.Errorf("blocks can not be nil")
 	}
-	blocks, j, k := entriesToBlocks(entries)
+	blocks, j, k := entriesToBlocks(entries, sc.opt, sc.compression, sc.keys)
 	exID, offsets, err := sc.Append(ctx, blocks)
 	if err != nil {
 		return 0, 0, err
@@ -428,40 +591,108 @@ func (sc *AutumnStreamClient) AppendEntries(ctx context.Context, entries []*pb.E
 }
 
 func (sc *AutumnStreamClient) Read(ctx context.Context, extentID uint64, offset uint32, numOfBlocks uint32) ([]*pb.Block, error) {
-	conn := sc.em.GetExtentConn(extentID)
-	if conn == nil {
-		return nil, errors.Errorf("no such extent")
+	return sc.em.ReadBlocks(ctx, extentID, offset, numOfBlocks)
+}
+
+func (sc *AutumnStreamClient) getLastExtentEnd(extentID uint64) uint32 {
+	sc.RLock()
+	defer sc.RUnlock()
+	if sc.streamInfo.ExtentIDs[len(sc.streamInfo.ExtentIDs)-1] != extentID {
+		return 0
+	}
+	return sc.end
+}
+
+func (sc *AutumnStreamClient) isQuorum() bool {
+	sc.RLock()
+	defer sc.RUnlock()
+	return sc.streamInfo.Quorum
+}
+
+func (sc *AutumnStreamClient) setLastExtentEnd(extentID uint64, end uint32) {
+	sc.Lock()
+	defer sc.Unlock()
+	if sc.streamInfo.ExtentIDs[len(sc.streamInfo.ExtentIDs)-1] == extentID {
+		sc.end = end
 	}
-	c := pb.NewExtentServiceClient(conn)
-	res, err := c.ReadBlocks(ctx, &pb.ReadBlocksRequest{
-		ExtentID:    extentID,
-		Offset:      offset,
-		NumOfBlocks: numOfBlocks,
-	})
-	return res.Blocks, err
 }
 
+/*
+Append流程:
+1. blocks写入之后如果会超过maxExtentSize, 先seal当前extent, 分配新的extent. 最后一个extent的长度不知道时
+(刚Connect), 先问primary的CommitLength
+2. append失败, 如果知道ExpectedOffset, 在同一个offset重试MaxAppendRetry次
+3. 还是失败, seal当前extent, 在新的extent上重试, 一次Append最多分配MaxAllocRetry次新的extent, 之后返回错误
+*/
 func (sc *AutumnStreamClient) Append(ctx context.Context, blocks []*pb.Block) (extentID uint64, offsets []uint32, err error) {
+	size := SizeOfBlocks(blocks)
+	if size+512 > sc.maxExtentSize {
+		return 0, nil, errors.Errorf("blocks of %d bytes can not fit in extent of %d bytes", size, sc.maxExtentSize)
+	}
+	loop := 0
+	allocs := 0
+	rotate := func(extentID uint64) error {
+		if allocs >= MaxAllocRetry {
+			return errors.Errorf("append to stream %d failed after %d new extents: %v", sc.streamID, allocs, err)
+		}
+		allocs++
+		loop = 0
+		return sc.allocNewExtent(ctx, extentID)
+	}
 retry:
 	extentID, conn := sc.getLastExtentConn()
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	c := pb.NewExtentServiceClient(conn)
-	res, err := c.Append(ctx, &pb.AppendRequest{
-		ExtentID: extentID,
-		Blocks:   blocks,
-		Peers:    sc.em.GetPeers(extentID), //sc.getPeers(extentID),
-	})
-	cancel()
-
-	//FIXME
-	if err == context.DeadlineExceeded { //timeout
-		sc.mustAllocNewExtent(extentID)
+	end := sc.getLastExtentEnd(extentID)
+
+	//rotate before the extent exceeds maxExtentSize
+	tail := sc.lastExtentSize(ctx, extentID, end, conn)
+	if end == 0 && tail != 0 {
+		//the commit length of primary is where blocks should be
+		sc.setLastExtentEnd(extentID, tail)
+		end = tail
+	}
+	if tail > 512 && uint64(tail)+uint64(size) > uint64(sc.maxExtentSize) {
+		xlog.Logger.Infof("extent %d of stream %d is full, size %d", extentID, sc.streamID, tail)
+		if err = rotate(extentID); err != nil {
+			return 0, nil, err
+		}
 		goto retry
 	}
-	//检查offset结果, 如果已经超过2GB, 调用StreamAllocExtent
-	if res.Offsets[len(res.Offsets)-1] > MaxExtentSize {
-		sc.mustAllocNewExtent(extentID)
+
+	var res *pb.AppendResponse
+	if conn == nil {
+		err = errors.Errorf("no connection to extent %d", extentID)
+	} else {
+		pctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		c := pb.NewExtentServiceClient(conn)
+		res, err = c.Append(pctx, &pb.AppendRequest{
+			ExtentID:       extentID,
+			Blocks:         blocks,
+			Peers:          sc.em.GetPeers(extentID), //sc.getPeers(extentID),
+			ExpectedOffset: end,
+			Quorum:         sc.isQuorum(),
+		})
+		cancel()
+	}
+
+	if err != nil {
+		xlog.Logger.Warnf("append to extent %d at %d failed: %v", extentID, end, err)
+		if ctx.Err() != nil {
+			return 0, nil, ctx.Err()
+		}
+		//if we know where the blocks should be, retry at the same offset.
+		//extent node returns the same offsets if blocks have been appended
+		if end != 0 && loop < MaxAppendRetry && conn != nil {
+			loop++
+			time.Sleep(time.Duration(loop*100) * time.Millisecond)
+			goto retry
+		}
+		if e := rotate(extentID); e != nil {
+			return 0, nil, e
+		}
+		goto retry
 	}
+	last := len(res.Offsets) - 1
+	sc.setLastExtentEnd(extentID, res.Offsets[last]+blocks[last].BlockLength+512)
 	return extentID, res.Offsets, nil
 
 }